@@ -49,6 +49,7 @@ func init() {
 	// Query: Get a single user by ID
 	fraiseql.NewQuery("user").
 		ReturnType(User{}).
+		Single().
 		Config(map[string]interface{}{
 			"sql_source": "v_user",
 		}).
@@ -56,6 +56,7 @@ func init() {
 
 	fraiseql.NewQuery("user").
 		ReturnType(User{}).
+		Single().
 		Config(map[string]interface{}{
 			"sql_source": "v_user",
 		}).
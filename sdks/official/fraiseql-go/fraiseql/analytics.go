@@ -1,5 +1,12 @@
 package fraiseql
 
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
 // FactTableBuilder provides a fluent interface for building fact table definitions.
 type FactTableBuilder struct {
 	name        string
@@ -7,6 +14,15 @@ type FactTableBuilder struct {
 	measures    []string
 	dimensions  []map[string]interface{}
 	description string
+
+	// err records a builder error (e.g. an invalid NewFactTableFromType tag),
+	// reported by Register.
+	err error
+}
+
+// measureAggregations are the aggregation functions a measure may declare.
+var measureAggregations = map[string]bool{
+	"sum": true, "avg": true, "count": true, "max": true, "min": true,
 }
 
 // NewFactTable creates a new fact table builder with the given logical name.
@@ -18,6 +34,113 @@ func NewFactTable(name string) *FactTableBuilder {
 	}
 }
 
+// NewFactTableFromType creates a fact table builder whose measures and
+// dimensions are read from a struct's fraiseql tags, so the same struct can be
+// registered as a type and back the fact table:
+//
+//	type Sale struct {
+//	    Revenue  float64 `fraiseql:"revenue,type=Float,measure=sum;avg"`
+//	    Category string  `fraiseql:"category,type=String,dimension=text"`
+//	}
+//
+//	fraiseql.NewFactTableFromType("sales", Sale{}).TableName("tf_sales").Register()
+//
+// A `measure=` field must be numeric (an integer or float Go type, or typed
+// Int, Float or Decimal) and list aggregations from sum, avg, count, max and
+// min. A `dimension=` field gives the dimension's data type; its expression
+// reads the field from the fact table's JSONB data column (data->>'name').
+// Tag errors are reported by Register.
+func NewFactTableFromType(name string, structValue interface{}) *FactTableBuilder {
+	b := NewFactTable(name)
+	b.err = b.addTaggedColumns(reflect.TypeOf(structValue))
+	return b
+}
+
+// addTaggedColumns adds the measures and dimensions declared by struct tags.
+func (b *FactTableBuilder) addTaggedColumns(structType reflect.Type) error {
+	if structType == nil {
+		return fmt.Errorf("expected struct type, got nil")
+	}
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("expected struct type, got %v", structType.Kind())
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, ok := field.Tag.Lookup("fraiseql")
+		if !ok || !field.IsExported() || field.Anonymous {
+			continue
+		}
+		measure, hasMeasure := tagOption(tag, "measure")
+		dimension, hasDimension := tagOption(tag, "dimension")
+		if !hasMeasure && !hasDimension {
+			continue
+		}
+
+		info, err := parseFieldTag(tag, field.Name, field.Type)
+		if err != nil {
+			return fmt.Errorf("invalid tag for field %s: %w", field.Name, err)
+		}
+
+		if hasMeasure {
+			if !isNumericField(field.Type, info.Type) {
+				return fmt.Errorf("measure field %q has non-numeric type %q", info.Name, info.Type)
+			}
+			var aggregations []string
+			for _, agg := range strings.Split(measure, ";") {
+				agg = strings.TrimSpace(agg)
+				if !measureAggregations[agg] {
+					return fmt.Errorf(
+						"measure field %q has unknown aggregation %q (must be one of sum, avg, count, max, min)",
+						info.Name, agg,
+					)
+				}
+				aggregations = append(aggregations, agg)
+			}
+			b.Measure(info.Name, aggregations...)
+		}
+		if hasDimension {
+			if dimension == "" {
+				return fmt.Errorf("dimension field %q has an empty data type", info.Name)
+			}
+			b.Dimension(info.Name, fmt.Sprintf("data->>'%s'", info.Name), dimension)
+		}
+	}
+	return nil
+}
+
+// tagOption returns the value of a key=value option in a fraiseql tag.
+func tagOption(tag, key string) (string, bool) {
+	for _, part := range strings.Split(tag, ",") {
+		k, v, found := strings.Cut(strings.TrimSpace(part), "=")
+		if found && strings.TrimSpace(k) == key {
+			return strings.TrimSpace(v), true
+		}
+	}
+	return "", false
+}
+
+// isNumericField reports whether a field can be aggregated as a measure.
+func isNumericField(goType reflect.Type, graphQLType string) bool {
+	switch graphQLType {
+	case "Int", "Float", "Decimal":
+		return true
+	}
+	if goType.Kind() == reflect.Pointer {
+		goType = goType.Elem()
+	}
+	switch goType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
 // TableName sets the underlying database table name for this fact table.
 func (b *FactTableBuilder) TableName(name string) *FactTableBuilder {
 	b.tableName = name
@@ -42,6 +165,21 @@ func (b *FactTableBuilder) Dimension(name, expression, dataType string) *FactTab
 	return b
 }
 
+// CompositeDimension adds a dimension spanning several source paths (e.g.
+// country and region) so the compiler groups by their combination. At least
+// two paths are required; Register reports an error otherwise.
+func (b *FactTableBuilder) CompositeDimension(name string, paths []string, dataType string) *FactTableBuilder {
+	if len(paths) < 2 && b.err == nil {
+		b.err = fmt.Errorf("composite dimension %q needs at least two paths, got %d", name, len(paths))
+	}
+	b.dimensions = append(b.dimensions, map[string]interface{}{
+		"name":      name,
+		"paths":     append([]string(nil), paths...),
+		"data_type": dataType,
+	})
+	return b
+}
+
 // Description sets a human-readable description for this fact table.
 func (b *FactTableBuilder) Description(desc string) *FactTableBuilder {
 	b.description = desc
@@ -49,8 +187,13 @@ func (b *FactTableBuilder) Description(desc string) *FactTableBuilder {
 }
 
 // Register registers the fact table with the global schema registry.
-// Returns an error if a fact table with the same name is already registered.
+// Returns an error if a fact table with the same name is already registered,
+// or if the builder was misconfigured (invalid NewFactTableFromType tags, or a
+// CompositeDimension with fewer than two paths).
 func (b *FactTableBuilder) Register() error {
+	if b.err != nil {
+		return fmt.Errorf("fact table %q: %w", b.name, b.err)
+	}
 	return RegisterFactTable(FactTableDefinition{
 		Name:           b.name,
 		TableName:      b.tableName,
@@ -68,8 +211,15 @@ type AggregateQueryBuilder struct {
 	autoAggregates bool
 	description    string
 	config         map[string]interface{}
+	granularities  []string
 }
 
+// timeGranularities are the time buckets an aggregate query can roll up to.
+var timeGranularities = []string{"second", "minute", "hour", "day", "week", "month", "quarter", "year"}
+
+// granularityArgName is the argument Granularities adds.
+const granularityArgName = "granularity"
+
 // NewAggregateQueryConfig creates a new aggregate query builder with the given name.
 func NewAggregateQueryConfig(name string) *AggregateQueryBuilder {
 	return &AggregateQueryBuilder{
@@ -102,15 +252,132 @@ func (b *AggregateQueryBuilder) Description(desc string) *AggregateQueryBuilder
 	return b
 }
 
-// Register registers the aggregate query with the global schema registry.
-// Returns an error if an aggregate query with the same name is already registered.
+// Granularities lets clients pick the time bucket the query rolls up to, so
+// one aggregate query serves daily, monthly and quarterly reports alike. It
+// generates an enum named after the query (e.g. SalesOverTimeGranularity for
+// salesOverTime) whose members are the granularities in upper case, adds a
+// nullable "granularity" argument of that enum defaulting to the first
+// granularity listed, and exports the allowed set as the "granularities"
+// config key. Each must be one of second, minute, hour, day, week, month,
+// quarter or year.
+//
+// Example:
+//
+//	fraiseql.NewAggregateQueryConfig("salesOverTime").
+//		FactTableName("sales").
+//		Granularities("day", "month", "quarter").
+//		Register()
+func (b *AggregateQueryBuilder) Granularities(granularities ...string) *AggregateQueryBuilder {
+	b.granularities = granularities
+	if b.granularities == nil {
+		b.granularities = []string{}
+	}
+	return b
+}
+
+// validateGranularities checks the Granularities values, if any were set.
+func (b *AggregateQueryBuilder) validateGranularities() error {
+	if b.granularities == nil {
+		return nil
+	}
+	if len(b.granularities) == 0 {
+		return fmt.Errorf("aggregate query %q: Granularities needs at least one granularity", b.name)
+	}
+	seen := make(map[string]bool, len(b.granularities))
+	for _, g := range b.granularities {
+		known := false
+		for _, t := range timeGranularities {
+			known = known || g == t
+		}
+		if !known {
+			return fmt.Errorf(
+				"aggregate query %q: unknown granularity %q (must be one of %s)",
+				b.name, g, strings.Join(timeGranularities, ", "),
+			)
+		}
+		if seen[g] {
+			return fmt.Errorf("aggregate query %q: granularity %q is listed more than once", b.name, g)
+		}
+		seen[g] = true
+	}
+	return nil
+}
+
+// granularityEnumName names the enum Granularities generates for the query.
+func (b *AggregateQueryBuilder) granularityEnumName() string {
+	if b.name == "" {
+		return "Granularity"
+	}
+	return strings.ToUpper(b.name[:1]) + b.name[1:] + "Granularity"
+}
+
+// Register registers the aggregate query with the global schema registry,
+// together with its granularity enum if Granularities was called. Returns an
+// error if an aggregate query or, with Granularities, an enum with the same
+// name is already registered, or if Granularities lists an unknown or repeated
+// granularity.
 func (b *AggregateQueryBuilder) Register() error {
-	return RegisterAggregateQuery(AggregateQueryDefinition{
+	if err := b.validateGranularities(); err != nil {
+		return err
+	}
+	definition := AggregateQueryDefinition{
 		Name:           b.name,
 		FactTable:      b.factTableName,
 		AutoGroupBy:    b.autoGroupBy,
 		AutoAggregates: b.autoAggregates,
 		Description:    b.description,
 		Config:         b.config,
-	})
+	}
+	if b.granularities == nil {
+		return RegisterAggregateQuery(definition)
+	}
+
+	enum := EnumDefinition{Name: b.granularityEnumName()}
+	for _, g := range b.granularities {
+		enum.Values = append(enum.Values, EnumValueDefinition{Name: strings.ToUpper(g)})
+	}
+	sort.Slice(enum.Values, func(i, j int) bool { return enum.Values[i].Name < enum.Values[j].Name })
+	definition.Arguments = []ArgumentDefinition{{
+		Name:      granularityArgName,
+		Type:      enum.Name,
+		Nullable:  true,
+		Default:   strings.ToUpper(b.granularities[0]),
+		IsDefault: true,
+	}}
+	config := make(map[string]interface{}, len(b.config)+1)
+	for k, v := range b.config {
+		config[k] = v
+	}
+	config["granularities"] = b.granularities
+	definition.Config = config
+	return registerWithGranularityEnum(definition, enum)
+}
+
+// registerWithGranularityEnum registers the aggregate query together with its
+// generated granularity enum: both or neither, under one registry lock. The
+// OnRegister callbacks run for the enum, then the aggregate query.
+func registerWithGranularityEnum(definition AggregateQueryDefinition, enum EnumDefinition) (err error) {
+	defer func() {
+		if err == nil {
+			notifyRegistered("enum", enum.Name)
+			notifyRegistered("aggregate query", definition.Name)
+		}
+	}()
+
+	reg := getInstance()
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	if _, exists := reg.enums[enum.Name]; exists {
+		return fmt.Errorf(
+			"aggregate query %q: Granularities generates enum %q, which collides with a registered enum of the same name",
+			definition.Name, enum.Name,
+		)
+	}
+	if _, exists := reg.aggregateQueries[definition.Name]; exists {
+		return fmt.Errorf("aggregate query %q is already registered; each name must be unique within a schema", definition.Name)
+	}
+	reg.enums[enum.Name] = enum
+	reg.aggregateQueries[definition.Name] = definition
+	return nil
 }
@@ -0,0 +1,258 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// saleFact is both an entity type and the backing of a fact table.
+type saleFact struct {
+	ID         ID      `fraiseql:"id"`
+	Revenue    float64 `fraiseql:"revenue,type=Float,measure=sum;avg;max"`
+	Quantity   int     `fraiseql:"quantity,measure=sum;count"`
+	Margin     Decimal `fraiseql:"margin,type=Decimal,measure=avg"`
+	Category   string  `fraiseql:"category,type=String,dimension=text"`
+	Region     string  `fraiseql:"region,dimension=text"`
+	OccurredAt string  `fraiseql:"occurredAt,type=DateTime"`
+}
+
+func TestNewFactTableFromType(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(saleFact{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := NewFactTableFromType("sales", saleFact{}).
+		TableName("tf_sales").
+		Dimension("year_month", "date_trunc('month', occurred_at)::text", "text").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	schema := GetSchema()
+	if len(schema.FactTables) != 1 {
+		t.Fatalf("expected 1 fact table, got %d", len(schema.FactTables))
+	}
+	ft := schema.FactTables[0]
+	if ft.TableName != "tf_sales" {
+		t.Errorf("table name: want tf_sales, got %q", ft.TableName)
+	}
+
+	wantMeasures := []string{"revenue:sum", "revenue:avg", "revenue:max", "quantity:sum", "quantity:count", "margin:avg"}
+	if strings.Join(ft.Measures, ",") != strings.Join(wantMeasures, ",") {
+		t.Errorf("measures: want %v, got %v", wantMeasures, ft.Measures)
+	}
+
+	if len(ft.DimensionPaths) != 3 {
+		t.Fatalf("expected 3 dimensions, got %v", ft.DimensionPaths)
+	}
+	category := ft.DimensionPaths[0]
+	if category["name"] != "category" || category["expression"] != "data->>'category'" || category["data_type"] != "text" {
+		t.Errorf("unexpected category dimension: %v", category)
+	}
+	if ft.DimensionPaths[2]["name"] != "year_month" {
+		t.Errorf("explicit dimensions should follow tagged ones, got %v", ft.DimensionPaths[2])
+	}
+
+	// The measure/dimension keys do not disturb type registration.
+	if len(schema.Types) != 1 || len(schema.Types[0].Fields) != 7 {
+		t.Errorf("saleFact should register as a type with 7 fields, got %+v", schema.Types)
+	}
+}
+
+func TestNewFactTableFromTypeValidation(t *testing.T) {
+	type nonNumericMeasure struct {
+		Category string `fraiseql:"category,type=String,measure=sum"`
+	}
+	type unknownAggregation struct {
+		Revenue float64 `fraiseql:"revenue,measure=median"`
+	}
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr string
+	}{
+		{"non-numeric measure", nonNumericMeasure{}, `measure field "category" has non-numeric type "String"`},
+		{"unknown aggregation", unknownAggregation{}, `unknown aggregation "median"`},
+		{"not a struct", 42, "expected struct type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := NewFactTableFromType("sales", tt.value).TableName("tf_sales").Register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if len(GetSchema().FactTables) != 0 {
+				t.Error("invalid fact table should not be registered")
+			}
+		})
+	}
+}
+
+func TestCompositeDimension(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewFactTable("sales").
+		TableName("tf_sales").
+		Measure("revenue", "sum").
+		CompositeDimension("geo", []string{"data->>'country'", "data->>'region'"}, "text").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	var exported struct {
+		FactTables []struct {
+			DimensionPaths []struct {
+				Name     string   `json:"name"`
+				Paths    []string `json:"paths"`
+				DataType string   `json:"data_type"`
+			} `json:"dimension_paths"`
+		} `json:"fact_tables"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	dim := exported.FactTables[0].DimensionPaths[0]
+	if dim.Name != "geo" || dim.DataType != "text" {
+		t.Errorf("unexpected composite dimension: %+v", dim)
+	}
+	if strings.Join(dim.Paths, ",") != "data->>'country',data->>'region'" {
+		t.Errorf("expected both source paths, got %v", dim.Paths)
+	}
+}
+
+func TestCompositeDimensionNeedsTwoPaths(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	err := NewFactTable("sales").
+		TableName("tf_sales").
+		CompositeDimension("geo", []string{"data->>'country'"}, "text").
+		Register()
+	if err == nil || !strings.Contains(err.Error(), "needs at least two paths") {
+		t.Errorf("expected error for single-path composite dimension, got %v", err)
+	}
+}
+
+func TestAggregateQueryGranularities(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewAggregateQueryConfig("salesOverTime").
+		FactTableName("sales").
+		AutoGroupBy(true).
+		Granularities("day", "month", "quarter").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewAggregateQueryConfig("salesByCategory").FactTableName("sales").Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	var exported struct {
+		AggregateQueries []struct {
+			Name      string `json:"name"`
+			Arguments []struct {
+				Name     string `json:"name"`
+				Type     string `json:"type"`
+				Nullable bool   `json:"nullable"`
+				Default  string `json:"default"`
+			} `json:"arguments"`
+			Config map[string]interface{} `json:"config"`
+		} `json:"aggregate_queries"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(exported.AggregateQueries) != 2 {
+		t.Fatalf("expected 2 aggregate queries, got %s", data)
+	}
+
+	byCategory, overTime := exported.AggregateQueries[0], exported.AggregateQueries[1]
+	if len(byCategory.Arguments) != 0 || byCategory.Config["granularities"] != nil {
+		t.Errorf("salesByCategory should have no granularity, got %+v", byCategory)
+	}
+	if len(overTime.Arguments) != 1 {
+		t.Fatalf("expected a granularity argument, got %+v", overTime.Arguments)
+	}
+	if arg := overTime.Arguments[0]; arg.Name != "granularity" || arg.Type != "SalesOverTimeGranularity" || !arg.Nullable || arg.Default != "DAY" {
+		t.Errorf("granularity argument = %+v, want a nullable SalesOverTimeGranularity defaulting to DAY", arg)
+	}
+	if got, _ := json.Marshal(overTime.Config["granularities"]); string(got) != `["day","month","quarter"]` {
+		t.Errorf("granularities = %s, want [\"day\",\"month\",\"quarter\"]", got)
+	}
+
+	enums := GetSchema().Enums
+	if len(enums) != 1 || enums[0].Name != "SalesOverTimeGranularity" {
+		t.Fatalf("expected the SalesOverTimeGranularity enum, got %+v", enums)
+	}
+	var members []string
+	for _, v := range enums[0].Values {
+		members = append(members, v.Name)
+	}
+	if strings.Join(members, ",") != "DAY,MONTH,QUARTER" {
+		t.Errorf("enum members = %v, want DAY, MONTH and QUARTER", members)
+	}
+	if errs := ValidateSchema(); errs != nil {
+		t.Errorf("expected a valid schema, got %v", errs)
+	}
+	if orphans, err := FindOrphanTypes(); err != nil || len(orphans) != 0 {
+		t.Errorf("the granularity enum should be reachable, got orphans %v (%v)", orphans, err)
+	}
+}
+
+func TestAggregateQueryGranularityEnumCollision(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	Enum("SalesOverTimeGranularity", map[string]string{"DAY": "day"})
+	err := NewAggregateQueryConfig("salesOverTime").FactTableName("sales").Granularities("day").Register()
+	if err == nil || !strings.Contains(err.Error(), `generates enum "SalesOverTimeGranularity", which collides`) {
+		t.Fatalf("expected an enum collision error, got %v", err)
+	}
+	if len(GetSchema().AggregateQueries) != 0 {
+		t.Error("the aggregate query should not be registered when its enum is not")
+	}
+}
+
+func TestAggregateQueryGranularitiesValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		granularities []string
+		wantErr       string
+	}{
+		{"unknown", []string{"day", "fortnight"}, `unknown granularity "fortnight" (must be one of second, minute, hour, day, week, month, quarter, year)`},
+		{"repeated", []string{"day", "day"}, `granularity "day" is listed more than once`},
+		{"empty", nil, "Granularities needs at least one granularity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := NewAggregateQueryConfig("salesOverTime").
+				FactTableName("sales").
+				Granularities(tt.granularities...).
+				Register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
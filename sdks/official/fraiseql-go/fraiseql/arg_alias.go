@@ -0,0 +1,109 @@
+package fraiseql
+
+import "fmt"
+
+// argAlias records an Alias call: alias is accepted in place of arg.
+type argAlias struct {
+	arg   string
+	alias string
+}
+
+// Alias lets callers pass the most recently added argument under each of
+// aliases as well as its own name, so renaming an argument does not break
+// clients during a deprecation window. The aliases are exported as the
+// "argument_aliases" config key, mapping each alias to the argument name.
+// Register returns an error if Alias is called before any Arg, or if an alias
+// is not a valid GraphQL name or collides with another argument or alias.
+//
+// Example:
+//
+//	fraiseql.NewQuery("orders").
+//		ReturnType(Order{}).
+//		ReturnsArray(true).
+//		Arg("userId", "ID", nil).Alias("user_id").
+//		Register()
+func (qb *QueryBuilder) Alias(aliases ...string) *QueryBuilder {
+	qb.addArgAliases(aliases)
+	return qb
+}
+
+// Alias lets callers pass the most recently added argument under each of
+// aliases as well. See QueryBuilder.Alias.
+func (mb *MutationBuilder) Alias(aliases ...string) *MutationBuilder {
+	mb.addArgAliases(aliases)
+	return mb
+}
+
+func (b *operationBuilder) addArgAliases(aliases []string) {
+	arg := ""
+	if len(b.arguments) > 0 {
+		arg = b.arguments[len(b.arguments)-1].Name
+	}
+	for _, alias := range aliases {
+		b.argAliases = append(b.argAliases, argAlias{arg: arg, alias: alias})
+	}
+}
+
+// validateArgAliases checks that each alias follows an argument, is a valid
+// GraphQL name and is not already the name or alias of an argument.
+func (b *operationBuilder) validateArgAliases(kind string) error {
+	taken := make(map[string]string, len(b.arguments)+len(b.argAliases))
+	for _, arg := range b.arguments {
+		taken[arg.Name] = "argument"
+	}
+	for _, a := range b.argAliases {
+		if a.arg == "" {
+			return fmt.Errorf("%s %q: Alias(%q) must follow the Arg it aliases", kind, b.name, a.alias)
+		}
+		if !graphQLNamePattern.MatchString(a.alias) {
+			return fmt.Errorf("%s %q: alias %q of argument %q is not a valid GraphQL name", kind, b.name, a.alias, a.arg)
+		}
+		if what, exists := taken[a.alias]; exists {
+			return fmt.Errorf(
+				"%s %q: alias %q of argument %q collides with an existing %s of that name",
+				kind, b.name, a.alias, a.arg, what,
+			)
+		}
+		taken[a.alias] = "alias"
+	}
+	return nil
+}
+
+// validateArgAliasTargets checks the aliases against arguments, the query's
+// final argument list: each aliased argument must still be a top-level
+// argument, and no alias may take the name of one. FilterInput moves nullable
+// arguments into the generated input type, where a top-level alias cannot
+// reach them, and adds the `filter` argument.
+func (qb *QueryBuilder) validateArgAliasTargets(arguments []ArgumentDefinition) error {
+	for _, a := range qb.argAliases {
+		found := false
+		for _, arg := range arguments {
+			if arg.Name == a.alias {
+				return fmt.Errorf(
+					"query %q: alias %q of argument %q collides with an existing argument of that name",
+					qb.name, a.alias, a.arg,
+				)
+			}
+			if arg.Name == a.arg {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf(
+				"query %q: alias %q refers to argument %q, which FilterInput(%q) moves into the filter input; aliases only apply to top-level arguments",
+				qb.name, a.alias, a.arg, qb.filterInput,
+			)
+		}
+	}
+	return nil
+}
+
+// argAliasConfig returns the "argument_aliases" config value, mapping each
+// alias to the argument it stands for.
+func (b *operationBuilder) argAliasConfig() map[string]string {
+	aliases := make(map[string]string, len(b.argAliases))
+	for _, a := range b.argAliases {
+		aliases[a.alias] = a.arg
+	}
+	return aliases
+}
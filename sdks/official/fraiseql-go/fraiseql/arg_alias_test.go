@@ -0,0 +1,91 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestArgAliasExport(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewQuery("orders").
+		ReturnType("Order").
+		ReturnsArray(true).
+		Arg("userId", "ID", nil).Alias("user_id").
+		Arg("limit", "Int", 10).
+		Register(); err != nil {
+		t.Fatalf("query Register: %v", err)
+	}
+	if err := NewMutation("cancelOrder").
+		ReturnType("Order").
+		Arg("orderId", "ID", nil).Alias("order_id", "id").
+		Register(); err != nil {
+		t.Fatalf("mutation Register: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	for _, want := range []string{
+		`"argument_aliases":{"user_id":"userId"}`,
+		`"argument_aliases":{"id":"orderId","order_id":"orderId"}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in export, got %s", want, data)
+		}
+	}
+}
+
+func TestArgAliasValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		register func() error
+		wantErr  string
+	}{
+		{
+			name: "collides with another argument",
+			register: NewQuery("orders").ReturnType("Order").
+				Arg("userId", "ID", nil).Alias("limit").
+				Arg("limit", "Int", 10).Register,
+			wantErr: `query "orders": alias "limit" of argument "userId" collides with an existing argument of that name`,
+		},
+		{
+			name: "collides with another alias",
+			register: NewMutation("moveOrder").ReturnType("Order").
+				Arg("fromId", "ID", nil).Alias("id").
+				Arg("toId", "ID", nil).Alias("id").Register,
+			wantErr: `mutation "moveOrder": alias "id" of argument "toId" collides with an existing alias of that name`,
+		},
+		{
+			name: "names its own argument",
+			register: NewQuery("orders").ReturnType("Order").
+				Arg("userId", "ID", nil).Alias("userId").Register,
+			wantErr: `alias "userId" of argument "userId" collides with an existing argument`,
+		},
+		{
+			name:     "before any argument",
+			register: NewQuery("orders").ReturnType("Order").Alias("user_id").Register,
+			wantErr:  `query "orders": Alias("user_id") must follow the Arg it aliases`,
+		},
+		{
+			name: "invalid name",
+			register: NewQuery("orders").ReturnType("Order").
+				Arg("userId", "ID", nil).Alias("user-id").Register,
+			wantErr: `alias "user-id" of argument "userId" is not a valid GraphQL name`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := tt.register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
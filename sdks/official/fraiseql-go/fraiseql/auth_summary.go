@@ -0,0 +1,156 @@
+package fraiseql
+
+import "strings"
+
+// FieldAuth is what reading one field requires.
+type FieldAuth struct {
+	// Field is the owner-qualified field name, e.g. "User.salary".
+	Field string `json:"field"`
+	// Scopes lists the action:resource scopes from the `scope=` and
+	// `scopes=` tags.
+	Scopes []string `json:"scopes,omitempty"`
+	// Roles lists the bare role names from the `scopes=` tag.
+	Roles []string `json:"roles,omitempty"`
+}
+
+// OperationAuth summarizes the auth requirements of one operation.
+type OperationAuth struct {
+	// Kind is "query", "mutation" or "subscription".
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Fields lists the protected fields reachable from the operation's
+	// result, through nested object fields: the result type's fields
+	// first, then those of the types they lead to.
+	Fields []FieldAuth `json:"fields,omitempty"`
+	// RequiresRole is the role a query itself requires, set with the query
+	// builder's RequiresRole.
+	RequiresRole string `json:"requires_role,omitempty"`
+	// Scopes and Roles are the sorted unions over Fields: what a client
+	// selecting every field needs. Roles also holds RequiresRole and the
+	// RequiresRole of each type the result reaches.
+	Scopes []string `json:"scopes,omitempty"`
+	Roles  []string `json:"roles,omitempty"`
+}
+
+// AuthSummary collects, for each registered query, mutation and
+// subscription, the scopes and roles its result fields require, for
+// documentation and audit. Operations are listed by kind, then name; an
+// operation whose result reaches no protected field has no Fields.
+//
+// The summary is read-only; it reflects the RequiresRole of the queries and
+// types and the `scope=` and `scopes=` tags of the registered types, and
+// changes nothing. Returns an error if a schema transform fails.
+func AuthSummary() ([]OperationAuth, error) {
+	schema, err := buildSchema()
+	if err != nil {
+		return nil, err
+	}
+	types := namedTypes(schema.Types)
+
+	var summary []OperationAuth
+	for _, q := range schema.Queries {
+		summary = append(summary, operationAuth("query", q.Name, q.ReturnType, q.RequiresRole, types))
+	}
+	for _, m := range schema.Mutations {
+		summary = append(summary, operationAuth("mutation", m.Name, m.ReturnType, "", types))
+	}
+	for _, s := range schema.Subscriptions {
+		summary = append(summary, operationAuth("subscription", s.Name, s.EntityType, "", types))
+	}
+	return summary, nil
+}
+
+// operationAuth walks the types reachable from returnType and gathers their
+// protected fields and required roles, adding the role the operation itself
+// requires.
+func operationAuth(kind, name, returnType, requiresRole string, types map[string]TypeDefinition) OperationAuth {
+	op := OperationAuth{Kind: kind, Name: name, RequiresRole: requiresRole}
+	scopes := make(map[string]bool)
+	roles := make(map[string]bool)
+	if requiresRole != "" {
+		roles[requiresRole] = true
+	}
+
+	visited := make(map[string]bool)
+	queue := []string{namedType(returnType)}
+	for len(queue) > 0 {
+		typeName := queue[0]
+		queue = queue[1:]
+		def, ok := types[typeName]
+		if !ok || visited[typeName] {
+			continue
+		}
+		visited[typeName] = true
+		if def.RequiresRole != "" {
+			roles[def.RequiresRole] = true
+		}
+
+		for _, f := range def.Fields {
+			queue = append(queue, namedType(f.Type))
+
+			fa := FieldAuth{Field: typeName + "." + f.Name}
+			fa.Scopes, fa.Roles = fieldScopes(f)
+			if fa.Scopes == nil && fa.Roles == nil {
+				continue
+			}
+			for _, s := range fa.Scopes {
+				scopes[s] = true
+			}
+			for _, r := range fa.Roles {
+				roles[r] = true
+			}
+			op.Fields = append(op.Fields, fa)
+		}
+	}
+
+	if len(scopes) > 0 {
+		op.Scopes = sortedKeys(scopes)
+	}
+	if len(roles) > 0 {
+		op.Roles = sortedKeys(roles)
+	}
+	return op
+}
+
+// fieldScopes splits the entries of f's scope= and scopes= tags into
+// action:resource scopes and bare role names.
+func fieldScopes(f FieldInfo) (scopes, roles []string) {
+	if f.Scope != "" {
+		scopes = append(scopes, f.Scope)
+	}
+	for _, s := range f.Scopes {
+		if strings.Contains(s, ":") || s == "*" {
+			scopes = append(scopes, s)
+		} else {
+			roles = append(roles, s)
+		}
+	}
+	return scopes, roles
+}
+
+// ListScopes returns the distinct scopes the `scope=` and `scopes=` tags of
+// the registered types and input types use, sorted, e.g. to generate an
+// authorization policy document. Bare role names from `scopes=` are not
+// scopes and are left out; AuthSummary lists them per operation.
+func ListScopes() []string {
+	reg := getInstance()
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	add := func(fields []FieldInfo) {
+		for _, f := range fields {
+			scopes, _ := fieldScopes(f)
+			for _, s := range scopes {
+				seen[s] = true
+			}
+		}
+	}
+	for _, t := range reg.types {
+		add(t.Fields)
+	}
+	for _, in := range reg.inputTypes {
+		add(in.Fields)
+	}
+	return sortedKeys(seen)
+}
@@ -0,0 +1,96 @@
+package fraiseql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAuthSummary(t *testing.T) {
+	type Department struct {
+		Name   string  `fraiseql:"name"`
+		Budget float64 `fraiseql:"budget,scopes=finance;admin"`
+	}
+	type Employee struct {
+		ID         int        `fraiseql:"id"`
+		Email      string     `fraiseql:"email,scope=read:employee.email"`
+		Salary     float64    `fraiseql:"salary,scopes=read:employee.salary;hr"`
+		Department Department `fraiseql:"department"`
+		Manager    *Employee  `fraiseql:"manager,type=Employee,nullable=true"`
+	}
+	type Tag struct {
+		Label string `fraiseql:"label"`
+	}
+
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(Department{}, Employee{}, Tag{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := NewQuery("employees").ReturnType("Employee").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("query Register: %v", err)
+	}
+	if err := NewQuery("tags").ReturnType("Tag").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("query Register: %v", err)
+	}
+	if err := NewQuery("departments").ReturnType("Department").ReturnsArray(true).RequiresRole("admin").Register(); err != nil {
+		t.Fatalf("query Register: %v", err)
+	}
+	if err := NewQuery("auditTags").ReturnType("Tag").ReturnsArray(true).RequiresRole("auditor").Register(); err != nil {
+		t.Fatalf("query Register: %v", err)
+	}
+	if err := RegisterType("Payroll", []FieldInfo{{Name: "total", Type: "Float"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	RegisterSchemaTransform(func(s *Schema) error {
+		for i := range s.Types {
+			if s.Types[i].Name == "Payroll" {
+				s.Types[i].RequiresRole = "manager"
+			}
+		}
+		return nil
+	})
+	if err := NewMutation("renameDepartment").ReturnType("Department").Register(); err != nil {
+		t.Fatalf("mutation Register: %v", err)
+	}
+	if err := NewMutation("runPayroll").ReturnType("Payroll").Register(); err != nil {
+		t.Fatalf("mutation Register: %v", err)
+	}
+
+	want := []OperationAuth{
+		{Kind: "query", Name: "auditTags", RequiresRole: "auditor", Roles: []string{"auditor"}},
+		{
+			Kind:         "query",
+			Name:         "departments",
+			RequiresRole: "admin",
+			Fields:       []FieldAuth{{Field: "Department.budget", Roles: []string{"finance", "admin"}}},
+			Roles:        []string{"admin", "finance"},
+		},
+		{
+			Kind: "query",
+			Name: "employees",
+			Fields: []FieldAuth{
+				{Field: "Employee.email", Scopes: []string{"read:employee.email"}},
+				{Field: "Employee.salary", Scopes: []string{"read:employee.salary"}, Roles: []string{"hr"}},
+				{Field: "Department.budget", Roles: []string{"finance", "admin"}},
+			},
+			Scopes: []string{"read:employee.email", "read:employee.salary"},
+			Roles:  []string{"admin", "finance", "hr"},
+		},
+		{Kind: "query", Name: "tags"},
+		{
+			Kind:   "mutation",
+			Name:   "renameDepartment",
+			Fields: []FieldAuth{{Field: "Department.budget", Roles: []string{"finance", "admin"}}},
+			Roles:  []string{"admin", "finance"},
+		},
+		{Kind: "mutation", Name: "runPayroll", Roles: []string{"manager"}},
+	}
+	got, err := AuthSummary()
+	if err != nil {
+		t.Fatalf("AuthSummary: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AuthSummary() =\n%+v\nwant\n%+v", got, want)
+	}
+}
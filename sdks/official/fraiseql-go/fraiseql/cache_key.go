@@ -0,0 +1,64 @@
+package fraiseql
+
+import "fmt"
+
+// CacheKey names the arguments whose values key the query's cached results:
+// the runtime keeps one cache entry per distinct combination of their values,
+// and requests differing only in other arguments share it. Parameters set
+// with InjectParams may be named too, e.g. a locale taken from the session,
+// so each locale is cached apart. It is exported as the "cache_key" config
+// key; without it the runtime keys on every argument.
+//
+// Register returns an error if no name is given, a name is repeated or is
+// neither an argument nor an injected parameter of the query, or if
+// CacheTTLSeconds(0) disables caching.
+//
+// Example:
+//
+//	fraiseql.NewQuery("article").
+//		ReturnType(Article{}).
+//		Arg("id", "ID", nil).
+//		Arg("preview", "Boolean", false).
+//		InjectParams(map[string]string{"locale": "jwt:locale"}).
+//		CacheTTLSeconds(300).
+//		CacheKey("id", "locale").
+//		Register()
+func (qb *QueryBuilder) CacheKey(args ...string) *QueryBuilder {
+	qb.cacheKey = args
+	if qb.cacheKey == nil {
+		qb.cacheKey = []string{}
+	}
+	return qb
+}
+
+// validateCacheKey checks the CacheKey names against arguments, the query's
+// arguments as registered.
+func (qb *QueryBuilder) validateCacheKey(arguments []ArgumentDefinition) error {
+	if qb.cacheKey == nil {
+		return nil
+	}
+	if len(qb.cacheKey) == 0 {
+		return fmt.Errorf("query %q: CacheKey needs at least one argument name", qb.name)
+	}
+	if qb.cacheTTLSeconds != nil && *qb.cacheTTLSeconds == 0 {
+		return fmt.Errorf("query %q: CacheKey has no effect when CacheTTLSeconds(0) disables caching", qb.name)
+	}
+	known := make(map[string]bool, len(arguments)+len(qb.injectParams))
+	for _, a := range arguments {
+		known[a.Name] = true
+	}
+	for name := range qb.injectParams {
+		known[name] = true
+	}
+	seen := make(map[string]bool, len(qb.cacheKey))
+	for _, name := range qb.cacheKey {
+		if !known[name] {
+			return fmt.Errorf("query %q: cache key %q is not an argument or injected parameter of the query", qb.name, name)
+		}
+		if seen[name] {
+			return fmt.Errorf("query %q: cache key %q is listed more than once", qb.name, name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
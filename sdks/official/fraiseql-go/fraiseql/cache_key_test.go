@@ -0,0 +1,82 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCacheKeyExport(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewQuery("article").
+		ReturnType("Article").
+		Arg("id", "ID", nil).
+		Arg("preview", "Boolean", false).
+		InjectParams(map[string]string{"locale": "jwt:locale"}).
+		CacheTTLSeconds(300).
+		CacheKey("id", "locale").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewQuery("articles").
+		ReturnType("Article").
+		ReturnsArray(true).
+		Arg("limit", "Int", 10).
+		CacheTTLSeconds(60).
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	if want := `"cache_key":["id","locale"]`; !strings.Contains(string(data), want) {
+		t.Errorf("expected %s in export, got %s", want, data)
+	}
+	if strings.Count(string(data), `"cache_key"`) != 1 {
+		t.Errorf("a query without CacheKey should not export one, got %s", data)
+	}
+}
+
+func TestCacheKeyValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   *QueryBuilder
+		wantErr string
+	}{
+		{
+			name:    "unknown argument",
+			query:   NewQuery("article").ReturnType("Article").Arg("id", "ID", nil).CacheKey("id", "lang"),
+			wantErr: `query "article": cache key "lang" is not an argument or injected parameter of the query`,
+		},
+		{
+			name:    "repeated argument",
+			query:   NewQuery("article").ReturnType("Article").Arg("id", "ID", nil).CacheKey("id", "id"),
+			wantErr: `query "article": cache key "id" is listed more than once`,
+		},
+		{
+			name:    "no arguments",
+			query:   NewQuery("article").ReturnType("Article").Arg("id", "ID", nil).CacheKey(),
+			wantErr: `query "article": CacheKey needs at least one argument name`,
+		},
+		{
+			name:    "caching disabled",
+			query:   NewQuery("article").ReturnType("Article").Arg("id", "ID", nil).CacheTTLSeconds(0).CacheKey("id"),
+			wantErr: "CacheKey has no effect when CacheTTLSeconds(0) disables caching",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := tt.query.Register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
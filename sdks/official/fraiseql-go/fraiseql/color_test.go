@@ -0,0 +1,100 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeColor(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "#AABBCC", want: "#aabbcc"},
+		{input: "#abc", want: "#aabbcc"},
+		{input: "#abcd", want: "#aabbccdd"},
+		{input: "#abcf", want: "#aabbcc"},
+		{input: "#11223380", want: "#11223380"},
+		{input: "#112233FF", want: "#112233"},
+		{input: "rgb(255, 0, 128)", want: "#ff0080"},
+		{input: "RGB(0,0,0)", want: "#000000"},
+		{input: "rgba(255, 255, 255, 0.5)", want: "#ffffff80"},
+		{input: "rgba(10, 20, 30, 1)", want: "#0a141e"},
+		{input: "rebeccapurple", want: "#663399"},
+		{input: "  Red ", want: "#ff0000"},
+		{input: "transparent", want: "#00000000"},
+		{input: "", wantErr: true},
+		{input: "#", wantErr: true},
+		{input: "#ab", wantErr: true},
+		{input: "#abcde", wantErr: true},
+		{input: "#ggg", wantErr: true},
+		{input: "#-12", wantErr: true},
+		{input: "rgb(256, 0, 0)", wantErr: true},
+		{input: "rgb(1, 2)", wantErr: true},
+		{input: "rgb(1.5, 2, 3)", wantErr: true},
+		{input: "rgba(1, 2, 3, 2)", wantErr: true},
+		{input: "rgb(1, 2, 3", wantErr: true},
+		{input: "notacolor", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := NormalizeColor(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NormalizeColor(%q) = %q, want an error", tt.input, got)
+				}
+				if IsValidColor(tt.input) {
+					t.Errorf("IsValidColor(%q) = true", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeColor(%q): %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeColor(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if again, _ := NormalizeColor(got); again != got {
+				t.Errorf("NormalizeColor(%q) = %q, not idempotent", got, again)
+			}
+		})
+	}
+}
+
+func TestCSSNamedColorsAreNormalized(t *testing.T) {
+	for name, hex := range cssNamedColors {
+		if name != strings.ToLower(name) {
+			t.Errorf("named color %q is not lower case", name)
+		}
+		if got, err := normalizeHexColor(hex, hex[1:]); err != nil || got != hex {
+			t.Errorf("named color %q maps to %q, which is not normalized hex", name, hex)
+		}
+	}
+	if n := len(cssNamedColors); n != 149 {
+		t.Errorf("named colors = %d, want 149", n)
+	}
+}
+
+func TestColorHexFormatTag(t *testing.T) {
+	type Theme struct {
+		Accent string `fraiseql:"accent,type=Color,format=hex"`
+	}
+	type BadTheme struct {
+		Accent string `fraiseql:"accent,type=Color,format=rgb"`
+	}
+
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(Theme{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if got := getInstance().types["Theme"].Fields[0].Format; got != "hex" {
+		t.Errorf("Format = %q, want hex", got)
+	}
+	err := RegisterTypes(BadTheme{})
+	if err == nil || !strings.Contains(err.Error(), "must be one of css, hex") {
+		t.Errorf("error = %v, want the supported Color formats", err)
+	}
+}
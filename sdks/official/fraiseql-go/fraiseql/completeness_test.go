@@ -486,9 +486,9 @@ func TestRegisterErrorType(t *testing.T) {
 func TestRegisterErrorTypeDuplicate(t *testing.T) {
 	Reset()
 	_ = RegisterErrorType("MyError", []FieldInfo{{Name: "message", Type: "String"}}, "")
-	err := RegisterErrorType("MyError", []FieldInfo{{Name: "message", Type: "String"}}, "")
+	err := RegisterErrorType("MyError", []FieldInfo{{Name: "reason", Type: "String"}}, "")
 	if err == nil {
-		t.Fatal("expected error for duplicate error type registration")
+		t.Fatal("expected error for conflicting error type registration")
 	}
 }
 
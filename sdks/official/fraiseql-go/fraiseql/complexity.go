@@ -0,0 +1,43 @@
+package fraiseql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Complexity sets the query's cost for the runtime's query cost analyzer,
+// which rejects requests whose total cost exceeds its limit. It is exported
+// as the "complexity" config key; the fields selected add their own costs (see
+// the `complexity=` field tag). Register returns an error if cost is negative.
+//
+// Example:
+//
+//	fraiseql.NewQuery("searchProducts").
+//		ReturnType(Product{}).
+//		ReturnsArray(true).
+//		Complexity(10).
+//		Register()
+func (qb *QueryBuilder) Complexity(cost int) *QueryBuilder {
+	qb.complexity = &cost
+	return qb
+}
+
+// validateComplexity checks the Complexity value, if one was set.
+func (qb *QueryBuilder) validateComplexity() error {
+	if qb.complexity != nil && *qb.complexity < 0 {
+		return fmt.Errorf("query %q: complexity must not be negative, got %d", qb.name, *qb.complexity)
+	}
+	return nil
+}
+
+// parseComplexity parses a field's complexity= tag value.
+func parseComplexity(value, fieldName string) (*int, error) {
+	cost, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: complexity=%q is not an integer", fieldName, value)
+	}
+	if cost < 0 {
+		return nil, fmt.Errorf("field %s: complexity must not be negative, got %d", fieldName, cost)
+	}
+	return &cost, nil
+}
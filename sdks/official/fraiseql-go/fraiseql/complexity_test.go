@@ -0,0 +1,88 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComplexityExport(t *testing.T) {
+	type Product struct {
+		ID      string `fraiseql:"id,type=ID"`
+		Reviews string `fraiseql:"reviews,complexity=5"`
+		Name    string `fraiseql:"name,complexity=0"`
+		Price   string `fraiseql:"price,type=Decimal"`
+	}
+
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(Product{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := NewQuery("searchProducts").
+		ReturnType("Product").
+		ReturnsArray(true).
+		Complexity(10).
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	for _, want := range []string{
+		`"config":{"complexity":10}`,
+		`"name":"reviews","type":"String","nullable":false,"source_name":"reviews","complexity":5`,
+		`"name":"name","type":"String","nullable":false,"source_name":"name","complexity":0`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in export, got %s", want, data)
+		}
+	}
+	if strings.Count(string(data), `"complexity"`) != 3 {
+		t.Errorf("fields without a complexity should omit it, got %s", data)
+	}
+}
+
+func TestComplexityValidation(t *testing.T) {
+	type Negative struct {
+		Name string `fraiseql:"name,complexity=-1"`
+	}
+	type NotNumber struct {
+		Name string `fraiseql:"name,complexity=high"`
+	}
+	type ComplexInput struct {
+		Name string `fraiseql:"name,complexity=2"`
+	}
+
+	tests := []struct {
+		name     string
+		register func() error
+		wantErr  string
+	}{
+		{"negative query", NewQuery("products").ReturnType("Product").Complexity(-3).Register, `query "products": complexity must not be negative, got -3`},
+		{"negative tag", func() error { return RegisterTypes(Negative{}) }, "field Name: complexity must not be negative, got -1"},
+		{"non-numeric tag", func() error { return RegisterTypes(NotNumber{}) }, `complexity="high" is not an integer`},
+		{"input type", func() error { return RegisterInputTypes(ComplexInput{}) }, "only output type fields are selected at a cost"},
+		{
+			"negative FieldInfo",
+			func() error {
+				cost := -2
+				return RegisterType("Product", []FieldInfo{{Name: "id", Type: "ID", Complexity: &cost}}, "")
+			},
+			`type "Product": field "id": complexity must not be negative, got -2`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := tt.register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package fraiseql
+
+import "fmt"
+
+// GenerateInputType registers an input type named inputName with the stored
+// fields of the registered object type typeName, for mutation inputs that
+// mirror an entity. Fields the client cannot write are left out: computed
+// fields (`computed=true` or `resolver=`), slugs derived on write
+// (`slugFrom=`) and internal fields. The fields keep their type, nullability,
+// description and write-side options such as normalize, sanitize and format.
+//
+// Example:
+//
+//	fraiseql.RegisterTypes(Article{})
+//	fraiseql.GenerateInputType("Article", "ArticleInput")
+//
+// Returns an error if the type is not registered, if a stored field holds an
+// object type, which input types cannot, or if the input type cannot be
+// registered (see RegisterInputType).
+func GenerateInputType(typeName, inputName string) error {
+	reg := getInstance()
+	reg.mu.RLock()
+	def, exists := reg.types[typeName]
+	objectFields := make(map[string]bool)
+	for _, f := range def.Fields {
+		if _, isObject := reg.types[namedType(f.Type)]; isObject {
+			objectFields[f.Name] = true
+		}
+	}
+	reg.mu.RUnlock()
+	if !exists {
+		return fmt.Errorf("GenerateInputType: type %q is not registered", typeName)
+	}
+
+	input := InputTypeDefinition{
+		Name:        inputName,
+		Description: fmt.Sprintf("Input for %s", typeName),
+	}
+	for _, f := range def.Fields {
+		if f.Computed || f.Resolver != "" || f.SlugFrom != "" || f.Internal {
+			continue
+		}
+		if objectFields[f.Name] {
+			return fmt.Errorf(
+				"GenerateInputType: field %s.%s has object type %s, which an input type cannot hold; mark it computed or register the input type by hand",
+				typeName, f.Name, f.Type,
+			)
+		}
+		input.Fields = append(input.Fields, FieldInfo{
+			Name:             f.Name,
+			Type:             f.Type,
+			Nullable:         f.Nullable,
+			Description:      f.Description,
+			Normalize:        f.Normalize,
+			Sanitize:         f.Sanitize,
+			Format:           f.Format,
+			ValidateTimezone: f.ValidateTimezone,
+			Storage:          f.Storage,
+			Redact:           f.Redact,
+		})
+	}
+	return RegisterInputType(input)
+}
@@ -0,0 +1,108 @@
+package fraiseql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComputedTag(t *testing.T) {
+	type Person struct {
+		FirstName string `fraiseql:"firstName"`
+		FullName  string `fraiseql:"fullName,computed=true"`
+		Initials  string `fraiseql:"initials,resolver=fn_initials"`
+	}
+
+	fields, err := ExtractFields(reflect.TypeOf(Person{}))
+	if err != nil {
+		t.Fatalf("ExtractFields: %v", err)
+	}
+	if fields["firstName"].Computed {
+		t.Error("firstName should be stored")
+	}
+	if !fields["fullName"].Computed {
+		t.Error("computed=true should mark fullName computed")
+	}
+	if !fields["initials"].Computed {
+		t.Error("a resolver field should be computed")
+	}
+
+	Reset()
+	defer Reset()
+	if err := RegisterTypes(Person{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	if want := `{"name":"fullName","type":"String","nullable":false,"source_name":"fullName","computed":true}`; !strings.Contains(string(data), want) {
+		t.Errorf("expected %s in export, got %s", want, data)
+	}
+}
+
+func TestGenerateInputType(t *testing.T) {
+	type Article struct {
+		ID        ID       `fraiseql:"id"`
+		Title     string   `fraiseql:"title"`
+		Body      Markdown `fraiseql:"body,type=Markdown,sanitize=true"`
+		Slug      Slug     `fraiseql:"slug,type=Slug,slugFrom=title"`
+		WordCount int      `fraiseql:"wordCount,computed=true"`
+		Excerpt   string   `fraiseql:"excerpt,resolver=fn_excerpt"`
+		Deleted   bool     `fraiseql:"deleted,internal=true"`
+		Summary   *string  `fraiseql:"summary"`
+	}
+
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(Article{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := GenerateInputType("Article", "ArticleInput"); err != nil {
+		t.Fatalf("GenerateInputType: %v", err)
+	}
+
+	input, ok := getInstance().inputTypes["ArticleInput"]
+	if !ok {
+		t.Fatal("ArticleInput was not registered")
+	}
+	var names []string
+	for _, f := range input.Fields {
+		names = append(names, f.Name)
+	}
+	if want := []string{"id", "title", "body", "summary"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("input fields = %v, want %v", names, want)
+	}
+	if body := input.Fields[2]; body.Type != "Markdown" || !body.Sanitize {
+		t.Errorf("body = %+v, want a sanitized Markdown field", body)
+	}
+	if summary := input.Fields[3]; !summary.Nullable {
+		t.Errorf("summary = %+v, want nullable", summary)
+	}
+}
+
+func TestGenerateInputTypeErrors(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := GenerateInputType("Order", "OrderInput"); err == nil || !strings.Contains(err.Error(), `type "Order" is not registered`) {
+		t.Errorf("error = %v, want an unregistered type error", err)
+	}
+
+	if err := RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := RegisterType("Order", []FieldInfo{{Name: "id", Type: "ID"}, {Name: "buyer", Type: "User"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	err := GenerateInputType("Order", "OrderInput")
+	if err == nil || !strings.Contains(err.Error(), "field Order.buyer has object type User") {
+		t.Errorf("error = %v, want an object field error", err)
+	}
+
+	err = RegisterInputType(InputTypeDefinition{Name: "NoteInput", Fields: []FieldInfo{{Name: "length", Type: "Int", Computed: true}}})
+	if err == nil || !strings.Contains(err.Error(), `field "length" is computed`) {
+		t.Errorf("error = %v, want a computed input field error", err)
+	}
+}
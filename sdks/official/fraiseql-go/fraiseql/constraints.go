@@ -0,0 +1,70 @@
+package fraiseql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// FieldConstraints are the inclusive numeric bounds the runtime enforces on a
+// field's values. A nil bound is unconstrained.
+type FieldConstraints struct {
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+// scalarConstraints are the default bounds of scalars with a well-defined
+// range. They apply to every field of the scalar unless the field sets its
+// own bound, e.g. with a `min=` or `max=` tag.
+var scalarConstraints = map[string]FieldConstraints{
+	"Latitude":   {Min: float64Ptr(-90), Max: float64Ptr(90)},
+	"Longitude":  {Min: float64Ptr(-180), Max: float64Ptr(180)},
+	"Percentage": {Min: float64Ptr(0), Max: float64Ptr(100)},
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+// parseConstraintBound parses the value of a `min=` or `max=` tag.
+func parseConstraintBound(key, value, fieldName string) (*float64, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %s=%q is not a number", fieldName, key, value)
+	}
+	return &f, nil
+}
+
+// withScalarConstraints fills in the unset bounds of each field from the
+// defaults of its scalar and checks that no field's min exceeds its max. owner
+// names the type for errors. The caller's fields slice is copied, not modified.
+func withScalarConstraints(owner string, fields []FieldInfo) ([]FieldInfo, error) {
+	var result []FieldInfo
+	for i, f := range fields {
+		defaults, hasDefaults := scalarConstraints[namedType(f.Type)]
+		if hasDefaults {
+			c := FieldConstraints{}
+			if f.Constraints != nil {
+				c = *f.Constraints
+			}
+			if c.Min == nil {
+				c.Min = defaults.Min
+			}
+			if c.Max == nil {
+				c.Max = defaults.Max
+			}
+			if result == nil {
+				result = make([]FieldInfo, len(fields))
+				copy(result, fields)
+			}
+			result[i].Constraints = &c
+			f.Constraints = &c
+		}
+		if c := f.Constraints; c != nil && c.Min != nil && c.Max != nil && *c.Min > *c.Max {
+			return nil, fmt.Errorf("type %q: field %q has min %v greater than max %v", owner, f.Name, *c.Min, *c.Max)
+		}
+	}
+	if result == nil {
+		return fields, nil
+	}
+	return result, nil
+}
@@ -0,0 +1,99 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestScalarConstraintsAutoApplied(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Store struct {
+		ID       ID         `fraiseql:"id"`
+		Lat      Latitude   `fraiseql:"lat,type=Latitude"`
+		Lng      Longitude  `fraiseql:"lng,type=Longitude"`
+		Discount Percentage `fraiseql:"discount,type=Percentage,max=50"`
+		Rating   float64    `fraiseql:"rating,min=1,max=5"`
+	}
+	if err := RegisterTypes(Store{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	var exported struct {
+		Types []struct {
+			Fields []struct {
+				Name        string              `json:"name"`
+				Constraints *map[string]float64 `json:"constraints"`
+			} `json:"fields"`
+		} `json:"types"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	fields := exported.Types[0].Fields
+
+	if fields[0].Constraints != nil {
+		t.Errorf("id should have no constraints, got %v", *fields[0].Constraints)
+	}
+	want := map[string][2]float64{
+		"lat":      {-90, 90},
+		"lng":      {-180, 180},
+		"discount": {0, 50},
+		"rating":   {1, 5},
+	}
+	for _, f := range fields[1:] {
+		if f.Constraints == nil {
+			t.Errorf("%s: expected constraints", f.Name)
+			continue
+		}
+		c := *f.Constraints
+		if c["min"] != want[f.Name][0] || c["max"] != want[f.Name][1] {
+			t.Errorf("%s: want min %v max %v, got %v", f.Name, want[f.Name][0], want[f.Name][1], c)
+		}
+	}
+	if !strings.Contains(string(data), `"constraints":{"min":-90,"max":90}`) {
+		t.Errorf("expected Latitude constraints in exported JSON, got %s", data)
+	}
+}
+
+func TestScalarConstraintsOnExplicitFields(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	fields := []FieldInfo{{Name: "lng", Type: "Longitude"}}
+	if err := RegisterType("Point", fields, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if fields[0].Constraints != nil {
+		t.Error("the caller's fields slice should not be modified")
+	}
+	c := GetSchema().Types[0].Fields[0].Constraints
+	if c == nil || *c.Min != -180 || *c.Max != 180 {
+		t.Errorf("expected Longitude constraints, got %+v", c)
+	}
+}
+
+func TestConstraintValidation(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Bad struct {
+		Lat Latitude `fraiseql:"lat,type=Latitude,min=100"`
+	}
+	if err := RegisterTypes(Bad{}); err == nil || !strings.Contains(err.Error(), "greater than max") {
+		t.Errorf("expected min > max error, got %v", err)
+	}
+
+	type NotANumber struct {
+		Score float64 `fraiseql:"score,min=low"`
+	}
+	if err := RegisterTypes(NotANumber{}); err == nil || !strings.Contains(err.Error(), "is not a number") {
+		t.Errorf("expected invalid bound error, got %v", err)
+	}
+}
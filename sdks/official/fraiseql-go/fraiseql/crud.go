@@ -0,0 +1,246 @@
+package fraiseql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// The operations RegisterCRUD registers, for CRUDOptions.Disable.
+const (
+	CRUDGet    = "get"
+	CRUDList   = "list"
+	CRUDCreate = "create"
+	CRUDUpdate = "update"
+	CRUDDelete = "delete"
+)
+
+// CRUDOptions adjusts the operations RegisterCRUD registers. The zero value
+// registers all five with the default names and sources.
+type CRUDOptions struct {
+	// Disable lists operations not to register: CRUDGet, CRUDList,
+	// CRUDCreate, CRUDUpdate or CRUDDelete.
+	Disable []string
+	// View is the sql_source of the two queries; empty means the type's
+	// source view (v_<type>, or the one set with View).
+	View string
+	// CreateFunction, UpdateFunction and DeleteFunction are the sql_source
+	// of the mutations; empty means fn_create_<type>, fn_update_<type> and
+	// fn_delete_<type>.
+	CreateFunction string
+	UpdateFunction string
+	DeleteFunction string
+}
+
+// RegisterCRUD registers typeValue's struct type, if it is not registered
+// yet, and the five standard operations on it. For a type OrderItem with
+// primary key id:
+//
+//   - orderItem(id): a nullable single lookup on v_order_item.
+//   - orderItems: the list, with limit, offset, where and order_by
+//     auto_params for pagination and filtering.
+//   - createOrderItem(...): one argument per stored field except the
+//     primary key, calling fn_create_order_item.
+//   - updateOrderItem(id, ...): the primary key and every stored field as
+//     a nullable argument, calling fn_update_order_item.
+//   - deleteOrderItem(id): calling fn_delete_order_item.
+//
+// The primary key is the field tagged `primaryKey=true`, or else the field
+// named id. Stored fields leave out computed, `slugFrom=` and internal fields,
+// as GenerateInputType does, and fields holding another object type, which
+// cannot be arguments.
+//
+// Example:
+//
+//	fraiseql.RegisterCRUD(User{}, fraiseql.CRUDOptions{Disable: []string{fraiseql.CRUDDelete}})
+//
+// Returns an error if an option is unknown, if the type has no primary key or
+// conflicts with a registered type, or if an operation name is already
+// registered. Everything is validated before anything is registered, and the
+// type and the operations are then registered together under one registry
+// lock: on error, neither the type nor any operation is registered.
+func RegisterCRUD(typeValue interface{}, opts CRUDOptions) (err error) {
+	typeDef, err := structTypeDefinition(reflect.TypeOf(typeValue))
+	if err != nil {
+		return err
+	}
+	typeName := typeDef.Name
+
+	enabled := map[string]bool{CRUDGet: true, CRUDList: true, CRUDCreate: true, CRUDUpdate: true, CRUDDelete: true}
+	for _, op := range opts.Disable {
+		if !enabled[op] {
+			return fmt.Errorf(
+				"RegisterCRUD(%s): unknown operation %q to disable; must be one of get, list, create, update, delete",
+				typeName, op,
+			)
+		}
+		enabled[op] = false
+	}
+
+	if typeDef, err = prepareType(typeDef); err != nil {
+		return err
+	}
+
+	// A registered type may carry declarations such as View, so its
+	// definition is the one the operations are built on.
+	reg := getInstance()
+	reg.mu.RLock()
+	def := typeDef
+	if existing, exists := reg.types[typeName]; exists {
+		def = existing
+	}
+	objectFields := make(map[string]bool)
+	for _, f := range def.Fields {
+		if _, isObject := reg.types[namedType(f.Type)]; isObject || namedType(f.Type) == typeName {
+			objectFields[f.Name] = true
+		}
+	}
+	reg.mu.RUnlock()
+
+	var key *FieldInfo
+	var stored []FieldInfo
+	for i, f := range def.Fields {
+		if f.PrimaryKey || (f.Name == "id" && key == nil) {
+			key = &def.Fields[i]
+		}
+	}
+	if key == nil {
+		return fmt.Errorf("RegisterCRUD(%s): the type needs a primaryKey=true field or a field named id", typeName)
+	}
+	for _, f := range def.Fields {
+		if f.Name == key.Name || f.Computed || f.Resolver != "" || f.SlugFrom != "" || f.Internal || objectFields[f.Name] {
+			continue
+		}
+		stored = append(stored, f)
+	}
+
+	snake := toSnakeCase(typeName)
+	view := opts.View
+	if view == "" {
+		view = def.SqlSource
+	}
+	single := strings.ToLower(typeName[:1]) + typeName[1:]
+	keyType := strings.TrimSuffix(key.Type, "!")
+
+	var queryBuilders []*QueryBuilder
+	if enabled[CRUDGet] {
+		queryBuilders = append(queryBuilders, NewQuery(single).
+			ReturnType(typeName).
+			Single().
+			Config(map[string]interface{}{"sql_source": view}).
+			Arg(key.Name, keyType, nil).
+			Description(fmt.Sprintf("Get a %s by %s", typeName, key.Name)))
+	}
+	if enabled[CRUDList] {
+		queryBuilders = append(queryBuilders, NewQuery(pluralize(single)).
+			ReturnType(typeName).
+			ReturnsArray(true).
+			Config(map[string]interface{}{
+				"sql_source": view,
+				"auto_params": map[string]bool{
+					"limit":    true,
+					"offset":   true,
+					"where":    true,
+					"order_by": true,
+				},
+			}).
+			Description(fmt.Sprintf("List %s records", typeName)))
+	}
+
+	var mutationBuilders []*MutationBuilder
+	mutation := func(op, function, operation string) *MutationBuilder {
+		if function == "" {
+			function = "fn_" + op + "_" + snake
+		}
+		return NewMutation(op + typeName).
+			ReturnType(typeName).
+			Config(map[string]interface{}{"sql_source": function, "operation": operation}).
+			Description(fmt.Sprintf("%s a %s", strings.ToUpper(op[:1])+op[1:], typeName))
+	}
+	if enabled[CRUDCreate] {
+		m := mutation(CRUDCreate, opts.CreateFunction, "CREATE")
+		for _, f := range stored {
+			m.Arg(f.Name, f.Type, nil, f.Nullable)
+		}
+		mutationBuilders = append(mutationBuilders, m)
+	}
+	if enabled[CRUDUpdate] {
+		m := mutation(CRUDUpdate, opts.UpdateFunction, "UPDATE").Arg(key.Name, keyType, nil)
+		for _, f := range stored {
+			m.Arg(f.Name, strings.TrimSuffix(f.Type, "!"), nil, true)
+		}
+		mutationBuilders = append(mutationBuilders, m)
+	}
+	if enabled[CRUDDelete] {
+		mutationBuilders = append(mutationBuilders, mutation(CRUDDelete, opts.DeleteFunction, "DELETE").Arg(key.Name, keyType, nil))
+	}
+
+	queries := make([]QueryDefinition, 0, len(queryBuilders))
+	for _, qb := range queryBuilders {
+		q, _, err := qb.build()
+		if err != nil {
+			return fmt.Errorf("RegisterCRUD(%s): %w", typeName, err)
+		}
+		queries = append(queries, q)
+	}
+	mutations := make([]MutationDefinition, 0, len(mutationBuilders))
+	for _, mb := range mutationBuilders {
+		m, err := mb.build()
+		if err != nil {
+			return fmt.Errorf("RegisterCRUD(%s): %w", typeName, err)
+		}
+		mutations = append(mutations, m)
+	}
+
+	defer func() {
+		if err != nil {
+			return
+		}
+		notifyRegistered("type", typeName)
+		for _, q := range queries {
+			notifyRegistered("query", q.Name)
+		}
+		for _, m := range mutations {
+			notifyRegistered("mutation", m.Name)
+		}
+	}()
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	for _, q := range queries {
+		if _, exists := reg.queries[q.Name]; exists {
+			return fmt.Errorf("RegisterCRUD(%s): query %q is already registered", typeName, q.Name)
+		}
+	}
+	for _, m := range mutations {
+		if _, exists := reg.mutations[m.Name]; exists {
+			return fmt.Errorf("RegisterCRUD(%s): mutation %q is already registered", typeName, m.Name)
+		}
+	}
+	if err := reg.storeTypeLocked(typeDef); err != nil {
+		return err
+	}
+	for _, q := range queries {
+		reg.queries[q.Name] = q
+	}
+	for _, m := range mutations {
+		reg.mutations[m.Name] = m
+	}
+	return nil
+}
+
+// pluralize returns the plural of an English noun for a list query name,
+// e.g. "user" to "users", "category" to "categories" and "status" to
+// "statuses". The result always differs from name, so the list query never
+// collides with the single lookup.
+func pluralize(name string) string {
+	switch {
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "sh"), strings.HasSuffix(name, "ch"),
+		strings.HasSuffix(name, "x"), strings.HasSuffix(name, "z"):
+		return name + "es"
+	case len(name) > 1 && strings.HasSuffix(name, "y") && !strings.ContainsRune("aeiou", rune(name[len(name)-2])):
+		return name[:len(name)-1] + "ies"
+	}
+	return name + "s"
+}
@@ -0,0 +1,202 @@
+package fraiseql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type crudAuthor struct {
+	ID ID `fraiseql:"id"`
+}
+
+type crudOrderItem struct {
+	ID        ID          `fraiseql:"id"`
+	SKU       string      `fraiseql:"sku"`
+	Quantity  int         `fraiseql:"quantity"`
+	Note      *string     `fraiseql:"note"`
+	Total     float64     `fraiseql:"total,computed=true"`
+	Author    *crudAuthor `fraiseql:"author,type=crudAuthor,nullable=true"`
+	DeletedAt *string     `fraiseql:"deletedAt,internal=true"`
+}
+
+func TestRegisterCRUD(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(crudAuthor{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := RegisterCRUD(crudOrderItem{}, CRUDOptions{}); err != nil {
+		t.Fatalf("RegisterCRUD: %v", err)
+	}
+
+	schema := GetSchema()
+	queries := namedQueries(schema.Queries)
+	mutations := namedMutations(schema.Mutations)
+	if got := sortedKeys(queries); !reflect.DeepEqual(got, []string{"crudOrderItem", "crudOrderItems"}) {
+		t.Fatalf("queries = %v", got)
+	}
+	if got := sortedKeys(mutations); !reflect.DeepEqual(got, []string{"createcrudOrderItem", "deletecrudOrderItem", "updatecrudOrderItem"}) {
+		t.Fatalf("mutations = %v", got)
+	}
+
+	get := queries["crudOrderItem"]
+	if get.ReturnsList || !get.Nullable || get.SqlSource != "v_crud_order_item" ||
+		len(get.Arguments) != 1 || get.Arguments[0].Name != "id" || get.Arguments[0].Nullable {
+		t.Errorf("get query = %+v, want a nullable lookup by a required id on v_crud_order_item", get)
+	}
+	list := queries["crudOrderItems"]
+	wantParams := map[string]bool{"limit": true, "offset": true, "where": true, "order_by": true}
+	if !list.ReturnsList || list.SqlSource != "v_crud_order_item" || !reflect.DeepEqual(list.Config["auto_params"], wantParams) {
+		t.Errorf("list query = %+v, want a paginated list on v_crud_order_item", list)
+	}
+
+	argNames := func(args []ArgumentDefinition) []string {
+		var names []string
+		for _, a := range args {
+			names = append(names, a.Name)
+		}
+		return names
+	}
+	create := mutations["createcrudOrderItem"]
+	if create.SqlSource != "fn_create_crud_order_item" || create.Operation != "CREATE" {
+		t.Errorf("create = %+v, want fn_create_crud_order_item with operation CREATE", create)
+	}
+	if got := argNames(create.Arguments); !reflect.DeepEqual(got, []string{"sku", "quantity", "note"}) {
+		t.Errorf("create arguments = %v, want the stored fields", got)
+	}
+	if create.Arguments[0].Nullable || !create.Arguments[2].Nullable {
+		t.Errorf("create arguments should keep field nullability, got %+v", create.Arguments)
+	}
+	update := mutations["updatecrudOrderItem"]
+	if got := argNames(update.Arguments); !reflect.DeepEqual(got, []string{"id", "sku", "quantity", "note"}) {
+		t.Errorf("update arguments = %v, want id and the stored fields", got)
+	}
+	for _, a := range update.Arguments[1:] {
+		if !a.Nullable {
+			t.Errorf("update argument %q should be nullable", a.Name)
+		}
+	}
+	if update.SqlSource != "fn_update_crud_order_item" || update.Operation != "UPDATE" {
+		t.Errorf("update = %+v, want fn_update_crud_order_item with operation UPDATE", update)
+	}
+	del := mutations["deletecrudOrderItem"]
+	if del.SqlSource != "fn_delete_crud_order_item" || del.Operation != "DELETE" || !reflect.DeepEqual(argNames(del.Arguments), []string{"id"}) {
+		t.Errorf("delete = %+v, want fn_delete_crud_order_item by id", del)
+	}
+}
+
+func TestRegisterCRUDOptions(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Invoice struct {
+		Number string `fraiseql:"number,primaryKey=true"`
+		Amount int    `fraiseql:"amount"`
+	}
+	err := RegisterCRUD(Invoice{}, CRUDOptions{
+		Disable:        []string{CRUDDelete, CRUDList},
+		View:           "tv_invoice",
+		CreateFunction: "fn_issue_invoice",
+	})
+	if err != nil {
+		t.Fatalf("RegisterCRUD: %v", err)
+	}
+
+	schema := GetSchema()
+	if len(schema.Queries) != 1 || schema.Queries[0].Name != "invoice" || schema.Queries[0].SqlSource != "tv_invoice" {
+		t.Errorf("queries = %+v, want only invoice on tv_invoice", schema.Queries)
+	}
+	if got := schema.Queries[0].Arguments; len(got) != 1 || got[0].Name != "number" {
+		t.Errorf("invoice arguments = %+v, want the primaryKey field", got)
+	}
+	mutations := namedMutations(schema.Mutations)
+	if got := sortedKeys(mutations); !reflect.DeepEqual(got, []string{"createInvoice", "updateInvoice"}) {
+		t.Fatalf("mutations = %v", got)
+	}
+	if got := mutations["createInvoice"].SqlSource; got != "fn_issue_invoice" {
+		t.Errorf("createInvoice sql_source = %q, want fn_issue_invoice", got)
+	}
+	if got := mutations["updateInvoice"].SqlSource; got != "fn_update_invoice" {
+		t.Errorf("updateInvoice sql_source = %q, want fn_update_invoice", got)
+	}
+}
+
+func TestRegisterCRUDErrors(t *testing.T) {
+	type Tag struct {
+		ID   ID     `fraiseql:"id"`
+		Name string `fraiseql:"name"`
+	}
+	type Setting struct {
+		Name string `fraiseql:"name"`
+	}
+
+	tests := []struct {
+		name    string
+		setup   func()
+		value   interface{}
+		opts    CRUDOptions
+		wantErr string
+	}{
+		{
+			name:    "unknown disabled operation",
+			value:   Tag{},
+			opts:    CRUDOptions{Disable: []string{"remove"}},
+			wantErr: `unknown operation "remove"`,
+		},
+		{
+			name:    "no primary key",
+			value:   Setting{},
+			wantErr: "needs a primaryKey=true field or a field named id",
+		},
+		{
+			name: "name already registered",
+			setup: func() {
+				_ = NewQuery("tags").ReturnType("Tag").ReturnsArray(true).Config(map[string]interface{}{"sql_source": "v_tag"}).Register()
+			},
+			value:   Tag{},
+			wantErr: `query "tags" is already registered`,
+		},
+		{
+			name: "type conflicts with a registered type",
+			setup: func() {
+				_ = RegisterType("Tag", []FieldInfo{{Name: "id", Type: "ID"}}, "")
+			},
+			value:   Tag{},
+			wantErr: `type "Tag" is already registered with a different definition`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+			if tt.setup != nil {
+				tt.setup()
+			}
+			before := GetSchema()
+			err := RegisterCRUD(tt.value, tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("RegisterCRUD error = %v, want containing %q", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(GetSchema(), before) {
+				t.Errorf("neither the type nor any operation should be registered on error")
+			}
+		})
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	for name, want := range map[string]string{
+		"user":     "users",
+		"category": "categories",
+		"day":      "days",
+		"status":   "statuses",
+		"box":      "boxes",
+		"batch":    "batches",
+	} {
+		if got := pluralize(name); got != want {
+			t.Errorf("pluralize(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
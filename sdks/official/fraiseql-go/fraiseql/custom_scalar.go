@@ -60,6 +60,20 @@ type CustomScalar interface {
 	ParseLiteral(ast interface{}) (interface{}, error)
 }
 
+// SpecifiedByScalar is an optional interface for custom scalars that publish a
+// specification URL (GraphQL `@specifiedBy(url:)`). The URL is exported with
+// the scalar as "specified_by". Passing the URL to RegisterCustomScalar does
+// the same without a method.
+//
+// Example:
+//
+//	func (e *Email) SpecifiedByURL() string {
+//	    return "https://tools.ietf.org/html/rfc5322"
+//	}
+type SpecifiedByScalar interface {
+	SpecifiedByURL() string
+}
+
 // toString converts a value to string, handling common types.
 func toString(value interface{}) string {
 	switch v := value.(type) {
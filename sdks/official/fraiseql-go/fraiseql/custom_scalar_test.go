@@ -1,7 +1,9 @@
 package fraiseql
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -479,6 +481,162 @@ func TestCustomScalarsExportedInSchema(t *testing.T) {
 	}
 }
 
+// IBANScalar is a test scalar that publishes its specification URL.
+type IBANScalar struct{}
+
+func (s *IBANScalar) Name() string { return "BankAccount" }
+
+func (s *IBANScalar) SpecifiedByURL() string {
+	return "https://www.iso.org/standard/81090.html"
+}
+
+func (s *IBANScalar) Serialize(value interface{}) (interface{}, error) { return toString(value), nil }
+
+func (s *IBANScalar) ParseValue(value interface{}) (interface{}, error) { return toString(value), nil }
+
+func (s *IBANScalar) ParseLiteral(ast interface{}) (interface{}, error) { return toString(ast), nil }
+
+func TestCustomScalarSpecifiedByURL(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	RegisterCustomScalar(&IBANScalar{})
+	RegisterCustomScalar(&EmailScalar{})
+	RegisterCustomScalar(&PhoneScalar{})
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	var exported struct {
+		CustomScalars []map[string]interface{} `json:"custom_scalars"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	urls := make(map[string]interface{})
+	for _, sc := range exported.CustomScalars {
+		urls[sc["name"].(string)] = sc["specified_by"]
+	}
+
+	if urls["BankAccount"] != "https://www.iso.org/standard/81090.html" {
+		t.Errorf("BankAccount: spec URL should round-trip, got %v", urls["BankAccount"])
+	}
+	// Email does not implement SpecifiedByScalar but has a seeded URL.
+	if urls["Email"] != "https://tools.ietf.org/html/rfc5322" {
+		t.Errorf("Email: expected seeded RFC 5322 URL, got %v", urls["Email"])
+	}
+	if _, ok := urls["Phone"]; !ok {
+		t.Fatal("Phone scalar should be exported")
+	}
+	if urls["Phone"] != nil {
+		t.Errorf("Phone: expected no spec URL, got %v", urls["Phone"])
+	}
+}
+
+func TestRegisterCustomScalarSpecifiedBy(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	RegisterCustomScalar(&PhoneScalar{}, "https://www.itu.int/rec/T-REC-E.164")
+	RegisterCustomScalar(&IBANScalar{}, "https://example.com/iban")
+	if err := RegisterType("Account", []FieldInfo{
+		{Name: "id", Type: "UUID"},
+		{Name: "openedAt", Type: "DateTime"},
+		{Name: "name", Type: "String"},
+	}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+
+	urls := make(map[string]interface{})
+	for _, sc := range GetSchema().CustomScalars {
+		urls[sc["name"].(string)] = sc["specified_by"]
+	}
+	want := map[string]interface{}{
+		"Phone": "https://www.itu.int/rec/T-REC-E.164",
+		// The registration URL takes precedence over the SpecifiedByURL method.
+		"BankAccount": "https://example.com/iban",
+		// Referenced FraiseQL scalars export their seeded URLs.
+		"UUID":     "https://tools.ietf.org/html/rfc4122",
+		"DateTime": "https://scalars.graphql.org/andimarek/date-time",
+	}
+	if !reflect.DeepEqual(urls, want) {
+		t.Errorf("scalar spec URLs = %v, want %v", urls, want)
+	}
+}
+
+func TestScalarSpecifiedByURL(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if got := ScalarSpecifiedByURL("UUID"); got != "https://tools.ietf.org/html/rfc4122" {
+		t.Errorf("UUID: got %q", got)
+	}
+	if got := ScalarSpecifiedByURL("String"); got != "" {
+		t.Errorf("String: built-in GraphQL scalars have no spec URL, got %q", got)
+	}
+}
+
+func TestScalarBaseType(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	tests := map[string]string{
+		"Port":        "int",
+		"Latitude":    "float",
+		"Longitude":   "float",
+		"Percentage":  "float",
+		"Vector":      "list",
+		"Json":        "json",
+		"Email":       "string",
+		"Decimal":     "string",
+		"Int":         "int",
+		"Float":       "float",
+		"Boolean":     "boolean",
+		"ID":          "string",
+		"String":      "string",
+		"NotAScalar":  "",
+		"OrderStatus": "",
+	}
+	for name, want := range tests {
+		if got := ScalarBaseType(name); got != want {
+			t.Errorf("ScalarBaseType(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	RegisterCustomScalar(&EmailScalar{})
+	if got := ScalarBaseType("Email"); got != "string" {
+		t.Errorf("a custom scalar should not change a FraiseQL scalar's base type, got %q", got)
+	}
+}
+
+// TestScalarBaseTypeMatchesGoType checks the recorded base types against the
+// kinds of the Go marker types.
+func TestScalarBaseTypeMatchesGoType(t *testing.T) {
+	markers := map[string]interface{}{
+		"Port":       Port(0),
+		"Latitude":   Latitude(0),
+		"Longitude":  Longitude(0),
+		"Percentage": Percentage(0),
+		"Vector":     Vector(nil),
+		"Email":      Email(""),
+		"APIKey":     APIKey(""),
+	}
+	kinds := map[reflect.Kind]string{
+		reflect.Int:     "int",
+		reflect.Float64: "float",
+		reflect.Slice:   "list",
+		reflect.String:  "string",
+	}
+	for name, marker := range markers {
+		want := kinds[reflect.TypeOf(marker).Kind()]
+		if got := ScalarBaseType(name); got != want {
+			t.Errorf("ScalarBaseType(%q) = %q, want %q from its Go type", name, got, want)
+		}
+	}
+}
+
 func TestUnregisterCustomScalar(t *testing.T) {
 	defer Reset()
 
@@ -0,0 +1,97 @@
+package fraiseql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dateRangeFilter is a range filter added by DateOverlaps or DateRangeContains.
+type dateRangeFilter struct {
+	field    string
+	arg      string
+	operator string
+}
+
+// dateRangeArgTypes are the argument types each range operator accepts.
+var dateRangeArgTypes = map[string][]string{
+	"&&": {"DateRange"},
+	"@>": {"Date", "DateTime", "DateRange"},
+}
+
+// DateOverlaps restricts the query to rows whose DateRange field overlaps the
+// range passed in the named argument, e.g. bookings that clash with a
+// requested stay. The compiler generates a `field && $arg` WHERE clause.
+//
+// The argument is declared as a required DateRange unless it was already
+// added with Arg. Register checks that field is DateRange-typed on the return
+// type.
+//
+// Example:
+//
+//	fraiseql.NewQuery("clashingBookings").
+//		ReturnType(Booking{}).
+//		ReturnsArray(true).
+//		DateOverlaps("stay", "requested").
+//		Register()
+func (qb *QueryBuilder) DateOverlaps(field, rangeArg string) *QueryBuilder {
+	return qb.addDateRangeFilter(field, rangeArg, "DateRange", "&&")
+}
+
+// DateRangeContains restricts the query to rows whose DateRange field contains
+// the value passed in the named argument. The compiler generates a
+// `field @> $arg` WHERE clause.
+//
+// The argument is declared as a required Date unless it was already added with
+// Arg; declare it as DateTime or DateRange to test containment of an instant
+// or a whole range instead. See DateOverlaps.
+func (qb *QueryBuilder) DateRangeContains(field, arg string) *QueryBuilder {
+	return qb.addDateRangeFilter(field, arg, "Date", "@>")
+}
+
+func (qb *QueryBuilder) addDateRangeFilter(field, arg, argType, operator string) *QueryBuilder {
+	if qb.argIndex(arg) < 0 {
+		qb.addArg(arg, argType, nil)
+	}
+	qb.dateRangeFilters = append(qb.dateRangeFilters, dateRangeFilter{field: field, arg: arg, operator: operator})
+	return qb
+}
+
+// validateDateRangeFilters checks each range filter against the registered
+// return type and the query's arguments.
+func (qb *QueryBuilder) validateDateRangeFilters() error {
+	for _, f := range qb.dateRangeFilters {
+		if err := qb.requireReturnFieldType("date range filters", f.field, "DateRange"); err != nil {
+			return err
+		}
+
+		arg := qb.arguments[qb.argIndex(f.arg)]
+		allowed := dateRangeArgTypes[f.operator]
+		valid := false
+		for _, t := range allowed {
+			if namedType(arg.Type) == t {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf(
+				"query %q: argument %q must be of type %s for the %s operator, got %s",
+				qb.name, f.arg, strings.Join(allowed, ", "), f.operator, arg.Type,
+			)
+		}
+	}
+	return nil
+}
+
+// dateRangeFilterConfig returns the "date_range_filters" config value in call order.
+func (qb *QueryBuilder) dateRangeFilterConfig() []map[string]interface{} {
+	filters := make([]map[string]interface{}, len(qb.dateRangeFilters))
+	for i, f := range qb.dateRangeFilters {
+		filters[i] = map[string]interface{}{
+			"field":    f.field,
+			"operator": f.operator,
+			"arg":      f.arg,
+		}
+	}
+	return filters
+}
@@ -0,0 +1,113 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// booking has a DateRange stay alongside plain date fields.
+type booking struct {
+	ID      ID        `fraiseql:"id"`
+	Stay    DateRange `fraiseql:"stay,type=DateRange"`
+	Created string    `fraiseql:"createdAt,type=DateTime"`
+}
+
+func TestDateRangeFilterConfig(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(booking{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := NewQuery("availableBookings").
+		ReturnType(booking{}).
+		ReturnsArray(true).
+		DateOverlaps("stay", "requested").
+		DateRangeContains("stay", "day").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	var exported struct {
+		Queries []struct {
+			Arguments []ArgumentDefinition `json:"arguments"`
+			Config    struct {
+				DateRangeFilters []map[string]string `json:"date_range_filters"`
+			} `json:"config"`
+		} `json:"queries"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	q := exported.Queries[0]
+
+	filters := q.Config.DateRangeFilters
+	if len(filters) != 2 {
+		t.Fatalf("expected 2 date range filters, got %v", filters)
+	}
+	if filters[0]["field"] != "stay" || filters[0]["operator"] != "&&" || filters[0]["arg"] != "requested" {
+		t.Errorf("unexpected overlap filter: %v", filters[0])
+	}
+	if filters[1]["operator"] != "@>" || filters[1]["arg"] != "day" {
+		t.Errorf("unexpected containment filter: %v", filters[1])
+	}
+
+	if len(q.Arguments) != 2 || q.Arguments[0].Type != "DateRange" || q.Arguments[1].Type != "Date" {
+		t.Errorf("expected DateRange and Date arguments to be declared, got %+v", q.Arguments)
+	}
+	for _, a := range q.Arguments {
+		if a.Nullable {
+			t.Errorf("argument %q should be required", a.Name)
+		}
+	}
+}
+
+func TestDateRangeFilterValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func() *QueryBuilder
+		wantErr string
+	}{
+		{
+			name: "field is not a DateRange",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(booking{}).DateOverlaps("createdAt", "requested")
+			},
+			wantErr: `field "createdAt" on type "booking" is DateTime, date range filters require a field of type DateRange`,
+		},
+		{
+			name: "overlap argument is not a DateRange",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(booking{}).Arg("requested", "Date", nil).DateOverlaps("stay", "requested")
+			},
+			wantErr: `argument "requested" must be of type DateRange for the && operator`,
+		},
+		{
+			name: "unknown field",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(booking{}).DateRangeContains("period", "day")
+			},
+			wantErr: `has no field "period"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+			if err := RegisterTypes(booking{}); err != nil {
+				t.Fatalf("RegisterTypes: %v", err)
+			}
+
+			err := tt.build().Register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
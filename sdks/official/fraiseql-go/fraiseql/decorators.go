@@ -3,29 +3,289 @@ package fraiseql
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"strings"
 )
 
 // operationBuilder holds the common fields and shared logic for QueryBuilder and MutationBuilder.
 type operationBuilder struct {
+	name         string
+	returnType   string
+	scalarReturn bool
+	returnsList  bool
+	nullable     bool
+	arguments    []ArgumentDefinition
+	description  string
+	config       map[string]interface{}
+	restPath     string
+	restMethod   string
+	profile      string
+	rateLimit    *int
+
+	argDeprecations []argDeprecation
+	argDescriptions []argDescription
+	hiddenArgs      []string
+	inputListArgs   []inputListArg
+	argAliases      []argAlias
+}
+
+// inputListArg records an ArgList call, whose element type is checked against
+// the registered input types when the operation is registered.
+type inputListArg struct {
+	name      string
+	inputType string
+}
+
+// argDeprecation records a DeprecateArg call, applied when the operation is registered.
+type argDeprecation struct {
+	name   string
+	reason string
+}
+
+// argDescription records a DescribeArg call, applied when the operation is registered.
+type argDescription struct {
 	name        string
-	returnType  string
-	returnsList bool
-	nullable    bool
-	arguments   []ArgumentDefinition
 	description string
-	config      map[string]interface{}
-	restPath    string
-	restMethod  string
 }
 
 func (b *operationBuilder) setReturnType(returnType interface{}) {
+	b.scalarReturn = false
 	switch v := returnType.(type) {
 	case string:
 		b.returnType = v
+	case nil:
+		b.returnType = ""
 	default:
-		b.returnType = getTypeName(returnType)
+		if name := getTypeName(returnType); name != "" {
+			b.returnType = name
+			return
+		}
+		// A Go scalar value (e.g. 0 for Int) maps to its GraphQL scalar.
+		graphQLType, _, err := goToGraphQLType(reflect.TypeOf(returnType))
+		if err == nil && IsScalarType(graphQLType) {
+			b.returnType = graphQLType
+			b.scalarReturn = true
+		} else {
+			b.returnType = ""
+		}
+	}
+}
+
+func (b *operationBuilder) setReturnsScalar(scalar string) {
+	b.returnType = scalar
+	b.scalarReturn = true
+}
+
+// validate checks the builder configuration before registration: the
+// operation needs a name and a return type. kind is "query" or "mutation".
+func (b *operationBuilder) validate(kind string) error {
+	if strings.TrimSpace(b.name) == "" {
+		return fmt.Errorf("%s name must not be empty", kind)
+	}
+	if b.returnType == "" {
+		return fmt.Errorf(
+			"%s %q has no return type; call ReturnType or ReturnsScalar before Register",
+			kind, b.name,
+		)
+	}
+	if err := b.validateArgNames(kind); err != nil {
+		return err
+	}
+	if err := b.validateArgTypes(kind); err != nil {
+		return err
+	}
+	if err := b.validateArgDefaults(kind); err != nil {
+		return err
+	}
+	if err := b.validateInputListArgs(kind); err != nil {
+		return err
+	}
+	if err := b.validateRateLimit(kind); err != nil {
+		return err
+	}
+	if err := b.validateArgAliases(kind); err != nil {
+		return err
+	}
+	if b.profile != "" {
+		if err := validateProfileName(b.profile); err != nil {
+			return fmt.Errorf("%s %q: %w", kind, b.name, err)
+		}
+	}
+	if route, ok := b.config["route"]; ok {
+		if s, isString := route.(string); !isString || !validRoutes[s] {
+			return fmt.Errorf(
+				"%s %q: invalid route %v; must be one of \"primary\" or \"replica\"",
+				kind, b.name, route,
+			)
+		}
+	}
+	return b.validateReturnType(kind)
+}
+
+// validateArgNames rejects an argument name added more than once, e.g. by a
+// copy-pasted Arg call.
+func (b *operationBuilder) validateArgNames(kind string) error {
+	seen := make(map[string]bool, len(b.arguments))
+	for _, arg := range b.arguments {
+		if seen[arg.Name] {
+			return fmt.Errorf("%s %q: argument %q is declared more than once", kind, b.name, arg.Name)
+		}
+		seen[arg.Name] = true
 	}
+	return nil
+}
+
+// validateArgTypes checks that each argument type string is well-formed GraphQL
+// type notation and does not contradict the argument's Nullable flag.
+func (b *operationBuilder) validateArgTypes(kind string) error {
+	for _, arg := range b.arguments {
+		if !isWellFormedType(arg.Type) {
+			return fmt.Errorf(
+				"%s %q: argument %q has malformed type %q; use GraphQL notation such as \"ID\", \"[ID!]\" or \"[ID!]!\"",
+				kind, b.name, arg.Name, arg.Type,
+			)
+		}
+		if arg.Nullable && strings.HasSuffix(arg.Type, "!") {
+			return fmt.Errorf(
+				"%s %q: argument %q has non-null type %q but is marked nullable",
+				kind, b.name, arg.Name, arg.Type,
+			)
+		}
+	}
+	return nil
+}
+
+// isWellFormedType reports whether typeStr is a named type, optionally
+// wrapped in lists, with at most one "!" after each named type or list.
+func isWellFormedType(typeStr string) bool {
+	typeStr = strings.TrimSuffix(typeStr, "!")
+	if strings.HasPrefix(typeStr, "[") && strings.HasSuffix(typeStr, "]") {
+		return isWellFormedType(typeStr[1 : len(typeStr)-1])
+	}
+	return graphQLNamePattern.MatchString(typeStr)
+}
+
+// ListType builds a GraphQL list type string for an argument, e.g.
+// ListType("ID", false) returns "[ID!]" and ListType("String", true) returns
+// "[String]". Pass the result to Arg; make the list itself required with
+// Nullable false (the default) or by appending "!".
+func ListType(elemType string, nullableElements bool) string {
+	if nullableElements {
+		return "[" + elemType + "]"
+	}
+	return "[" + elemType + "!]"
+}
+
+// validateArgDefaults checks that each argument default's Go kind is
+// compatible with the argument's declared built-in scalar type. Defaults for
+// other types (enums, custom scalars, lists, input types) are not checked.
+func (b *operationBuilder) validateArgDefaults(kind string) error {
+	for _, arg := range b.arguments {
+		if !arg.IsDefault {
+			continue
+		}
+		graphQLType := strings.TrimSuffix(arg.Type, "!")
+		if !defaultMatchesScalar(graphQLType, reflect.ValueOf(arg.Default).Kind()) {
+			return fmt.Errorf(
+				"%s %q: argument %q has default %#v (%T) which is not compatible with type %s",
+				kind, b.name, arg.Name, arg.Default, arg.Default, arg.Type,
+			)
+		}
+	}
+	return nil
+}
+
+// validateInputListArgs checks that the element type of each ArgList argument
+// is a registered input type.
+func (b *operationBuilder) validateInputListArgs(kind string) error {
+	if len(b.inputListArgs) == 0 {
+		return nil
+	}
+
+	reg := getInstance()
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	for _, arg := range b.inputListArgs {
+		if _, exists := reg.inputTypes[arg.inputType]; !exists {
+			return fmt.Errorf(
+				"%s %q: argument %q: %q is not a registered input type; register it with RegisterInputType first",
+				kind, b.name, arg.name, arg.inputType,
+			)
+		}
+	}
+	return nil
+}
+
+// defaultMatchesScalar reports whether a default value of the given Go kind
+// can be coerced to the named GraphQL type. Following GraphQL input coercion,
+// Float accepts integers and ID accepts integers as well as strings.
+func defaultMatchesScalar(graphQLType string, k reflect.Kind) bool {
+	isInt := k >= reflect.Int && k <= reflect.Uint64
+	switch graphQLType {
+	case "Int":
+		return isInt
+	case "Float":
+		return isInt || k == reflect.Float32 || k == reflect.Float64
+	case "Boolean":
+		return k == reflect.Bool
+	case "String":
+		return k == reflect.String
+	case "ID":
+		return k == reflect.String || isInt
+	default:
+		return true
+	}
+}
+
+// requireReturnFieldType checks that the registered return type has a field
+// whose named type is one of want. feature names the builder option being
+// validated (e.g. "hierarchy filters") for the error message.
+func (b *operationBuilder) requireReturnFieldType(feature, field string, want ...string) error {
+	reg := getInstance()
+	reg.mu.RLock()
+	def, exists := reg.types[b.returnType]
+	reg.mu.RUnlock()
+	if !exists {
+		return fmt.Errorf(
+			"query %q: %s require return type %q to be registered first",
+			b.name, feature, b.returnType,
+		)
+	}
+
+	for _, f := range def.Fields {
+		if f.Name != field {
+			continue
+		}
+		for _, w := range want {
+			if namedType(f.Type) == w {
+				return nil
+			}
+		}
+		return fmt.Errorf(
+			"query %q: field %q on type %q is %s, %s require a field of type %s",
+			b.name, field, def.Name, f.Type, feature, strings.Join(want, ", "),
+		)
+	}
+	return fmt.Errorf("query %q: type %q has no field %q", b.name, def.Name, field)
+}
+
+// validRoutes are the connection-routing hints the runtime understands.
+var validRoutes = map[string]bool{
+	"primary": true,
+	"replica": true,
+}
+
+// validateReturnType checks that a scalar return type names a built-in,
+// FraiseQL, or registered custom scalar. kind is "query" or "mutation".
+func (b *operationBuilder) validateReturnType(kind string) error {
+	if b.scalarReturn && !IsScalarType(b.returnType) && !HasCustomScalar(b.returnType) {
+		return fmt.Errorf(
+			"%s %q: return type %q is not a known scalar type",
+			kind, b.name, b.returnType,
+		)
+	}
+	return nil
 }
 
 func (b *operationBuilder) setReturnsArray(arr bool) {
@@ -40,6 +300,13 @@ func (b *operationBuilder) setConfig(cfg map[string]interface{}) {
 	b.config = cfg
 }
 
+func (b *operationBuilder) setRoute(route string) {
+	if b.config == nil {
+		b.config = make(map[string]interface{})
+	}
+	b.config["route"] = route
+}
+
 func (b *operationBuilder) addArg(name string, graphQLType string, defaultValue interface{}, nullable ...bool) {
 	isNullable := false
 	if len(nullable) > 0 {
@@ -57,10 +324,92 @@ func (b *operationBuilder) addArg(name string, graphQLType string, defaultValue
 	b.arguments = append(b.arguments, arg)
 }
 
+// addInputListArg adds a required list-of-input argument such as
+// items: [CreateItemInput!]!.
+func (b *operationBuilder) addInputListArg(name, inputType string, nullableElements bool) {
+	b.addArg(name, ListType(inputType, nullableElements), nil)
+	b.inputListArgs = append(b.inputListArgs, inputListArg{name: name, inputType: inputType})
+}
+
 func (b *operationBuilder) setDescription(desc string) {
 	b.description = desc
 }
 
+func (b *operationBuilder) deprecateArg(name, reason string) {
+	b.argDeprecations = append(b.argDeprecations, argDeprecation{name: name, reason: reason})
+}
+
+func (b *operationBuilder) hideArg(name string) {
+	b.hiddenArgs = append(b.hiddenArgs, name)
+}
+
+func (b *operationBuilder) describeArg(name, description string) {
+	b.argDescriptions = append(b.argDescriptions, argDescription{name: name, description: description})
+}
+
+// argIndex returns the index of the named argument, or -1 if there is none.
+func (b *operationBuilder) argIndex(name string) int {
+	for i, arg := range b.arguments {
+		if arg.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyArgDeprecations marks the arguments named by DeprecateArg as deprecated.
+// A deprecated argument must exist and must be optional (nullable or defaulted),
+// since GraphQL forbids deprecating a required argument. kind is "query" or "mutation".
+func (b *operationBuilder) applyArgDeprecations(kind string) error {
+	for _, d := range b.argDeprecations {
+		i := b.argIndex(d.name)
+		if i < 0 {
+			return fmt.Errorf("%s %q: DeprecateArg: no argument named %q", kind, b.name, d.name)
+		}
+		if !b.arguments[i].Nullable && !b.arguments[i].IsDefault {
+			return fmt.Errorf(
+				"%s %q: argument %q is required and cannot be deprecated; make it nullable or give it a default",
+				kind, b.name, d.name,
+			)
+		}
+		b.arguments[i].Deprecated = &DeprecationInfo{Reason: d.reason}
+	}
+	return nil
+}
+
+// applyArgDescriptions sets the descriptions given by DescribeArg. A described
+// argument must exist. kind is "query" or "mutation".
+func (b *operationBuilder) applyArgDescriptions(kind string) error {
+	for _, d := range b.argDescriptions {
+		i := b.argIndex(d.name)
+		if i < 0 {
+			return fmt.Errorf("%s %q: DescribeArg: no argument named %q", kind, b.name, d.name)
+		}
+		b.arguments[i].Description = d.description
+	}
+	return nil
+}
+
+// applyHiddenArgs marks the arguments named by HideArg as hidden. A hidden
+// argument must exist and must be optional (nullable or defaulted), since
+// clients reading the public schema cannot supply it. kind is "query" or "mutation".
+func (b *operationBuilder) applyHiddenArgs(kind string) error {
+	for _, name := range b.hiddenArgs {
+		i := b.argIndex(name)
+		if i < 0 {
+			return fmt.Errorf("%s %q: HideArg: no argument named %q", kind, b.name, name)
+		}
+		if !b.arguments[i].Nullable && !b.arguments[i].IsDefault {
+			return fmt.Errorf(
+				"%s %q: argument %q is required and cannot be hidden; make it nullable or give it a default",
+				kind, b.name, name,
+			)
+		}
+		b.arguments[i].Hidden = true
+	}
+	return nil
+}
+
 // parseInjectParams converts {"param": "jwt:claim"} to {"param": {"source": "jwt", "claim": "claim"}}.
 func parseInjectParams(params map[string]string) map[string]interface{} {
 	result := make(map[string]interface{}, len(params))
@@ -84,9 +433,18 @@ type QueryBuilder struct {
 	relayCursorType   string
 	injectParams      map[string]interface{}
 	cacheTTLSeconds   *uint64
+	cacheKey          []string
 	additionalViews   []string
 	requiresRole      string
 	deprecation       *DeprecationInfo
+	ltreeFilters      []ltreeFilter
+	vectorSearch      *vectorSearch
+	ipRangeFilters    []ipRangeFilter
+	dateRangeFilters  []dateRangeFilter
+	spatialFilters    []spatialFilter
+	filterInput       string
+	count             bool
+	complexity        *int
 }
 
 // NewQuery creates a new query builder
@@ -106,6 +464,14 @@ func (qb *QueryBuilder) ReturnType(returnType interface{}) *QueryBuilder {
 	return qb
 }
 
+// ReturnsScalar sets a scalar return type for the query (e.g. "Int" for a count).
+// The name must be a built-in GraphQL scalar, a FraiseQL scalar, or a registered
+// custom scalar; Register returns an error otherwise.
+func (qb *QueryBuilder) ReturnsScalar(scalar string) *QueryBuilder {
+	qb.setReturnsScalar(scalar)
+	return qb
+}
+
 // ReturnsArray sets whether the query returns an array
 func (qb *QueryBuilder) ReturnsArray(b bool) *QueryBuilder {
 	qb.setReturnsArray(b)
@@ -118,6 +484,13 @@ func (qb *QueryBuilder) Nullable(b bool) *QueryBuilder {
 	return qb
 }
 
+// Single marks the query as a single-object lookup, such as a fetch by id:
+// it returns one nullable object, so a missing row resolves to null instead
+// of an error. It is shorthand for ReturnsArray(false).Nullable(true).
+func (qb *QueryBuilder) Single() *QueryBuilder {
+	return qb.ReturnsArray(false).Nullable(true)
+}
+
 // Config sets the configuration for the query
 func (qb *QueryBuilder) Config(config map[string]interface{}) *QueryBuilder {
 	qb.setConfig(config)
@@ -131,6 +504,15 @@ func (qb *QueryBuilder) Arg(name string, graphQLType string, defaultValue interf
 	return qb
 }
 
+// ArgList adds a required argument holding a list of input objects, e.g.
+// ArgList("items", "CreateItemInput", false) declares items: [CreateItemInput!]!.
+// nullableElements allows null list elements. Register returns an error unless
+// inputType is a registered input type.
+func (qb *QueryBuilder) ArgList(name, inputType string, nullableElements bool) *QueryBuilder {
+	qb.addInputListArg(name, inputType, nullableElements)
+	return qb
+}
+
 // Description sets the description for the query
 func (qb *QueryBuilder) Description(desc string) *QueryBuilder {
 	qb.setDescription(desc)
@@ -143,6 +525,35 @@ func (qb *QueryBuilder) SqlSource(source string) *QueryBuilder {
 	return qb
 }
 
+// MaterializedView sets a materialized view the runtime may read from for the
+// common case, alongside the live sql_source used for fresh reads. It is
+// exported as the "materialized_view" config key; Register requires a
+// sql_source to be set as well.
+func (qb *QueryBuilder) MaterializedView(view string) *QueryBuilder {
+	qb.config["materialized_view"] = view
+	return qb
+}
+
+// Count makes the query return the number of matching rows as a non-null
+// Int instead of the rows themselves. It sets the "count" config flag so the
+// compiler emits a SELECT count(*) against the sql_source, filtered by the
+// query's arguments as usual; Register requires a sql_source to be set.
+//
+// Example:
+//
+//	fraiseql.NewQuery("userCount").
+//		SqlSource("v_user").
+//		Arg("isActive", "Boolean", nil, true).
+//		Count().
+//		Register()
+func (qb *QueryBuilder) Count() *QueryBuilder {
+	qb.setReturnsScalar("Int")
+	qb.returnsList = false
+	qb.nullable = false
+	qb.count = true
+	return qb
+}
+
 // SqlSourceDispatch sets dispatch configuration with an explicit parameter-to-source mapping.
 // The paramName is the GraphQL argument name whose value selects the SQL source.
 // The mapping maps enum values to SQL table/view names.
@@ -233,30 +644,149 @@ func (qb *QueryBuilder) Deprecated(reason string) *QueryBuilder {
 	return qb
 }
 
-// Register registers the query with the global schema registry.
-// Returns an error if a query with the same name is already registered.
-func (qb *QueryBuilder) Register() error {
+// DeprecateArg marks the named argument of this query as deprecated with the given reason.
+// The argument must be added with Arg and be optional; Register returns an error otherwise.
+func (qb *QueryBuilder) DeprecateArg(name, reason string) *QueryBuilder {
+	qb.deprecateArg(name, reason)
+	return qb
+}
+
+// HideArg hides the named argument of this query from introspection: the
+// runtime still accepts it, e.g. from internal callers, but the public schema
+// does not show it. The argument must be added with Arg and be optional;
+// Register returns an error otherwise.
+func (qb *QueryBuilder) HideArg(name string) *QueryBuilder {
+	qb.hideArg(name)
+	return qb
+}
+
+// DescribeArg sets the description of the named argument of this query, which is
+// exported with the argument for generated docs. The argument must be added
+// with Arg; Register returns an error otherwise.
+func (qb *QueryBuilder) DescribeArg(name, description string) *QueryBuilder {
+	qb.describeArg(name, description)
+	return qb
+}
+
+// Route sets a connection-routing hint for the runtime: "replica" or "primary".
+// It is exported as the "route" config key and validated by Register.
+func (qb *QueryBuilder) Route(route string) *QueryBuilder {
+	qb.setRoute(route)
+	return qb
+}
+
+// Profile restricts this query to the named build profile (e.g. "enterprise").
+// The name may contain only letters, digits, underscores and hyphens; Register
+// returns an error otherwise. See ExportSchemaForProfile.
+func (qb *QueryBuilder) Profile(profile string) *QueryBuilder {
+	qb.profile = profile
+	return qb
+}
+
+// MustRegister is like Register but panics if registration fails.
+// It is intended for package-level registration in init().
+func (qb *QueryBuilder) MustRegister() {
+	if err := qb.Register(); err != nil {
+		panic(err)
+	}
+}
+
+// build validates the builder and assembles the query definition, and the
+// filter input type when FilterInput is set, without registering them.
+func (qb *QueryBuilder) build() (QueryDefinition, InputTypeDefinition, error) {
+	if err := qb.validate("query"); err != nil {
+		return QueryDefinition{}, InputTypeDefinition{}, err
+	}
+	if err := qb.applyArgDeprecations("query"); err != nil {
+		return QueryDefinition{}, InputTypeDefinition{}, err
+	}
+	if err := qb.applyArgDescriptions("query"); err != nil {
+		return QueryDefinition{}, InputTypeDefinition{}, err
+	}
+	if err := qb.applyHiddenArgs("query"); err != nil {
+		return QueryDefinition{}, InputTypeDefinition{}, err
+	}
 	if qb.relay {
 		if !qb.returnsList {
-			return fmt.Errorf(
+			return QueryDefinition{}, InputTypeDefinition{}, fmt.Errorf(
 				"query %q: Relay(true) requires ReturnsArray(true); relay connections only apply to list queries",
 				qb.name,
 			)
 		}
 		if qb.config["sql_source"] == "" || qb.config["sql_source"] == nil {
-			return fmt.Errorf(
+			return QueryDefinition{}, InputTypeDefinition{}, fmt.Errorf(
 				"query %q: Relay(true) requires sql_source to be set via Config; the compiler needs the view name to derive the cursor column",
 				qb.name,
 			)
 		}
 	}
+	if view, ok := qb.config["materialized_view"]; ok {
+		name, isString := view.(string)
+		if !isString || strings.TrimSpace(name) == "" {
+			return QueryDefinition{}, InputTypeDefinition{}, fmt.Errorf("query %q: materialized_view must be a non-empty view name", qb.name)
+		}
+		source, _ := qb.config["sql_source"].(string)
+		if source == "" {
+			return QueryDefinition{}, InputTypeDefinition{}, fmt.Errorf(
+				"query %q: materialized_view %q requires sql_source to be set; the runtime falls back to it for fresh reads",
+				qb.name, name,
+			)
+		}
+		if source == name {
+			return QueryDefinition{}, InputTypeDefinition{}, fmt.Errorf("query %q: materialized_view and sql_source must name different views, both are %q", qb.name, name)
+		}
+	}
+	if qb.count {
+		if qb.returnType != "Int" || qb.returnsList {
+			return QueryDefinition{}, InputTypeDefinition{}, fmt.Errorf("query %q: Count() returns Int; do not combine it with ReturnType or ReturnsArray", qb.name)
+		}
+		if qb.relay || qb.vectorSearch != nil {
+			return QueryDefinition{}, InputTypeDefinition{}, fmt.Errorf("query %q: Count() cannot be combined with Relay or NearestNeighbors", qb.name)
+		}
+		if source, _ := qb.config["sql_source"].(string); source == "" {
+			return QueryDefinition{}, InputTypeDefinition{}, fmt.Errorf("query %q: Count() requires sql_source to be set; the compiler counts rows of that view", qb.name)
+		}
+	}
+	if err := qb.validateComplexity(); err != nil {
+		return QueryDefinition{}, InputTypeDefinition{}, err
+	}
+	if err := qb.validateLTreeFilters(); err != nil {
+		return QueryDefinition{}, InputTypeDefinition{}, err
+	}
+	if err := qb.validateVectorSearch(); err != nil {
+		return QueryDefinition{}, InputTypeDefinition{}, err
+	}
+	if err := qb.validateIPRangeFilters(); err != nil {
+		return QueryDefinition{}, InputTypeDefinition{}, err
+	}
+	if err := qb.validateDateRangeFilters(); err != nil {
+		return QueryDefinition{}, InputTypeDefinition{}, err
+	}
+	if err := qb.validateSpatialFilters(); err != nil {
+		return QueryDefinition{}, InputTypeDefinition{}, err
+	}
+	arguments := qb.arguments
+	var filterInput InputTypeDefinition
+	if qb.filterInput != "" {
+		var err error
+		if filterInput, arguments, err = qb.buildFilterInput(); err != nil {
+			return QueryDefinition{}, InputTypeDefinition{}, err
+		}
+	}
+	if err := qb.validateCacheKey(arguments); err != nil {
+		return QueryDefinition{}, InputTypeDefinition{}, err
+	}
+	if err := qb.validateArgAliasTargets(arguments); err != nil {
+		return QueryDefinition{}, InputTypeDefinition{}, err
+	}
 
 	definition := QueryDefinition{
 		Name:              qb.name,
 		ReturnType:        qb.returnType,
+		Profile:           qb.profile,
 		ReturnsList:       qb.returnsList,
 		Nullable:          qb.nullable,
-		Arguments:         qb.arguments,
+		Arguments:         arguments,
 		Description:       qb.description,
 		Relay:             qb.relay,
 		RelayCursorColumn: qb.relayCursorColumn,
@@ -292,8 +822,68 @@ func (qb *QueryBuilder) Register() error {
 			definition.Config = remaining
 		}
 	}
+	if len(qb.ltreeFilters) > 0 {
+		setConfig(&definition.Config, "ltree_filters", qb.ltreeFilterConfig())
+	}
+	if qb.vectorSearch != nil {
+		setConfig(&definition.Config, "vector_search", qb.vectorSearchConfig())
+	}
+	if len(qb.ipRangeFilters) > 0 {
+		setConfig(&definition.Config, "ip_range_filters", qb.ipRangeFilterConfig())
+	}
+	if len(qb.dateRangeFilters) > 0 {
+		setConfig(&definition.Config, "date_range_filters", qb.dateRangeFilterConfig())
+	}
+	if len(qb.spatialFilters) > 0 {
+		setConfig(&definition.Config, "spatial_filters", qb.spatialFilterConfig())
+	}
+	if qb.count {
+		setConfig(&definition.Config, "count", true)
+	}
+	if qb.rateLimit != nil {
+		setConfig(&definition.Config, "rate_limit", qb.rateLimitConfig())
+	}
+	if qb.complexity != nil {
+		setConfig(&definition.Config, "complexity", *qb.complexity)
+	}
+	if len(qb.argAliases) > 0 {
+		setConfig(&definition.Config, "argument_aliases", qb.argAliasConfig())
+	}
+	if qb.cacheKey != nil {
+		setConfig(&definition.Config, "cache_key", qb.cacheKey)
+	}
+	if qb.filterInput == "" {
+		return definition, InputTypeDefinition{}, nil
+	}
+
+	setConfig(&definition.Config, "filter_input", map[string]interface{}{
+		"arg":        filterArgName,
+		"input_type": qb.filterInput,
+	})
+	return definition, filterInput, nil
+}
 
-	return RegisterQuery(definition)
+// setConfig sets key in an operation definition's Config, creating the map
+// when the definition has no config yet.
+func setConfig(config *map[string]interface{}, key string, value interface{}) {
+	if *config == nil {
+		*config = make(map[string]interface{})
+	}
+	(*config)[key] = value
+}
+
+// Register registers the query with the global schema registry.
+// Returns an error if the query has no name or return type, or if a query
+// with the same name is already registered.
+func (qb *QueryBuilder) Register() error {
+	definition, filterInput, err := qb.build()
+	if err != nil {
+		return err
+	}
+	if qb.filterInput == "" {
+		return RegisterQuery(definition)
+	}
+	return registerWithFilterInput(definition, filterInput)
 }
 
 // MutationBuilder provides a fluent interface for building GraphQL mutations
@@ -303,6 +893,8 @@ type MutationBuilder struct {
 	invalidatesViews      []string
 	invalidatesFactTables []string
 	deprecation           *DeprecationInfo
+	beforeHooks           []string
+	afterHooks            []string
 }
 
 // NewMutation creates a new mutation builder
@@ -322,6 +914,14 @@ func (mb *MutationBuilder) ReturnType(returnType interface{}) *MutationBuilder {
 	return mb
 }
 
+// ReturnsScalar sets a scalar return type for the mutation (e.g. "Boolean").
+// The name must be a built-in GraphQL scalar, a FraiseQL scalar, or a registered
+// custom scalar; Register returns an error otherwise.
+func (mb *MutationBuilder) ReturnsScalar(scalar string) *MutationBuilder {
+	mb.setReturnsScalar(scalar)
+	return mb
+}
+
 // ReturnsArray sets whether the mutation returns an array
 func (mb *MutationBuilder) ReturnsArray(b bool) *MutationBuilder {
 	mb.setReturnsArray(b)
@@ -347,6 +947,15 @@ func (mb *MutationBuilder) Arg(name string, graphQLType string, defaultValue int
 	return mb
 }
 
+// ArgList adds a required argument holding a list of input objects, e.g.
+// ArgList("items", "CreateItemInput", false) declares items: [CreateItemInput!]!.
+// nullableElements allows null list elements. Register returns an error unless
+// inputType is a registered input type.
+func (mb *MutationBuilder) ArgList(name, inputType string, nullableElements bool) *MutationBuilder {
+	mb.addInputListArg(name, inputType, nullableElements)
+	return mb
+}
+
 // Description sets the description for the mutation
 func (mb *MutationBuilder) Description(desc string) *MutationBuilder {
 	mb.setDescription(desc)
@@ -388,6 +997,24 @@ func (mb *MutationBuilder) InvalidatesFactTables(tables []string) *MutationBuild
 	return mb
 }
 
+// Before adds a database function the runtime calls before the mutation's own
+// function, in the same transaction, e.g. to validate the input. Hooks run in
+// the order they are added and are exported as the "before_hooks" config key.
+// Unlike observers, which react to events afterwards, hooks are part of the
+// mutation itself.
+func (mb *MutationBuilder) Before(fn string) *MutationBuilder {
+	mb.beforeHooks = append(mb.beforeHooks, fn)
+	return mb
+}
+
+// After adds a database function the runtime calls after the mutation's own
+// function succeeds, e.g. to write an audit record. Hooks run in the order
+// they are added and are exported as the "after_hooks" config key.
+func (mb *MutationBuilder) After(fn string) *MutationBuilder {
+	mb.afterHooks = append(mb.afterHooks, fn)
+	return mb
+}
+
 // RestPath sets the REST endpoint path for this mutation.
 func (mb *MutationBuilder) RestPath(path string) *MutationBuilder {
 	mb.restPath = path
@@ -407,20 +1034,89 @@ func (mb *MutationBuilder) Deprecated(reason string) *MutationBuilder {
 	return mb
 }
 
-// Register registers the mutation with the global schema registry.
-// Returns an error if a mutation with the same name is already registered.
-func (mb *MutationBuilder) Register() error {
+// DeprecateArg marks the named argument of this mutation as deprecated with the given reason.
+// The argument must be added with Arg and be optional; Register returns an error otherwise.
+func (mb *MutationBuilder) DeprecateArg(name, reason string) *MutationBuilder {
+	mb.deprecateArg(name, reason)
+	return mb
+}
+
+// HideArg hides the named argument of this mutation from introspection: the
+// runtime still accepts it, e.g. from internal callers, but the public schema
+// does not show it. The argument must be added with Arg and be optional;
+// Register returns an error otherwise.
+func (mb *MutationBuilder) HideArg(name string) *MutationBuilder {
+	mb.hideArg(name)
+	return mb
+}
+
+// DescribeArg sets the description of the named argument of this mutation, which is
+// exported with the argument for generated docs. The argument must be added
+// with Arg; Register returns an error otherwise.
+func (mb *MutationBuilder) DescribeArg(name, description string) *MutationBuilder {
+	mb.describeArg(name, description)
+	return mb
+}
+
+// Route sets a connection-routing hint for the runtime: "replica" or "primary".
+// It is exported as the "route" config key and validated by Register.
+func (mb *MutationBuilder) Route(route string) *MutationBuilder {
+	mb.setRoute(route)
+	return mb
+}
+
+// Profile restricts this mutation to the named build profile (e.g. "enterprise").
+// The name may contain only letters, digits, underscores and hyphens; Register
+// returns an error otherwise. See ExportSchemaForProfile.
+func (mb *MutationBuilder) Profile(profile string) *MutationBuilder {
+	mb.profile = profile
+	return mb
+}
+
+// MustRegister is like Register but panics if registration fails.
+// It is intended for package-level registration in init().
+func (mb *MutationBuilder) MustRegister() {
+	if err := mb.Register(); err != nil {
+		panic(err)
+	}
+}
+
+// build validates the builder and assembles the mutation definition without
+// registering it.
+func (mb *MutationBuilder) build() (MutationDefinition, error) {
+	if err := mb.validate("mutation"); err != nil {
+		return MutationDefinition{}, err
+	}
+	if err := mb.applyArgDeprecations("mutation"); err != nil {
+		return MutationDefinition{}, err
+	}
+	if err := mb.applyArgDescriptions("mutation"); err != nil {
+		return MutationDefinition{}, err
+	}
+	if err := mb.applyHiddenArgs("mutation"); err != nil {
+		return MutationDefinition{}, err
+	}
+	for _, hook := range append(append([]string{}, mb.beforeHooks...), mb.afterHooks...) {
+		if !hookNamePattern.MatchString(hook) {
+			return MutationDefinition{}, fmt.Errorf(
+				"mutation %q: invalid hook %q; hooks must name a database function, e.g. fn_audit or audit.fn_log",
+				mb.name, hook,
+			)
+		}
+	}
+
 	definition := MutationDefinition{
-		Name:                 mb.name,
-		ReturnType:           mb.returnType,
-		ReturnsList:          mb.returnsList,
-		Nullable:             mb.nullable,
-		Arguments:            mb.arguments,
-		Description:          mb.description,
-		InjectParams:         mb.injectParams,
-		InvalidatesViews:     mb.invalidatesViews,
+		Name:                  mb.name,
+		ReturnType:            mb.returnType,
+		Profile:               mb.profile,
+		ReturnsList:           mb.returnsList,
+		Nullable:              mb.nullable,
+		Arguments:             mb.arguments,
+		Description:           mb.description,
+		InjectParams:          mb.injectParams,
+		InvalidatesViews:      mb.invalidatesViews,
 		InvalidatesFactTables: mb.invalidatesFactTables,
-		Deprecation:          mb.deprecation,
+		Deprecation:           mb.deprecation,
 	}
 
 	if mb.restPath != "" {
@@ -451,10 +1147,36 @@ func (mb *MutationBuilder) Register() error {
 			definition.Config = remaining
 		}
 	}
+	if len(mb.beforeHooks) > 0 {
+		setConfig(&definition.Config, "before_hooks", mb.beforeHooks)
+	}
+	if len(mb.afterHooks) > 0 {
+		setConfig(&definition.Config, "after_hooks", mb.afterHooks)
+	}
+	if mb.rateLimit != nil {
+		setConfig(&definition.Config, "rate_limit", mb.rateLimitConfig())
+	}
+	if len(mb.argAliases) > 0 {
+		setConfig(&definition.Config, "argument_aliases", mb.argAliasConfig())
+	}
+
+	return definition, nil
+}
 
+// Register registers the mutation with the global schema registry.
+// Returns an error if the mutation has no name or return type, or if a mutation
+// with the same name is already registered.
+func (mb *MutationBuilder) Register() error {
+	definition, err := mb.build()
+	if err != nil {
+		return err
+	}
 	return RegisterMutation(definition)
 }
 
+// hookNamePattern matches a database function name, optionally schema-qualified.
+var hookNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
 // NOTE: FactTableBuilder removed - use analytics.NewFactTable() instead
 // The analytics module provides better-structured fact table builders
 // with support for Measure and Dimension types.
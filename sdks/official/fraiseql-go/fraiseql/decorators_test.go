@@ -0,0 +1,667 @@
+package fraiseql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ---- Scalar return types ----
+
+func TestQueryReturnsScalar(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewQuery("userCount").ReturnsScalar("Int").SqlSource("v_user").Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	q := findQuery(schemaMap(t), "userCount")
+	if q == nil {
+		t.Fatal("query 'userCount' not found")
+	}
+	if got := q["return_type"]; got != "Int" {
+		t.Errorf("return_type: want Int, got %v", got)
+	}
+	if errs := ValidateSchema(); errs != nil {
+		t.Errorf("scalar return type should validate, got %v", errs)
+	}
+}
+
+func TestQueryReturnTypeFromGoScalarValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{0, "Int"},
+		{int64(0), "Int"},
+		{0.0, "Float"},
+		{false, "Boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			if err := NewQuery("value").ReturnType(tt.value).Register(); err != nil {
+				t.Fatalf("Register: %v", err)
+			}
+			if got := GetSchema().Queries[0].ReturnType; got != tt.want {
+				t.Errorf("ReturnType(%T): want %q, got %q", tt.value, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestQueryReturnsFraiseQLScalar(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewQuery("serverTime").ReturnsScalar("DateTime").Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if errs := ValidateSchema(); errs != nil {
+		t.Errorf("FraiseQL scalar return type should validate, got %v", errs)
+	}
+}
+
+func TestReturnsScalarRejectsUnknownScalar(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	err := NewQuery("userCount").ReturnsScalar("Integer").Register()
+	if err == nil {
+		t.Fatal("expected error for unknown scalar return type")
+	}
+	if !strings.Contains(err.Error(), "not a known scalar") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err = NewMutation("purge").ReturnsScalar("User").Register()
+	if err == nil {
+		t.Fatal("expected error for non-scalar name passed to ReturnsScalar")
+	}
+}
+
+func TestMutationReturnsScalar(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewMutation("deleteUser").
+		ReturnsScalar("Boolean").
+		SqlSource("fn_delete_user").
+		Arg("id", "ID", nil).
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	m := findMutation(schemaMap(t), "deleteUser")
+	if m == nil {
+		t.Fatal("mutation 'deleteUser' not found")
+	}
+	if got := m["return_type"]; got != "Boolean" {
+		t.Errorf("return_type: want Boolean, got %v", got)
+	}
+}
+
+func TestReturnsScalarAcceptsCustomScalar(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	RegisterCustomScalar(&PhoneScalar{})
+	if err := NewQuery("supportPhone").ReturnsScalar("Phone").Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+}
+
+// ---- Argument deprecation ----
+
+func TestDeprecateArg(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewQuery("users").
+		ReturnType("User").
+		ReturnsArray(true).
+		Arg("userId", "ID", nil, true).
+		Arg("oldParam", "String", nil, true).
+		DeprecateArg("oldParam", "use userId").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	q := findQuery(schemaMap(t), "users")
+	args := q["arguments"].([]interface{})
+	if _, has := args[0].(map[string]interface{})["deprecated"]; has {
+		t.Error("userId should not be deprecated")
+	}
+	dep, ok := args[1].(map[string]interface{})["deprecated"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected deprecated object on oldParam, got %v", args[1])
+	}
+	if dep["reason"] != "use userId" {
+		t.Errorf("reason: want %q, got %v", "use userId", dep["reason"])
+	}
+}
+
+func TestDeprecateArgOnMutation(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewMutation("updateUser").
+		ReturnType("User").
+		Arg("id", "ID", nil).
+		Arg("fullName", "String", "", false).
+		DeprecateArg("fullName", "use name").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	arg := GetSchema().Mutations[0].Arguments[1]
+	if arg.Deprecated == nil || arg.Deprecated.Reason != "use name" {
+		t.Errorf("expected defaulted argument to be deprecated, got %+v", arg)
+	}
+}
+
+func TestDeprecateArgValidation(t *testing.T) {
+	t.Run("unknown argument", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := NewQuery("users").ReturnType("User").DeprecateArg("missing", "gone").Register()
+		if err == nil || !strings.Contains(err.Error(), `"missing"`) {
+			t.Errorf("expected error naming the missing argument, got %v", err)
+		}
+	})
+
+	t.Run("required argument", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := NewQuery("user").ReturnType("User").Arg("id", "ID", nil).DeprecateArg("id", "x").Register()
+		if err == nil || !strings.Contains(err.Error(), "required") {
+			t.Errorf("expected error for deprecating a required argument, got %v", err)
+		}
+	})
+}
+
+// ---- Argument descriptions ----
+
+func TestDescribeArg(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewQuery("users").
+		ReturnType("User").
+		ReturnsArray(true).
+		Arg("limit", "Int", 20).
+		Arg("search", "String", nil, true).
+		DescribeArg("limit", "Maximum number of users to return").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewMutation("deleteUser").
+		ReturnType("User").
+		Arg("id", "ID", nil).
+		DescribeArg("id", "ID of the user to delete").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	args := findQuery(schemaMap(t), "users")["arguments"].([]interface{})
+	if got := args[0].(map[string]interface{})["description"]; got != "Maximum number of users to return" {
+		t.Errorf("limit description: got %v", got)
+	}
+	if _, has := args[1].(map[string]interface{})["description"]; has {
+		t.Error("undescribed argument should not export a description")
+	}
+	if got := GetSchema().Mutations[0].Arguments[0].Description; got != "ID of the user to delete" {
+		t.Errorf("mutation argument description: got %q", got)
+	}
+}
+
+func TestDescribeArgUnknownArgument(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	err := NewMutation("deleteUser").ReturnType("User").Arg("id", "ID", nil).DescribeArg("userId", "x").Register()
+	if err == nil || !strings.Contains(err.Error(), `DescribeArg: no argument named "userId"`) {
+		t.Errorf("expected error naming the missing argument, got %v", err)
+	}
+	if len(GetSchema().Mutations) != 0 {
+		t.Error("invalid mutation should not be registered")
+	}
+}
+
+func TestRegisterValidation(t *testing.T) {
+	t.Run("empty query name", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := NewQuery("  ").ReturnType("User").Register()
+		if err == nil || !strings.Contains(err.Error(), "name must not be empty") {
+			t.Errorf("expected empty-name error, got %v", err)
+		}
+		if len(GetSchema().Queries) != 0 {
+			t.Error("invalid query should not be registered")
+		}
+	})
+
+	t.Run("empty mutation name", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := NewMutation("").ReturnType("User").Register()
+		if err == nil || !strings.Contains(err.Error(), "mutation name must not be empty") {
+			t.Errorf("expected empty-name error, got %v", err)
+		}
+	})
+
+	t.Run("missing return type", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		if err := NewQuery("users").Register(); err == nil || !strings.Contains(err.Error(), "no return type") {
+			t.Errorf("expected missing-return-type error for query, got %v", err)
+		}
+		if err := NewMutation("createUser").Register(); err == nil || !strings.Contains(err.Error(), "no return type") {
+			t.Errorf("expected missing-return-type error for mutation, got %v", err)
+		}
+	})
+
+	t.Run("unmappable return type value", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := NewQuery("users").ReturnType(make(chan int)).Register()
+		if err == nil || !strings.Contains(err.Error(), "no return type") {
+			t.Errorf("expected missing-return-type error, got %v", err)
+		}
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		if err := NewQuery("users").ReturnType("User").Register(); err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+		err := NewQuery("users").ReturnType("User").Register()
+		if err == nil || !strings.Contains(err.Error(), "already registered") {
+			t.Errorf("expected duplicate error, got %v", err)
+		}
+	})
+}
+
+func TestMustRegister(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	NewQuery("users").ReturnType("User").MustRegister()
+	NewMutation("createUser").ReturnType("User").MustRegister()
+	if len(GetSchema().Queries) != 1 || len(GetSchema().Mutations) != 1 {
+		t.Fatal("MustRegister should register valid operations")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected MustRegister to panic on invalid query")
+		}
+	}()
+	NewQuery("broken").MustRegister()
+}
+
+func TestRoute(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewQuery("salesReport").ReturnType("Report").Route("replica").Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewMutation("createOrder").ReturnType("Order").Route("primary").Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	schema := GetSchema()
+	if got := schema.Queries[0].Config["route"]; got != "replica" {
+		t.Errorf("query route: want replica, got %v", got)
+	}
+	if got := schema.Mutations[0].Config["route"]; got != "primary" {
+		t.Errorf("mutation route: want primary, got %v", got)
+	}
+}
+
+func TestRouteValidation(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	err := NewQuery("salesReport").ReturnType("Report").Route("secondary").Register()
+	if err == nil || !strings.Contains(err.Error(), "invalid route") {
+		t.Errorf("expected invalid route error, got %v", err)
+	}
+
+	err = NewMutation("createOrder").
+		ReturnType("Order").
+		Config(map[string]interface{}{"route": 3}).
+		Register()
+	if err == nil || !strings.Contains(err.Error(), "invalid route") {
+		t.Errorf("expected invalid route error for non-string config value, got %v", err)
+	}
+
+	if len(GetSchema().Queries) != 0 || len(GetSchema().Mutations) != 0 {
+		t.Error("operations with invalid routes should not be registered")
+	}
+}
+
+func TestMaterializedView(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewQuery("salesSummary").
+		ReturnType("SalesSummary").
+		SqlSource("v_sales_summary").
+		MaterializedView("mv_sales_summary").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	q := GetSchema().Queries[0]
+	if q.SqlSource != "v_sales_summary" {
+		t.Errorf("sql_source: want v_sales_summary, got %q", q.SqlSource)
+	}
+	if q.Config["materialized_view"] != "mv_sales_summary" {
+		t.Errorf("materialized_view: want mv_sales_summary, got %v", q.Config["materialized_view"])
+	}
+	if _, leaked := q.Config["sql_source"]; leaked {
+		t.Error("sql_source should be lifted out of config")
+	}
+}
+
+func TestMaterializedViewValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *QueryBuilder
+		wantErr string
+	}{
+		{
+			name:    "missing sql_source",
+			builder: NewQuery("salesSummary").ReturnType("SalesSummary").MaterializedView("mv_sales_summary"),
+			wantErr: "requires sql_source",
+		},
+		{
+			name:    "empty view",
+			builder: NewQuery("salesSummary").ReturnType("SalesSummary").SqlSource("v_sales_summary").MaterializedView(""),
+			wantErr: "non-empty view name",
+		},
+		{
+			name: "non-string config value",
+			builder: NewQuery("salesSummary").ReturnType("SalesSummary").Config(map[string]interface{}{
+				"sql_source":        "v_sales_summary",
+				"materialized_view": true,
+			}),
+			wantErr: "non-empty view name",
+		},
+		{
+			name:    "same view as sql_source",
+			builder: NewQuery("salesSummary").ReturnType("SalesSummary").SqlSource("v_sales").MaterializedView("v_sales"),
+			wantErr: "must name different views",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := tt.builder.Register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCountQuery(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewQuery("userCount").
+		SqlSource("v_user").
+		Arg("isActive", "Boolean", nil, true).
+		Count().
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	q := GetSchema().Queries[0]
+	if q.ReturnType != "Int" || q.ReturnsList || q.Nullable {
+		t.Errorf("expected a non-null Int return, got %q (list=%v, nullable=%v)", q.ReturnType, q.ReturnsList, q.Nullable)
+	}
+	if q.Config["count"] != true {
+		t.Errorf("count: want true, got %v", q.Config["count"])
+	}
+	if q.SqlSource != "v_user" || len(q.Arguments) != 1 || q.Arguments[0].Name != "isActive" {
+		t.Errorf("expected sql_source and filter args to be kept, got %+v", q)
+	}
+	if errs := ValidateSchema(); errs != nil {
+		t.Errorf("expected a valid schema, got %v", errs)
+	}
+}
+
+func TestCountQueryValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *QueryBuilder
+		wantErr string
+	}{
+		{
+			name:    "missing sql_source",
+			builder: NewQuery("userCount").Count(),
+			wantErr: "requires sql_source",
+		},
+		{
+			name:    "list return",
+			builder: NewQuery("userCount").SqlSource("v_user").Count().ReturnsArray(true),
+			wantErr: "returns Int",
+		},
+		{
+			name:    "other return type",
+			builder: NewQuery("userCount").SqlSource("v_user").Count().ReturnType("User"),
+			wantErr: "returns Int",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := tt.builder.Register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestArgDefaultTypeValidation(t *testing.T) {
+	t.Run("well-typed defaults", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := NewQuery("users").
+			ReturnType("User").
+			ReturnsArray(true).
+			Arg("limit", "Int", 10).
+			Arg("ratio", "Float", 1).
+			Arg("active", "Boolean", true).
+			Arg("search", "String", "", true).
+			Arg("after", "ID", ID("abc")).
+			Arg("role", "Role", "ADMIN").
+			Register()
+		if err != nil {
+			t.Fatalf("well-typed defaults should register: %v", err)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		register func() error
+		wantErr  string
+	}{
+		{
+			name: "string default for Int",
+			register: func() error {
+				return NewQuery("users").ReturnType("User").Arg("limit", "Int", "10").Register()
+			},
+			wantErr: `query "users": argument "limit" has default "10" (string) which is not compatible with type Int`,
+		},
+		{
+			name: "float default for Int",
+			register: func() error {
+				return NewQuery("users").ReturnType("User").Arg("limit", "Int!", 1.5).Register()
+			},
+			wantErr: `argument "limit" has default 1.5 (float64) which is not compatible with type Int!`,
+		},
+		{
+			name: "int default for Boolean on a mutation",
+			register: func() error {
+				return NewMutation("archive").ReturnType("User").Arg("force", "Boolean", 1).Register()
+			},
+			wantErr: `mutation "archive": argument "force"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := tt.register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestListArgTypeValidation(t *testing.T) {
+	t.Run("well-formed list types", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := NewQuery("usersByIds").
+			ReturnType("User").
+			ReturnsArray(true).
+			Arg("ids", "[ID!]!", nil).
+			Arg("tags", ListType("String", true), nil, true).
+			Arg("matrix", "[[Int!]]", nil).
+			Arg("roles", ListType("Role", false), nil).
+			Register()
+		if err != nil {
+			t.Fatalf("well-formed list args should register: %v", err)
+		}
+
+		args := GetSchema().Queries[0].Arguments
+		want := []struct {
+			typ      string
+			nullable bool
+		}{{"[ID!]!", false}, {"[String]", true}, {"[[Int!]]", false}, {"[Role!]", false}}
+		for i, w := range want {
+			if args[i].Type != w.typ || args[i].Nullable != w.nullable {
+				t.Errorf("arg %d: want %s (nullable=%v), got %+v", i, w.typ, w.nullable, args[i])
+			}
+		}
+	})
+
+	for _, typ := range []string{"[ID!", "ID]", "[ID!]!!", "[]", "[ID!]]", "[ID!]![", "!ID", "my-type", ""} {
+		t.Run("malformed "+typ, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := NewQuery("q").ReturnType("User").Arg("ids", typ, nil).Register()
+			if err == nil || !strings.Contains(err.Error(), `argument "ids" has malformed type`) {
+				t.Errorf("expected malformed type error for %q, got %v", typ, err)
+			}
+		})
+	}
+
+	t.Run("non-null type marked nullable", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := NewMutation("tagAll").ReturnType("User").Arg("ids", "[ID!]!", nil, true).Register()
+		if err == nil || !strings.Contains(err.Error(), `has non-null type "[ID!]!" but is marked nullable`) {
+			t.Errorf("expected nullable conflict error, got %v", err)
+		}
+	})
+}
+
+func TestDuplicateArgNames(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	err := NewQuery("user").ReturnType("User").Arg("id", "ID", nil).Arg("id", "ID", nil).Register()
+	if err == nil || !strings.Contains(err.Error(), `query "user": argument "id" is declared more than once`) {
+		t.Errorf("expected duplicate argument error, got %v", err)
+	}
+
+	err = NewMutation("updateUser").ReturnType("User").Arg("id", "ID", nil).Arg("name", "String", nil).Arg("id", "UUID", nil).Register()
+	if err == nil || !strings.Contains(err.Error(), `mutation "updateUser": argument "id" is declared more than once`) {
+		t.Errorf("expected duplicate argument error, got %v", err)
+	}
+}
+
+func TestMutationHooks(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewMutation("createUser").
+		ReturnType("User").
+		SqlSource("fn_create_user").
+		Before("fn_validate_user").
+		Before("fn_check_quota").
+		After("audit.fn_log").
+		After("fn_notify").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	m := GetSchema().Mutations[0]
+	if got := m.Config["before_hooks"]; !reflect.DeepEqual(got, []string{"fn_validate_user", "fn_check_quota"}) {
+		t.Errorf("before_hooks: got %v", got)
+	}
+	if got := m.Config["after_hooks"]; !reflect.DeepEqual(got, []string{"audit.fn_log", "fn_notify"}) {
+		t.Errorf("after_hooks: got %v", got)
+	}
+
+	for _, hook := range []string{"", "fn validate", "1fn", "a.b.c"} {
+		err := NewMutation("m").ReturnType("User").Before(hook).Register()
+		if err == nil || !strings.Contains(err.Error(), "invalid hook") {
+			t.Errorf("hook %q: expected invalid hook error, got %v", hook, err)
+		}
+	}
+	if err := NewMutation("m").ReturnType("User").After("").Register(); err == nil {
+		t.Error("expected an error for an empty after hook")
+	}
+}
+
+func TestQuerySingle(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewQuery("user").
+		ReturnType("User").
+		ReturnsArray(true).
+		Single().
+		Arg("id", "ID", nil).
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	q := GetSchema().Queries[0]
+	if q.ReturnsList || !q.Nullable {
+		t.Errorf("expected a nullable non-list query, got returns_list=%v nullable=%v", q.ReturnsList, q.Nullable)
+	}
+}
@@ -0,0 +1,183 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// descriptionRegistry holds the descriptions set with SetDescriptions.
+type descriptionRegistry struct {
+	mu           sync.RWMutex
+	descriptions map[string]string
+}
+
+// Global instance
+var descriptions = &descriptionRegistry{}
+
+// SetDescriptions sets descriptions to merge into types, input types and
+// fields as they are registered, replacing any set before. Keys are a type or
+// input type name ("User"), a type and GraphQL field name ("User.email"), or
+// an enum and member name ("Role.ADMIN"). A description passed to
+// RegisterType or set on a definition or FieldInfo takes precedence.
+//
+// Call it before registering types, typically with the output of
+// GenerateDescriptions. Reset() clears the descriptions.
+func SetDescriptions(m map[string]string) {
+	descriptions.mu.Lock()
+	defer descriptions.mu.Unlock()
+	descriptions.descriptions = make(map[string]string, len(m))
+	for k, v := range m {
+		descriptions.descriptions[k] = v
+	}
+}
+
+// SetDescriptionsJSON is like SetDescriptions but reads a JSON object of
+// descriptions, such as one written by a go:generate step.
+func SetDescriptionsJSON(data []byte) error {
+	var m map[string]string
+	if err := json.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf("invalid descriptions JSON: %w", err)
+	}
+	SetDescriptions(m)
+	return nil
+}
+
+// withDescriptions fills in the empty type and field descriptions of def from
+// the descriptions set with SetDescriptions. The caller's Fields slice is
+// copied, not modified.
+func withDescriptions(def TypeDefinition) TypeDefinition {
+	def.Description, def.Fields = describe(def.Name, def.Description, def.Fields)
+	return def
+}
+
+// withInputDescriptions is withDescriptions for an input type.
+func withInputDescriptions(def InputTypeDefinition) InputTypeDefinition {
+	def.Description, def.Fields = describe(def.Name, def.Description, def.Fields)
+	return def
+}
+
+// describe merges the descriptions set with SetDescriptions into the
+// description and fields of the type or input type typeName.
+func describe(typeName, description string, fields []FieldInfo) (string, []FieldInfo) {
+	descriptions.mu.RLock()
+	defer descriptions.mu.RUnlock()
+	if len(descriptions.descriptions) == 0 {
+		return description, fields
+	}
+
+	if description == "" {
+		description = descriptions.descriptions[typeName]
+	}
+	if fields == nil {
+		return description, nil
+	}
+	described := make([]FieldInfo, len(fields))
+	copy(described, fields)
+	for i := range described {
+		if described[i].Description == "" {
+			described[i].Description = descriptions.descriptions[typeName+"."+described[i].Name]
+		}
+	}
+	return description, described
+}
+
+// GenerateDescriptions parses the Go source files in dir (test files
+// excluded) and returns the doc comments of its struct types and their fields,
+// keyed for SetDescriptions. Field keys use the GraphQL field name from the
+// fraiseql tag, falling back to the Go field name as RegisterTypes does.
+//
+// It is meant for a go:generate step that writes the map as JSON, since
+// reflection cannot read doc comments at run time.
+func GenerateDescriptions(dir string) (map[string]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string)
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+
+				doc := typeSpec.Doc
+				if doc == nil && len(gen.Specs) == 1 {
+					doc = gen.Doc
+				}
+				if text := commentText(doc); text != "" {
+					result[typeSpec.Name.Name] = text
+				}
+
+				for _, field := range structType.Fields.List {
+					text := commentText(field.Doc)
+					if text == "" {
+						text = commentText(field.Comment)
+					}
+					if text == "" {
+						continue
+					}
+					for _, name := range field.Names {
+						if !name.IsExported() {
+							continue
+						}
+						result[typeSpec.Name.Name+"."+astFieldName(name.Name, field.Tag)] = text
+					}
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// astFieldName returns the GraphQL field name for a struct field parsed from
+// source: the name part of its fraiseql tag, or goName if there is none.
+func astFieldName(goName string, tag *ast.BasicLit) string {
+	if tag == nil {
+		return goName
+	}
+	raw, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		return goName
+	}
+	fraiseqlTag, ok := reflect.StructTag(raw).Lookup("fraiseql")
+	if !ok {
+		return goName
+	}
+	name := strings.TrimSpace(strings.Split(fraiseqlTag, ",")[0])
+	if name == "" || strings.Contains(name, "=") {
+		return goName
+	}
+	return name
+}
+
+func commentText(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	return strings.TrimSpace(group.Text())
+}
@@ -0,0 +1,180 @@
+package fraiseql
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetDescriptionsMergesAtRegistration(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Author struct {
+		ID   ID     `fraiseql:"id"`
+		Name string `fraiseql:"name"`
+		Bio  string
+	}
+
+	SetDescriptions(map[string]string{
+		"Author":      "A person who writes posts.",
+		"Author.name": "Display name.",
+		"Author.Bio":  "Short biography.",
+		"Post":        "Not used: RegisterType passes its own description.",
+		"Post.title":  "Headline of the post.",
+	})
+	if err := RegisterTypes(Author{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	fields := []FieldInfo{
+		{Name: "id", Type: "ID", Description: "Explicit descriptions win."},
+		{Name: "title", Type: "String"},
+	}
+	if err := RegisterType("Post", fields, "A blog post"); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if fields[1].Description != "" {
+		t.Error("the caller's fields slice should not be modified")
+	}
+
+	schema := GetSchema()
+	author, post := schema.Types[0], schema.Types[1]
+	if author.Description != "A person who writes posts." {
+		t.Errorf("Author description: got %q", author.Description)
+	}
+	if author.Fields[0].Description != "" || author.Fields[1].Description != "Display name." ||
+		author.Fields[2].Description != "Short biography." {
+		t.Errorf("unexpected Author field descriptions: %+v", author.Fields)
+	}
+	if post.Description != "A blog post" {
+		t.Errorf("explicit type description should win, got %q", post.Description)
+	}
+	if post.Fields[0].Description != "Explicit descriptions win." || post.Fields[1].Description != "Headline of the post." {
+		t.Errorf("unexpected Post field descriptions: %+v", post.Fields)
+	}
+
+	// Re-registering the same struct stays idempotent with descriptions merged.
+	if err := RegisterTypes(Author{}); err != nil {
+		t.Errorf("identical re-registration should succeed, got %v", err)
+	}
+}
+
+func TestSetDescriptionsJSON(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := SetDescriptionsJSON([]byte(`{"Tag": "A label.", "Tag.name": "Label text."}`)); err != nil {
+		t.Fatalf("SetDescriptionsJSON: %v", err)
+	}
+	if err := RegisterType("Tag", []FieldInfo{{Name: "name", Type: "String"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	tag := GetSchema().Types[0]
+	if tag.Description != "A label." || tag.Fields[0].Description != "Label text." {
+		t.Errorf("unexpected descriptions: %+v", tag)
+	}
+
+	if err := SetDescriptionsJSON([]byte(`["not", "an", "object"]`)); err == nil {
+		t.Error("expected error for non-object descriptions JSON")
+	}
+}
+
+func TestSetDescriptionsMergesIntoInputTypes(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	SetDescriptions(map[string]string{
+		"CreateUserInput":       "Fields for a new user.",
+		"CreateUserInput.email": "Login address.",
+		"CreateUserInput.name":  "Not used: the field has its own description.",
+	})
+	fields := []FieldInfo{
+		{Name: "email", Type: "String"},
+		{Name: "name", Type: "String", Description: "Explicit descriptions win."},
+	}
+	if err := RegisterInputType(InputTypeDefinition{Name: "CreateUserInput", Fields: fields}); err != nil {
+		t.Fatalf("RegisterInputType: %v", err)
+	}
+	if fields[0].Description != "" {
+		t.Error("the caller's fields slice should not be modified")
+	}
+
+	input := GetSchema().InputTypes[0]
+	if input.Description != "Fields for a new user." {
+		t.Errorf("CreateUserInput description: got %q", input.Description)
+	}
+	if input.Fields[0].Description != "Login address." || input.Fields[1].Description != "Explicit descriptions win." {
+		t.Errorf("unexpected CreateUserInput field descriptions: %+v", input.Fields)
+	}
+}
+
+func TestResetClearsDescriptions(t *testing.T) {
+	Reset()
+	SetDescriptions(map[string]string{"Tag": "A label."})
+	Reset()
+	defer Reset()
+
+	if err := RegisterType("Tag", []FieldInfo{{Name: "name", Type: "String"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if d := GetSchema().Types[0].Description; d != "" {
+		t.Errorf("expected descriptions to be cleared by Reset, got %q", d)
+	}
+}
+
+func TestGenerateDescriptions(t *testing.T) {
+	dir := t.TempDir()
+	source := `package models
+
+// User is a registered account.
+type User struct {
+	// ID identifies the user.
+	ID    string ` + "`fraiseql:\"id\"`" + `
+	Email string ` + "`fraiseql:\"email,type=Email\"`" + ` // Primary contact address.
+	// Nickname has no tag, so the Go name is used.
+	Nickname string
+	// secret is unexported and skipped.
+	secret string
+	Plain  string
+}
+
+type (
+	// Post is a blog post.
+	Post struct {
+		// Title is the headline.
+		Title string ` + "`fraiseql:\"title\"`" + `
+	}
+
+	// Count is not a struct.
+	Count int
+)
+`
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	testSource := "package models\n\n// Fixture is test-only.\ntype Fixture struct{}\n"
+	if err := os.WriteFile(filepath.Join(dir, "models_test.go"), []byte(testSource), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GenerateDescriptions(dir)
+	if err != nil {
+		t.Fatalf("GenerateDescriptions: %v", err)
+	}
+	want := map[string]string{
+		"User":          "User is a registered account.",
+		"User.id":       "ID identifies the user.",
+		"User.email":    "Primary contact address.",
+		"User.Nickname": "Nickname has no tag, so the Go name is used.",
+		"Post":          "Post is a blog post.",
+		"Post.title":    "Title is the headline.",
+	}
+	if len(got) != len(want) {
+		t.Errorf("expected %d descriptions, got %d: %v", len(want), len(got), got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s: want %q, got %q", k, v, got[k])
+		}
+	}
+}
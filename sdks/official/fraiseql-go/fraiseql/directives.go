@@ -0,0 +1,191 @@
+package fraiseql
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// DirectiveDefinition declares a custom runtime directive and the locations
+// it may be used at.
+type DirectiveDefinition struct {
+	Name      string               `json:"name"`
+	Locations []string             `json:"locations"`
+	Arguments []ArgumentDefinition `json:"arguments,omitempty"`
+}
+
+// directiveLocations are the GraphQL directive locations, executable and type-system.
+var directiveLocations = map[string]bool{
+	"QUERY":                  true,
+	"MUTATION":               true,
+	"SUBSCRIPTION":           true,
+	"FIELD":                  true,
+	"FRAGMENT_DEFINITION":    true,
+	"FRAGMENT_SPREAD":        true,
+	"INLINE_FRAGMENT":        true,
+	"VARIABLE_DEFINITION":    true,
+	"SCHEMA":                 true,
+	"SCALAR":                 true,
+	"OBJECT":                 true,
+	"FIELD_DEFINITION":       true,
+	"ARGUMENT_DEFINITION":    true,
+	"INTERFACE":              true,
+	"UNION":                  true,
+	"ENUM":                   true,
+	"ENUM_VALUE":             true,
+	"INPUT_OBJECT":           true,
+	"INPUT_FIELD_DEFINITION": true,
+}
+
+// builtinDirectives are defined by the GraphQL specification and cannot be redeclared.
+var builtinDirectives = map[string]bool{
+	"deprecated":  true,
+	"include":     true,
+	"skip":        true,
+	"specifiedBy": true,
+	"oneOf":       true,
+}
+
+// graphQLNamePattern matches a GraphQL name.
+var graphQLNamePattern = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// RegisterDirective declares a custom directive so the compiler accepts it.
+// locations lists where it may appear, using the GraphQL names (FIELD,
+// FIELD_DEFINITION, OBJECT, INPUT_FIELD_DEFINITION, ...). Declared directives
+// are exported in the "directives" section and declared in the introspection
+// export.
+//
+// Struct fields use directives with the `directive=` tag key, at
+// FIELD_DEFINITION (INPUT_FIELD_DEFINITION on input types); struct types use
+// them through a blank field, at OBJECT. Separate several directives with
+// semicolons:
+//
+//	type User struct {
+//	    _     struct{} `fraiseql:"directive=cached"`
+//	    Email string   `fraiseql:"email,directive=sensitive;audited"`
+//	}
+//
+// ValidateSchema and ExportSchema reject usages of undeclared directives and
+// usages at a location the directive does not allow.
+//
+// Returns an error if the name is invalid, built in or already registered,
+// or if a location is unknown.
+func RegisterDirective(name string, locations []string, args []ArgumentDefinition) (err error) {
+	defer notifyIfRegistered(&err, "directive", name)
+	if !graphQLNamePattern.MatchString(name) {
+		return fmt.Errorf("directive name %q is not a valid GraphQL name", name)
+	}
+	if builtinDirectives[name] {
+		return fmt.Errorf("directive @%s is built in and cannot be redeclared", name)
+	}
+	if len(locations) == 0 {
+		return fmt.Errorf("directive @%s must declare at least one location", name)
+	}
+	for _, loc := range locations {
+		if !directiveLocations[loc] {
+			return fmt.Errorf("directive @%s: unknown location %q", name, loc)
+		}
+	}
+
+	reg := getInstance()
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	if _, exists := reg.directives[name]; exists {
+		return fmt.Errorf("directive %q is already registered; each name must be unique within a schema", name)
+	}
+	reg.directives[name] = DirectiveDefinition{
+		Name:      name,
+		Locations: locations,
+		Arguments: args,
+	}
+	return nil
+}
+
+// parseDirectiveList parses a `directive=` tag value: directive names
+// separated by semicolons, with or without a leading "@".
+func parseDirectiveList(value, fieldName string) ([]string, error) {
+	var directives []string
+	for _, d := range strings.Split(value, ";") {
+		d = strings.TrimPrefix(strings.TrimSpace(d), "@")
+		if !graphQLNamePattern.MatchString(d) {
+			return nil, fmt.Errorf("invalid directive %q for field %s", d, fieldName)
+		}
+		directives = append(directives, d)
+	}
+	return directives, nil
+}
+
+// structTypeDirectives returns the directives declared on a struct type's
+// blank `_` fields.
+func structTypeDirectives(structType reflect.Type) ([]string, error) {
+	var directives []string
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.Name != "_" {
+			continue
+		}
+		for _, part := range strings.Split(field.Tag.Get("fraiseql"), ",") {
+			key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+			if !found || strings.TrimSpace(key) != "directive" {
+				continue
+			}
+			parsed, err := parseDirectiveList(value, structType.Name())
+			if err != nil {
+				return nil, err
+			}
+			directives = append(directives, parsed...)
+		}
+	}
+	return directives, nil
+}
+
+// validateDirectiveUsages checks every directive used by types, fields and
+// input fields against the declared directives and their locations.
+func validateDirectiveUsages(schema Schema) []error {
+	declared := make(map[string]DirectiveDefinition, len(schema.Directives))
+	for _, d := range schema.Directives {
+		declared[d.Name] = d
+	}
+
+	var errs []error
+	check := func(where, directive, location string) {
+		def, ok := declared[directive]
+		if !ok {
+			errs = append(errs, fmt.Errorf(
+				"%s uses directive @%s, which is not declared; register it with RegisterDirective",
+				where, directive,
+			))
+			return
+		}
+		for _, loc := range def.Locations {
+			if loc == location {
+				return
+			}
+		}
+		errs = append(errs, fmt.Errorf(
+			"%s uses directive @%s at %s, but it is only valid at %s",
+			where, directive, location, strings.Join(def.Locations, ", "),
+		))
+	}
+
+	for _, t := range schema.Types {
+		for _, d := range t.Directives {
+			check(fmt.Sprintf("type %q", t.Name), d, "OBJECT")
+		}
+		for _, f := range t.Fields {
+			for _, d := range f.Directives {
+				check(fmt.Sprintf("type %q: field %q", t.Name, f.Name), d, "FIELD_DEFINITION")
+			}
+		}
+	}
+	for _, in := range schema.InputTypes {
+		for _, f := range in.Fields {
+			for _, d := range f.Directives {
+				check(fmt.Sprintf("input type %q: field %q", in.Name, f.Name), d, "INPUT_FIELD_DEFINITION")
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,166 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// auditedAccount uses custom directives at the OBJECT and FIELD_DEFINITION locations.
+type auditedAccount struct {
+	_     struct{} `fraiseql:"directive=cached"`
+	ID    ID       `fraiseql:"id"`
+	Email string   `fraiseql:"email,type=Email,directive=sensitive;@audited"`
+}
+
+func TestRegisterDirective(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	maxAge := []ArgumentDefinition{{Name: "maxAge", Type: "Int", Nullable: true}}
+	for _, d := range []struct {
+		name      string
+		locations []string
+		args      []ArgumentDefinition
+	}{
+		{"cached", []string{"OBJECT", "FIELD_DEFINITION"}, maxAge},
+		{"sensitive", []string{"FIELD_DEFINITION", "INPUT_FIELD_DEFINITION"}, nil},
+		{"audited", []string{"FIELD_DEFINITION"}, nil},
+	} {
+		if err := RegisterDirective(d.name, d.locations, d.args); err != nil {
+			t.Fatalf("RegisterDirective(%s): %v", d.name, err)
+		}
+	}
+	if err := RegisterTypes(auditedAccount{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+
+	if errs := ValidateSchema(); errs != nil {
+		t.Fatalf("expected declared directives to validate, got %v", errs)
+	}
+
+	schema := GetSchema()
+	account := schema.Types[0]
+	if len(account.Fields) != 2 {
+		t.Errorf("the blank directive field should not become a GraphQL field, got %+v", account.Fields)
+	}
+	if strings.Join(account.Directives, ",") != "cached" {
+		t.Errorf("type directives: want [cached], got %v", account.Directives)
+	}
+	if strings.Join(account.Fields[1].Directives, ",") != "sensitive,audited" {
+		t.Errorf("field directives: want [sensitive audited], got %v", account.Fields[1].Directives)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	var exported struct {
+		Directives []DirectiveDefinition `json:"directives"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(exported.Directives) != 3 || exported.Directives[1].Name != "cached" {
+		t.Fatalf("expected 3 sorted directives, got %+v", exported.Directives)
+	}
+	cached := exported.Directives[1]
+	if strings.Join(cached.Locations, ",") != "OBJECT,FIELD_DEFINITION" || len(cached.Arguments) != 1 {
+		t.Errorf("unexpected cached directive: %+v", cached)
+	}
+}
+
+func TestRegisterDirectiveValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		directive string
+		locations []string
+		wantErr   string
+	}{
+		{"invalid name", "no-dashes", []string{"FIELD"}, "not a valid GraphQL name"},
+		{"built-in directive", "deprecated", []string{"FIELD_DEFINITION"}, "built in"},
+		{"no locations", "cached", nil, "at least one location"},
+		{"unknown location", "cached", []string{"TABLE"}, `unknown location "TABLE"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := RegisterDirective(tt.directive, tt.locations, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("duplicate", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		if err := RegisterDirective("cached", []string{"OBJECT"}, nil); err != nil {
+			t.Fatalf("RegisterDirective: %v", err)
+		}
+		if err := RegisterDirective("cached", []string{"OBJECT"}, nil); err == nil ||
+			!strings.Contains(err.Error(), "already registered") {
+			t.Errorf("expected duplicate error, got %v", err)
+		}
+	})
+}
+
+func TestDirectiveMisuseRejected(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	// cached is valid on types only, and audited is never declared.
+	if err := RegisterDirective("cached", []string{"OBJECT"}, nil); err != nil {
+		t.Fatalf("RegisterDirective: %v", err)
+	}
+	if err := RegisterDirective("sensitive", []string{"FIELD_DEFINITION"}, nil); err != nil {
+		t.Fatalf("RegisterDirective: %v", err)
+	}
+	if err := RegisterType("Account", []FieldInfo{
+		{Name: "id", Type: "ID", Directives: []string{"cached"}},
+		{Name: "email", Type: "String", Directives: []string{"audited"}},
+	}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := RegisterInputType(InputTypeDefinition{
+		Name:   "AccountInput",
+		Fields: []FieldInfo{{Name: "email", Type: "String", Directives: []string{"sensitive"}}},
+	}); err != nil {
+		t.Fatalf("RegisterInputType: %v", err)
+	}
+
+	errs := ValidateSchema()
+	want := []string{
+		`type "Account": field "id" uses directive @cached at FIELD_DEFINITION, but it is only valid at OBJECT`,
+		`type "Account": field "email" uses directive @audited, which is not declared`,
+		`input type "AccountInput": field "email" uses directive @sensitive at INPUT_FIELD_DEFINITION`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("expected %d errors, got %v", len(want), errs)
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d: want %q, got %v", i, w, errs[i])
+		}
+	}
+
+	if err := ExportSchema(t.TempDir() + "/schema.json"); err == nil {
+		t.Error("ExportSchema should reject directive misuse")
+	}
+}
+
+func TestInvalidDirectiveTag(t *testing.T) {
+	type Bad struct {
+		Email string `fraiseql:"email,directive=sensitive;"`
+	}
+
+	Reset()
+	defer Reset()
+	if err := RegisterTypes(Bad{}); err == nil || !strings.Contains(err.Error(), `invalid directive ""`) {
+		t.Errorf("expected invalid directive error, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package fraiseql
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -264,6 +265,87 @@ func TestExportTypesFile(t *testing.T) {
 	_ = removeFile(tmpFile)
 }
 
+// TestExportSectionSingle verifies a single-section export holds only that key
+func TestExportSectionSingle(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}}, "")
+	if err := NewQuery("users").ReturnType("User").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	data, err := ExportSection("queries")
+	if err != nil {
+		t.Fatalf("ExportSection failed: %v", err)
+	}
+	var result map[string][]map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to unmarshal result: %v", err)
+	}
+	if len(result) != 1 {
+		t.Errorf("expected only the queries section, got keys %v", result)
+	}
+	if len(result["queries"]) != 1 || result["queries"][0]["name"] != "users" {
+		t.Errorf("unexpected queries section: %v", result["queries"])
+	}
+
+	// The types section alone matches ExportTypes.
+	sectionJSON, err := ExportSection("types")
+	if err != nil {
+		t.Fatalf("ExportSection failed: %v", err)
+	}
+	typesJSON, err := ExportTypes(true)
+	if err != nil {
+		t.Fatalf("ExportTypes failed: %v", err)
+	}
+	if string(sectionJSON) != string(typesJSON) {
+		t.Errorf("ExportSection(\"types\") should match ExportTypes(true):\n%s\nvs\n%s", sectionJSON, typesJSON)
+	}
+}
+
+// TestExportSectionMultiple verifies several sections are exported together
+func TestExportSectionMultiple(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}}, "")
+	if err := NewQuery("users").ReturnType("User").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewMutation("createUser").ReturnType("User").Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	data, err := ExportSection("types", "mutations", "enums")
+	if err != nil {
+		t.Fatalf("ExportSection failed: %v", err)
+	}
+	var result map[string]json.RawMessage
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to unmarshal result: %v", err)
+	}
+	for _, want := range []string{"types", "mutations", "enums"} {
+		if _, ok := result[want]; !ok {
+			t.Errorf("missing requested section %q", want)
+		}
+	}
+	if _, ok := result["queries"]; ok || len(result) != 3 {
+		t.Errorf("expected exactly the requested sections, got %d keys", len(result))
+	}
+}
+
+// TestExportSectionValidatesNames verifies unknown or missing section names are rejected
+func TestExportSectionValidatesNames(t *testing.T) {
+	if _, err := ExportSection("queries", "authz_policies"); err == nil ||
+		!strings.Contains(err.Error(), `unknown schema section "authz_policies"`) {
+		t.Errorf("expected unknown section error, got %v", err)
+	}
+	if _, err := ExportSection(); err == nil {
+		t.Error("expected error when no section is requested")
+	}
+}
+
 // Helper to remove test file
 func removeFile(path string) error {
 	// Use os.Remove, but we'll skip this since we're in test context
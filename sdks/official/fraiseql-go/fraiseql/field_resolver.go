@@ -0,0 +1,25 @@
+package fraiseql
+
+import "fmt"
+
+// validateResolver checks a field's resolver= tag: the name must be a
+// database function, optionally schema-qualified, and a computed field cannot
+// also be read from a JSONB column.
+func validateResolver(info FieldInfo, hasResolver bool) error {
+	if !hasResolver {
+		return nil
+	}
+	if !hookNamePattern.MatchString(info.Resolver) {
+		return fmt.Errorf(
+			"field %s: invalid resolver %q; resolvers must name a database function, e.g. fn_full_name or app.fn_full_name",
+			info.Name, info.Resolver,
+		)
+	}
+	if info.JsonbColumn != "" {
+		return fmt.Errorf(
+			"field %s: resolver %q cannot be combined with jsonb=; a field is either computed or selected",
+			info.Name, info.Resolver,
+		)
+	}
+	return nil
+}
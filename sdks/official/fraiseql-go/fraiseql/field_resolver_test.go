@@ -0,0 +1,72 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolverTagExport(t *testing.T) {
+	type Person struct {
+		FirstName string `fraiseql:"firstName"`
+		LastName  string `fraiseql:"lastName"`
+		FullName  string `fraiseql:"fullName,resolver=fn_full_name"`
+		Initials  string `fraiseql:"initials,resolver=people.fn_initials"`
+	}
+
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(Person{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	for _, want := range []string{
+		`{"name":"fullName","type":"String","nullable":false,"resolver":"fn_full_name","computed":true}`,
+		`{"name":"initials","type":"String","nullable":false,"resolver":"people.fn_initials","computed":true}`,
+		`{"name":"firstName","type":"String","nullable":false,"source_name":"firstName"}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in export, got %s", want, data)
+		}
+	}
+}
+
+func TestResolverTagValidation(t *testing.T) {
+	type Empty struct {
+		Name string `fraiseql:"name,resolver="`
+	}
+	type Invalid struct {
+		Name string `fraiseql:"name,resolver=fn full name"`
+	}
+	type WithJSONB struct {
+		Name string `fraiseql:"name,resolver=fn_name,jsonb=data"`
+	}
+	type ResolverInput struct {
+		Name string `fraiseql:"name,resolver=fn_name"`
+	}
+
+	tests := []struct {
+		name     string
+		register func() error
+		wantErr  string
+	}{
+		{"empty", func() error { return RegisterTypes(Empty{}) }, `invalid resolver ""`},
+		{"not an identifier", func() error { return RegisterTypes(Invalid{}) }, `invalid resolver "fn full name"`},
+		{"with jsonb", func() error { return RegisterTypes(WithJSONB{}) }, "cannot be combined with jsonb="},
+		{"input type", func() error { return RegisterInputTypes(ResolverInput{}) }, "only output type fields can be computed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := tt.register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package fraiseql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// validateFieldTypes checks the field types of a type being registered,
+// returning one error listing every problem: a type must be non-empty,
+// well-formed GraphQL type notation, and not a known scalar with the wrong
+// case, such as "string". Names that are not known yet are accepted, since
+// they may be registered later; ValidateSchema reports those that never are.
+func validateFieldTypes(owner string, fields []FieldInfo) error {
+	var problems []string
+	for _, f := range fields {
+		switch {
+		case strings.TrimSpace(f.Type) == "":
+			problems = append(problems, fmt.Sprintf("field %q has no type", f.Name))
+		case !isWellFormedType(f.Type):
+			problems = append(problems, fmt.Sprintf(
+				"field %q has malformed type %q; use a name such as String, [String!] or User!", f.Name, f.Type,
+			))
+		default:
+			if scalar := miscasedScalar(namedType(f.Type)); scalar != "" {
+				problems = append(problems, fmt.Sprintf(
+					"field %q has unknown type %q; did you mean %s?", f.Name, namedType(f.Type), scalar,
+				))
+			}
+		}
+	}
+	switch len(problems) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("type %q: %s", owner, problems[0])
+	}
+	return fmt.Errorf("type %q has invalid field types:\n  - %s", owner, strings.Join(problems, "\n  - "))
+}
+
+// miscasedScalar returns the built-in or standard scalar that name matches
+// except for case, e.g. "String" for "string", or "" if there is none.
+func miscasedScalar(name string) string {
+	if IsScalarType(name) {
+		return ""
+	}
+	if _, custom := GetAllCustomScalars()[name]; custom {
+		return ""
+	}
+	for scalar := range builtinScalars {
+		if strings.EqualFold(name, scalar) {
+			return scalar
+		}
+	}
+	for scalar := range ScalarNames {
+		if strings.EqualFold(name, scalar) {
+			return scalar
+		}
+	}
+	return ""
+}
+
+// validateFieldTypeReferences reports the object and input type fields whose
+// type names no registered type, input type, enum or scalar. This is the
+// deferred half of validateFieldTypes, run once every type is registered.
+func validateFieldTypeReferences(schema Schema, registeredNames map[string]struct{}) []error {
+	known := make(map[string]struct{}, len(registeredNames)+len(schema.InputTypes))
+	for name := range registeredNames {
+		known[name] = struct{}{}
+	}
+	for _, in := range schema.InputTypes {
+		known[in.Name] = struct{}{}
+	}
+	var errs []error
+	check := func(kind, owner string, fields []FieldInfo) {
+		for _, f := range fields {
+			name := namedType(f.Type)
+			if _, ok := known[name]; !ok && name != "" {
+				errs = append(errs, fmt.Errorf(
+					"%s %q: field %q has type %q which is not a registered type, enum or scalar",
+					kind, owner, f.Name, name,
+				))
+			}
+		}
+	}
+	for _, t := range schema.Types {
+		check("type", t.Name, t.Fields)
+	}
+	for _, in := range schema.InputTypes {
+		check("input type", in.Name, in.Fields)
+	}
+	return errs
+}
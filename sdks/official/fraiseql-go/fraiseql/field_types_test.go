@@ -0,0 +1,88 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegisterTypeFieldTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []FieldInfo
+		wantErr string
+	}{
+		{"empty type", []FieldInfo{{Name: "id", Type: "ID"}, {Name: "email", Type: ""}}, `type "User": field "email" has no type`},
+		{"malformed type", []FieldInfo{{Name: "tags", Type: "[String"}}, `field "tags" has malformed type "[String"`},
+		{"miscased scalar", []FieldInfo{{Name: "name", Type: "string"}}, `field "name" has unknown type "string"; did you mean String?`},
+		{"miscased list element", []FieldInfo{{Name: "ids", Type: "[uuid!]"}}, `field "ids" has unknown type "uuid"; did you mean UUID?`},
+		{
+			"several problems",
+			[]FieldInfo{{Name: "a", Type: ""}, {Name: "b", Type: "int"}},
+			"type \"User\" has invalid field types:\n  - field \"a\" has no type\n  - field \"b\" has unknown type \"int\"; did you mean Int?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := RegisterType("User", tt.fields, "")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+			if CountTypes() != 0 {
+				t.Errorf("a type with invalid fields was registered")
+			}
+		})
+	}
+
+	t.Run("input type", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := RegisterInputType(InputTypeDefinition{Name: "UserInput", Fields: []FieldInfo{{Name: "name"}}})
+		if err == nil || !strings.Contains(err.Error(), `type "UserInput": field "name" has no type`) {
+			t.Errorf("error = %v, want the empty field type", err)
+		}
+	})
+}
+
+func TestValidateSchemaUnknownFieldTypes(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	// Forward references are accepted at registration time.
+	if err := RegisterType("Post", []FieldInfo{
+		{Name: "id", Type: "ID"},
+		{Name: "author", Type: "User"},
+		{Name: "body", Type: "Strnig"},
+	}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := RegisterInputType(InputTypeDefinition{Name: "PostInput", Fields: []FieldInfo{
+		{Name: "status", Type: "PostStatus"},
+	}}); err != nil {
+		t.Fatalf("RegisterInputType: %v", err)
+	}
+	if err := RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}, {Name: "posts", Type: "[Post!]"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+
+	errs := ValidateSchema()
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	want := []string{
+		`type "Post": field "body" has type "Strnig" which is not a registered type, enum or scalar`,
+		`input type "PostInput": field "status" has type "PostStatus" which is not a registered type, enum or scalar`,
+	}
+	if strings.Join(msgs, "\n") != strings.Join(want, "\n") {
+		t.Errorf("ValidateSchema() =\n%s\nwant\n%s", strings.Join(msgs, "\n"), strings.Join(want, "\n"))
+	}
+
+	Enum("PostStatus", map[string]string{"DRAFT": "draft"})
+	if errs := ValidateSchema(); len(errs) != 1 {
+		t.Errorf("expected only the Strnig error once PostStatus is registered, got %v", errs)
+	}
+}
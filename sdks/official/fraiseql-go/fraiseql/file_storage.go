@@ -0,0 +1,61 @@
+package fraiseql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FileStorage locates the stored object a File or Image field refers to, so
+// the compiler and runtime can resolve the stored reference into a URL.
+type FileStorage struct {
+	// Backend is one of "s3", "gcs", "azure" or "local".
+	Backend string `json:"backend"`
+	// Bucket is the bucket (or Azure container) holding the objects; the
+	// local backend has none.
+	Bucket string `json:"bucket,omitempty"`
+}
+
+// storageBackends lists the backends of the `storage=` tag, and whether each
+// needs a `bucket=`.
+var storageBackends = map[string]bool{
+	"s3":    true,
+	"gcs":   true,
+	"azure": true,
+	"local": false,
+}
+
+// validateFileStorage checks a field's storage= and bucket= tags: they apply
+// to File and Image fields, the backend must be known, and a bucket is given
+// exactly when the backend needs one.
+func validateFileStorage(info FieldInfo) error {
+	s := info.Storage
+	if s == nil {
+		return nil
+	}
+	if s.Backend == "" {
+		return fmt.Errorf("field %s: bucket=%q requires a storage= backend", info.Name, s.Bucket)
+	}
+	if t := namedType(info.Type); t != "File" && t != "Image" {
+		return fmt.Errorf("field %s: storage= is not supported for type %s; only File and Image fields are stored objects", info.Name, info.Type)
+	}
+	needsBucket, known := storageBackends[s.Backend]
+	if !known {
+		backends := make([]string, 0, len(storageBackends))
+		for name := range storageBackends {
+			backends = append(backends, name)
+		}
+		sort.Strings(backends)
+		return fmt.Errorf(
+			"field %s: unknown storage backend %q; must be one of %s",
+			info.Name, s.Backend, strings.Join(backends, ", "),
+		)
+	}
+	switch {
+	case needsBucket && s.Bucket == "":
+		return fmt.Errorf("field %s: storage=%s requires a bucket=", info.Name, s.Backend)
+	case !needsBucket && s.Bucket != "":
+		return fmt.Errorf("field %s: storage=%s does not use a bucket, got bucket=%q", info.Name, s.Backend, s.Bucket)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package fraiseql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStorageTag(t *testing.T) {
+	type Product struct {
+		Photo     string `fraiseql:"photo,type=Image,storage=s3,bucket=uploads"`
+		Manual    string `fraiseql:"manual,type=File,nullable=true,storage=local"`
+		Thumbnail string `fraiseql:"thumbnail,type=Image"`
+	}
+
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(Product{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	fields := getInstance().types["Product"].Fields
+	if want := (&FileStorage{Backend: "s3", Bucket: "uploads"}); !reflect.DeepEqual(fields[0].Storage, want) {
+		t.Errorf("photo Storage = %+v, want %+v", fields[0].Storage, want)
+	}
+	if want := (&FileStorage{Backend: "local"}); !reflect.DeepEqual(fields[1].Storage, want) {
+		t.Errorf("manual Storage = %+v, want %+v", fields[1].Storage, want)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	for _, want := range []string{`"storage":{"backend":"s3","bucket":"uploads"}`, `"storage":{"backend":"local"}`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in export, got %s", want, data)
+		}
+	}
+	if strings.Count(string(data), `"storage"`) != 2 {
+		t.Errorf("fields without storage should omit it, got %s", data)
+	}
+}
+
+func TestStorageTagValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		wantErr string
+	}{
+		{"unknown backend", "avatar,type=Image,storage=dropbox,bucket=x", `unknown storage backend "dropbox"; must be one of azure, gcs, local, s3`},
+		{"missing bucket", "avatar,type=Image,storage=gcs", "storage=gcs requires a bucket="},
+		{"bucket on local", "avatar,type=Image,storage=local,bucket=uploads", `storage=local does not use a bucket, got bucket="uploads"`},
+		{"bucket without backend", "avatar,type=Image,bucket=uploads", `bucket="uploads" requires a storage= backend`},
+		{"not a file", "avatar,type=URL,storage=s3,bucket=uploads", "storage= is not supported for type URL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseFieldTag(tt.tag, "avatar", reflect.TypeOf(""))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package fraiseql
+
+import "fmt"
+
+// filterArgName is the argument FilterInput replaces the optional arguments with.
+const filterArgName = "filter"
+
+// FilterInput collects the query's nullable arguments into a generated input
+// type with the given name and replaces them with a single nullable `filter`
+// argument of that type, the common `where`-object pattern. Required
+// arguments stay top-level, as do arguments hidden with HideArg, which would
+// otherwise become public fields of the input type. The generated fields keep
+// their types, defaults and descriptions, and the "filter_input" config key
+// names the input type so the compiler unpacks it like the individual
+// arguments.
+//
+// Register returns an error if the query has no nullable arguments, if an
+// input type with the name is already registered, if a required argument is
+// itself named "filter" or an alias is, or if an argument moved into the
+// input type has an Alias or is deprecated with DeprecateArg, since input
+// fields carry no deprecation.
+//
+// Example:
+//
+//	fraiseql.NewQuery("posts").
+//		ReturnType(Post{}).
+//		ReturnsArray(true).
+//		Arg("authorId", "ID", nil, true).
+//		Arg("published", "Boolean", nil, true).
+//		FilterInput("PostFilter").
+//		Register()
+func (qb *QueryBuilder) FilterInput(name string) *QueryBuilder {
+	qb.filterInput = name
+	return qb
+}
+
+// buildFilterInput splits the query's arguments into the generated filter
+// input type and the arguments that remain.
+func (qb *QueryBuilder) buildFilterInput() (InputTypeDefinition, []ArgumentDefinition, error) {
+	input := InputTypeDefinition{
+		Name:        qb.filterInput,
+		Description: fmt.Sprintf("Filter for the %s query", qb.name),
+	}
+	var remaining []ArgumentDefinition
+	for _, arg := range qb.arguments {
+		if !arg.Nullable {
+			if arg.Name == filterArgName {
+				return InputTypeDefinition{}, nil, fmt.Errorf(
+					"query %q: argument %q collides with the argument FilterInput generates", qb.name, arg.Name,
+				)
+			}
+			remaining = append(remaining, arg)
+			continue
+		}
+		if arg.Hidden {
+			remaining = append(remaining, arg)
+			continue
+		}
+		if arg.Deprecated != nil {
+			return InputTypeDefinition{}, nil, fmt.Errorf(
+				"query %q: argument %q is deprecated, but FilterInput(%q) would move it into the filter input, whose fields cannot be deprecated; keep it required or drop the deprecation",
+				qb.name, arg.Name, qb.filterInput,
+			)
+		}
+		field := FieldInfo{Name: arg.Name, Type: arg.Type, Nullable: true, Description: arg.Description}
+		if arg.IsDefault {
+			field.Default = arg.Default
+		}
+		input.Fields = append(input.Fields, field)
+	}
+	if len(input.Fields) == 0 {
+		return InputTypeDefinition{}, nil, fmt.Errorf(
+			"query %q: FilterInput(%q) requires at least one nullable argument to collect", qb.name, qb.filterInput,
+		)
+	}
+
+	remaining = append(remaining, ArgumentDefinition{Name: filterArgName, Type: qb.filterInput, Nullable: true})
+	return input, remaining, nil
+}
+
+// registerWithFilterInput registers the query together with its generated
+// filter input type: both or neither, under one registry lock, after the
+// input type has been validated. The OnRegister callbacks run for the input
+// type, then the query.
+func registerWithFilterInput(definition QueryDefinition, input InputTypeDefinition) (err error) {
+	input, err = prepareInputType(input)
+	if err != nil {
+		return fmt.Errorf("query %q: FilterInput(%q): %w", definition.Name, input.Name, err)
+	}
+	defer func() {
+		if err == nil {
+			notifyRegistered("input type", input.Name)
+			notifyRegistered("query", definition.Name)
+		}
+	}()
+
+	reg := getInstance()
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	if _, exists := reg.inputTypes[input.Name]; exists {
+		return fmt.Errorf(
+			"query %q: FilterInput(%q) collides with a registered input type of the same name", definition.Name, input.Name,
+		)
+	}
+	if _, exists := reg.queries[definition.Name]; exists {
+		return fmt.Errorf("query %q is already registered; each name must be unique within a schema", definition.Name)
+	}
+	reg.inputTypes[input.Name] = input
+	reg.queries[definition.Name] = definition
+	return nil
+}
@@ -0,0 +1,205 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilterInput(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterType("Post", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := NewQuery("posts").
+		ReturnType("Post").
+		ReturnsArray(true).
+		Arg("authorId", "ID", nil, true).
+		Arg("published", "Boolean", true, true).
+		Arg("tags", "[String!]", nil, true).
+		Arg("limit", "Int", 20).
+		DescribeArg("authorId", "Author to filter by").
+		FilterInput("PostFilter").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	schema := GetSchema()
+	q := schema.Queries[0]
+	if len(q.Arguments) != 2 || q.Arguments[0].Name != "limit" || q.Arguments[1].Name != "filter" {
+		t.Fatalf("expected limit and filter arguments, got %+v", q.Arguments)
+	}
+	if filter := q.Arguments[1]; filter.Type != "PostFilter" || !filter.Nullable {
+		t.Errorf("expected a nullable PostFilter argument, got %+v", filter)
+	}
+	cfg, _ := q.Config["filter_input"].(map[string]interface{})
+	if cfg["arg"] != "filter" || cfg["input_type"] != "PostFilter" {
+		t.Errorf("unexpected filter_input config: %v", q.Config["filter_input"])
+	}
+
+	if len(schema.InputTypes) != 1 || schema.InputTypes[0].Name != "PostFilter" {
+		t.Fatalf("expected the PostFilter input type, got %+v", schema.InputTypes)
+	}
+	fields := schema.InputTypes[0].Fields
+	if len(fields) != 3 || fields[0].Name != "authorId" || fields[2].Type != "[String!]" {
+		t.Fatalf("unexpected filter fields: %+v", fields)
+	}
+	for _, f := range fields {
+		if !f.Nullable {
+			t.Errorf("filter field %q should be nullable", f.Name)
+		}
+	}
+	if fields[1].Default != true {
+		t.Errorf("expected the published default to be kept, got %v", fields[1].Default)
+	}
+	if fields[0].Description != "Author to filter by" {
+		t.Errorf("expected the authorId description to be kept, got %q", fields[0].Description)
+	}
+	if errs := ValidateSchema(); errs != nil {
+		t.Errorf("expected a valid schema, got %v", errs)
+	}
+}
+
+func TestFilterInputKeepsHiddenArgsTopLevel(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterType("Post", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := NewQuery("posts").
+		ReturnType("Post").
+		ReturnsArray(true).
+		Arg("authorId", "ID", nil, true).
+		Arg("secret", "String", nil, true).
+		HideArg("secret").
+		FilterInput("PostFilter").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	schema := GetSchema()
+	args := schema.Queries[0].Arguments
+	if len(args) != 2 || args[0].Name != "secret" || !args[0].Hidden || args[1].Name != "filter" {
+		t.Fatalf("expected the hidden secret argument to stay top-level, got %+v", args)
+	}
+	for _, f := range schema.InputTypes[0].Fields {
+		if f.Name == "secret" {
+			t.Fatal("hidden argument should not become a PostFilter field")
+		}
+	}
+
+	data, err := ExportIntrospectionJSON()
+	if err != nil {
+		t.Fatalf("ExportIntrospectionJSON: %v", err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("hidden argument should not appear in introspection:\n%s", data)
+	}
+}
+
+func TestFilterInputRegistersAtomically(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	var registered []string
+	OnRegister(func(kind, name string) { registered = append(registered, kind+" "+name) })
+
+	if err := NewQuery("posts").ReturnType("Post").Arg("id", "ID", nil).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	err := NewQuery("posts").ReturnType("Post").Arg("authorId", "ID", nil, true).FilterInput("PostFilter").Register()
+	if err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Fatalf("expected a duplicate query error, got %v", err)
+	}
+	if len(GetSchema().InputTypes) != 0 {
+		t.Error("the filter input type should not be registered when the query is not")
+	}
+
+	if err := NewQuery("drafts").ReturnType("Post").Arg("authorId", "ID", nil, true).FilterInput("DraftFilter").Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	want := []string{"query posts", "input type DraftFilter", "query drafts"}
+	if strings.Join(registered, ", ") != strings.Join(want, ", ") {
+		t.Errorf("OnRegister calls = %v, want %v", registered, want)
+	}
+}
+
+func TestFilterInputValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func() *QueryBuilder
+		wantErr string
+	}{
+		{
+			name: "input type name taken",
+			build: func() *QueryBuilder {
+				_ = NewInputType("PostFilter").Field("title", "String", true).Register()
+				return NewQuery("posts").ReturnType("Post").Arg("authorId", "ID", nil, true).FilterInput("PostFilter")
+			},
+			wantErr: "collides with a registered input type",
+		},
+		{
+			name: "required argument named filter",
+			build: func() *QueryBuilder {
+				return NewQuery("posts").ReturnType("Post").
+					Arg("filter", "String", nil).
+					Arg("authorId", "ID", nil, true).
+					FilterInput("PostFilter")
+			},
+			wantErr: `argument "filter" collides`,
+		},
+		{
+			name: "no nullable arguments",
+			build: func() *QueryBuilder {
+				return NewQuery("posts").ReturnType("Post").Arg("id", "ID", nil).FilterInput("PostFilter")
+			},
+			wantErr: "requires at least one nullable argument",
+		},
+		{
+			name: "alias of a moved argument",
+			build: func() *QueryBuilder {
+				return NewQuery("posts").ReturnType("Post").
+					Arg("authorId", "ID", nil, true).Alias("author_id").
+					FilterInput("PostFilter")
+			},
+			wantErr: `alias "author_id" refers to argument "authorId", which FilterInput("PostFilter") moves into the filter input`,
+		},
+		{
+			name: "alias named filter",
+			build: func() *QueryBuilder {
+				return NewQuery("posts").ReturnType("Post").
+					Arg("q", "String", nil).Alias("filter").
+					Arg("authorId", "ID", nil, true).
+					FilterInput("PostFilter")
+			},
+			wantErr: `alias "filter" of argument "q" collides with an existing argument`,
+		},
+		{
+			name: "deprecated moved argument",
+			build: func() *QueryBuilder {
+				return NewQuery("posts").ReturnType("Post").
+					Arg("authorId", "ID", nil, true).
+					DeprecateArg("authorId", "use author").
+					FilterInput("PostFilter")
+			},
+			wantErr: `argument "authorId" is deprecated, but FilterInput("PostFilter") would move it into the filter input`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := tt.build().Register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if len(GetSchema().Queries) != 0 {
+				t.Error("the query should not be registered")
+			}
+		})
+	}
+}
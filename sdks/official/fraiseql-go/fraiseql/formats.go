@@ -0,0 +1,48 @@
+package fraiseql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// scalarFormats lists the wire formats a field of each scalar may declare
+// with the `format=` tag. The first is the scalar's default.
+var scalarFormats = map[string][]string{
+	"DateTime": {"iso8601", "epoch_seconds", "epoch_millis"},
+	"Date":     {"iso8601", "epoch_days"},
+	"Time":     {"iso8601", "seconds_of_day"},
+	"Duration": {"iso8601", "seconds", "millis"},
+	// "hex" asks the runtime to store colors as NormalizeColor does.
+	"Color": {"css", "hex"},
+}
+
+// validateFieldFormat checks that a field's format is one its scalar supports.
+// owner names the type for errors.
+func validateFieldFormat(owner string, f FieldInfo) error {
+	if f.Format == "" {
+		return nil
+	}
+	scalar := namedType(f.Type)
+	formats, ok := scalarFormats[scalar]
+	if !ok {
+		supported := make([]string, 0, len(scalarFormats))
+		for name := range scalarFormats {
+			supported = append(supported, name)
+		}
+		sort.Strings(supported)
+		return fmt.Errorf(
+			"type %q: field %q has format %q, but type %s has no alternative formats; formats apply to %s",
+			owner, f.Name, f.Format, f.Type, strings.Join(supported, ", "),
+		)
+	}
+	for _, format := range formats {
+		if f.Format == format {
+			return nil
+		}
+	}
+	return fmt.Errorf(
+		"type %q: field %q has unknown %s format %q; must be one of %s",
+		owner, f.Name, scalar, f.Format, strings.Join(formats, ", "),
+	)
+}
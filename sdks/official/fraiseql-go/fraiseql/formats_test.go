@@ -0,0 +1,61 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFieldFormatTag(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Event struct {
+		ID        ID     `fraiseql:"id"`
+		CreatedAt string `fraiseql:"createdAt,type=DateTime,format=epoch_millis"`
+		UpdatedAt string `fraiseql:"updatedAt,type=DateTime"`
+	}
+	if err := RegisterTypes(Event{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+
+	fields := GetSchema().Types[0].Fields
+	if fields[1].Format != "epoch_millis" || fields[2].Format != "" {
+		t.Errorf("unexpected formats: %q, %q", fields[1].Format, fields[2].Format)
+	}
+	data, err := json.Marshal(fields[1])
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"format":"epoch_millis"`) {
+		t.Errorf("expected format in %s", data)
+	}
+}
+
+func TestFieldFormatValidation(t *testing.T) {
+	t.Run("unknown format", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		type Event struct {
+			CreatedAt string `fraiseql:"createdAt,type=DateTime,format=rfc822"`
+		}
+		err := RegisterTypes(Event{})
+		if err == nil || !strings.Contains(err.Error(), `unknown DateTime format "rfc822"; must be one of iso8601, epoch_seconds, epoch_millis`) {
+			t.Errorf("expected unknown format error, got %v", err)
+		}
+	})
+
+	t.Run("format on a scalar without formats", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := RegisterInputType(InputTypeDefinition{
+			Name:   "OtherInput",
+			Fields: []FieldInfo{{Name: "name", Type: "String", Format: "epoch_millis"}},
+		})
+		if err == nil || !strings.Contains(err.Error(), "type String has no alternative formats") {
+			t.Errorf("expected unsupported format error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+// Package fraiseqltest provides test helpers for schemas authored with the
+// fraiseql package.
+//
+// It lives in its own package so that importing fraiseql does not pull the
+// testing package into production binaries.
+//
+// Schemas built in init() can be validated with a one-line test:
+//
+//	func TestSchema(t *testing.T) {
+//	    fraiseqltest.AssertSchemaValid(t)
+//	}
+package fraiseqltest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fraiseql/fraiseql-go/fraiseql"
+)
+
+// AssertSchemaValid runs fraiseql.ValidateSchema and fraiseql.ValidateConventions
+// against the global registry and fails t with every collected error. A failing
+// schema transform stops the test with t.Fatal.
+func AssertSchemaValid(t testing.TB) {
+	t.Helper()
+
+	if _, err := fraiseql.GetSchemaJSON(false); err != nil {
+		t.Fatalf("schema could not be assembled: %v", err)
+	}
+
+	errs := append(fraiseql.ValidateSchema(), fraiseql.ValidateConventions()...)
+	if len(errs) == 0 {
+		return
+	}
+
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	t.Errorf("schema is not valid (%d errors):\n  - %s", len(errs), strings.Join(msgs, "\n  - "))
+}
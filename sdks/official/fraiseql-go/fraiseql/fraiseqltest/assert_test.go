@@ -0,0 +1,97 @@
+package fraiseqltest
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/fraiseql/fraiseql-go/fraiseql"
+)
+
+// recordingTB captures failures instead of failing the enclosing test.
+type recordingTB struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// Fatalf records the failure and stops the calling goroutine, like testing.T.
+func (r *recordingTB) Fatalf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+	runtime.Goexit()
+}
+
+func TestAssertSchemaValidPasses(t *testing.T) {
+	fraiseql.Reset()
+	defer fraiseql.Reset()
+
+	if err := fraiseql.RegisterType("User", []fraiseql.FieldInfo{
+		{Name: "id", Type: "ID", Nullable: false},
+		{Name: "name", Type: "String", Nullable: false},
+	}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := fraiseql.NewQuery("users").ReturnType("User").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	rec := &recordingTB{TB: t}
+	AssertSchemaValid(rec)
+	if len(rec.failures) != 0 {
+		t.Errorf("expected no failures, got %v", rec.failures)
+	}
+}
+
+func TestAssertSchemaValidReportsAllErrors(t *testing.T) {
+	fraiseql.Reset()
+	defer fraiseql.Reset()
+
+	if err := fraiseql.RegisterType("Post", []fraiseql.FieldInfo{
+		{Name: "id", Type: "Int", Nullable: false},
+		{Name: "authorId", Type: "String", Nullable: false},
+	}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := fraiseql.NewQuery("users").ReturnType("User").Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	rec := &recordingTB{TB: t}
+	AssertSchemaValid(rec)
+	if len(rec.failures) != 1 {
+		t.Fatalf("expected a single failure report, got %d", len(rec.failures))
+	}
+	msg := rec.failures[0]
+	for _, want := range []string{`query "users"`, `field "id"`, `field "authorId"`} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("failure should mention %s, got: %s", want, msg)
+		}
+	}
+}
+
+func TestAssertSchemaValidFailsOnTransformError(t *testing.T) {
+	fraiseql.Reset()
+	defer fraiseql.Reset()
+
+	fraiseql.RegisterSchemaTransform(func(*fraiseql.Schema) error {
+		return errors.New("boom")
+	})
+
+	rec := &recordingTB{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		AssertSchemaValid(rec)
+	}()
+	<-done
+	if len(rec.failures) != 1 || !strings.Contains(rec.failures[0], "boom") {
+		t.Errorf("expected one fatal failure with the transform error, got %v", rec.failures)
+	}
+}
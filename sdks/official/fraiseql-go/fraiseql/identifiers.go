@@ -0,0 +1,88 @@
+package fraiseql
+
+import "strings"
+
+// ValidateIBAN reports whether s is a well-formed IBAN: a two-letter country
+// code, two check digits and up to 30 alphanumeric characters, whose ISO 7064
+// mod-97 remainder is 1. Spaces, as in the grouped print form
+// "GB82 WEST 1234 5698 7654 32", are ignored. Country-specific lengths are
+// not checked.
+func ValidateIBAN(s string) bool {
+	s = strings.ReplaceAll(s, " ", "")
+	if len(s) < 15 || len(s) > 34 || !isUpperLetter(s[0]) || !isUpperLetter(s[1]) ||
+		!isDigit(rune(s[2])) || !isDigit(rune(s[3])) {
+		return false
+	}
+	return mod97(s[4:]+s[:4]) == 1
+}
+
+// ValidateLEI reports whether s is a well-formed Legal Entity Identifier:
+// eighteen alphanumeric characters followed by two check digits, validated
+// with ISO 7064 mod-97-10.
+func ValidateLEI(s string) bool {
+	if len(s) != 20 || !isDigit(rune(s[18])) || !isDigit(rune(s[19])) {
+		return false
+	}
+	return mod97(s) == 1
+}
+
+// mod97 returns the remainder modulo 97 of s read as a number, with letters
+// expanded to two digits (A=10 ... Z=35), or -1 if s has other characters.
+func mod97(s string) int {
+	remainder := 0
+	for i := 0; i < len(s); i++ {
+		v, ok := alnumValue(s[i])
+		if !ok {
+			return -1
+		}
+		if v >= 10 {
+			remainder = (remainder*100 + v) % 97
+		} else {
+			remainder = (remainder*10 + v) % 97
+		}
+	}
+	return remainder
+}
+
+// vinWeights are the ISO 3779 position weights of a VIN; the check digit at
+// position 9 has weight 0.
+var vinWeights = [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// ValidateVIN reports whether s is a well-formed 17-character Vehicle
+// Identification Number whose ninth character is the check digit ('0'-'9' or
+// 'X') computed from the transliterated characters. The letters I, O and Q
+// never appear in a VIN. VINs outside North America need not carry a valid
+// check digit, so this is stricter than the format alone.
+func ValidateVIN(s string) bool {
+	if len(s) != 17 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		v, ok := vinValue(s[i])
+		if !ok {
+			return false
+		}
+		sum += v * vinWeights[i]
+	}
+	check := byte('X')
+	if r := sum % 11; r < 10 {
+		check = byte('0' + r)
+	}
+	return s[8] == check
+}
+
+// vinLetterValues holds the transliterated value of each letter A-Z; '.'
+// marks I, O and Q, which never appear in a VIN.
+const vinLetterValues = "12345678.12345.7.923456789"
+
+// vinValue transliterates a VIN character to its numeric value.
+func vinValue(c byte) (int, bool) {
+	switch {
+	case isDigit(rune(c)):
+		return int(c - '0'), true
+	case isUpperLetter(c) && vinLetterValues[c-'A'] != '.':
+		return int(vinLetterValues[c-'A'] - '0'), true
+	}
+	return 0, false
+}
@@ -0,0 +1,45 @@
+package fraiseql
+
+import "testing"
+
+func TestValidateIdentifierChecksums(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(string) bool
+		value    string
+		want     bool
+	}{
+		{"IBAN GB", ValidateIBAN, "GB82WEST12345698765432", true},
+		{"IBAN DE", ValidateIBAN, "DE89370400440532013000", true},
+		{"IBAN FR with letter", ValidateIBAN, "FR1420041010050500013M02606", true},
+		{"IBAN NL", ValidateIBAN, "NL91ABNA0417164300", true},
+		{"IBAN grouped print form", ValidateIBAN, "GB82 WEST 1234 5698 7654 32", true},
+		{"IBAN bad check digits", ValidateIBAN, "GB81WEST12345698765432", false},
+		{"IBAN transposed digits", ValidateIBAN, "GB82WEST12345698765423", false},
+		{"IBAN lowercase", ValidateIBAN, "gb82west12345698765432", false},
+		{"IBAN too short", ValidateIBAN, "GB82WEST1234", false},
+		{"IBAN invalid character", ValidateIBAN, "GB82WEST1234569876543-", false},
+
+		{"LEI Apple", ValidateLEI, "HWUPKR0MPOU8FGXBT394", true},
+		{"LEI Deutsche Bank", ValidateLEI, "7LTWFZYICNSX8D621K86", true},
+		{"LEI bad check digits", ValidateLEI, "HWUPKR0MPOU8FGXBT395", false},
+		{"LEI letter in check digits", ValidateLEI, "HWUPKR0MPOU8FGXBT3A4", false},
+		{"LEI too long", ValidateLEI, "HWUPKR0MPOU8FGXBT3940", false},
+
+		{"VIN check digit X", ValidateVIN, "1M8GDM9AXKP042788", true},
+		{"VIN Honda", ValidateVIN, "1HGCM82633A004352", true},
+		{"VIN all ones", ValidateVIN, "11111111111111111", true},
+		{"VIN bad check digit", ValidateVIN, "1HGCM82643A004352", false},
+		{"VIN contains O", ValidateVIN, "1HGCM82633A0O4352", false},
+		{"VIN lowercase", ValidateVIN, "1hgcm82633a004352", false},
+		{"VIN too short", ValidateVIN, "1HGCM82633A00435", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.validate(tt.value); got != tt.want {
+				t.Errorf("validate(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
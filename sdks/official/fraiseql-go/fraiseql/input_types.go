@@ -0,0 +1,303 @@
+package fraiseql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// InputTypeDefinition represents a GraphQL input object type.
+//
+// When OneOf is set the input is a `@oneOf` input object (GraphQL 2023):
+// callers must provide exactly one of its fields, so every field must be nullable.
+type InputTypeDefinition struct {
+	Name        string      `json:"name"`
+	Fields      []FieldInfo `json:"fields"`
+	Description string      `json:"description,omitempty"`
+	OneOf       bool        `json:"one_of,omitempty"`
+}
+
+// RequiredIfRule is a conditional requirement on an input field: the field
+// must be provided when Field has the value Value.
+type RequiredIfRule struct {
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+// parseRequiredIf parses a `requiredIf=field:value` tag value.
+func parseRequiredIf(value, fieldName string) (*RequiredIfRule, error) {
+	field, want, found := strings.Cut(value, ":")
+	field, want = strings.TrimSpace(field), strings.TrimSpace(want)
+	if !found || field == "" || want == "" {
+		return nil, fmt.Errorf("field %s: requiredIf must have the form field:value, got %q", fieldName, value)
+	}
+	return &RequiredIfRule{Field: field, Value: want}, nil
+}
+
+// validateRequiredIf checks that each conditional requirement of an input
+// type refers to another field of the same input, and is on a nullable field
+// (a non-null field is always required).
+func validateRequiredIf(definition InputTypeDefinition) error {
+	names := make(map[string]bool, len(definition.Fields))
+	for _, f := range definition.Fields {
+		names[f.Name] = true
+	}
+	for _, f := range definition.Fields {
+		if f.RequiredIf == nil {
+			continue
+		}
+		switch {
+		case f.RequiredIf.Field == f.Name:
+			return fmt.Errorf("input type %q: field %q cannot be requiredIf on itself", definition.Name, f.Name)
+		case !names[f.RequiredIf.Field]:
+			return fmt.Errorf(
+				"input type %q: field %q is requiredIf on %q, which is not a field of the input type",
+				definition.Name, f.Name, f.RequiredIf.Field,
+			)
+		case !f.Nullable:
+			return fmt.Errorf(
+				"input type %q: field %q has requiredIf but is non-nullable; conditionally required fields must be nullable",
+				definition.Name, f.Name,
+			)
+		}
+	}
+	return nil
+}
+
+// RegisterInputType registers an input object type with the schema registry.
+// Returns an error if an input type with the same name is already registered,
+// if a field default does not match its scalar type, if a requiredIf rule
+// refers to an unknown field, or if a oneOf input declares a non-nullable
+// field or a field default. Enum defaults are checked by ValidateSchema, since
+// the enum may be registered later.
+func RegisterInputType(definition InputTypeDefinition) (err error) {
+	defer notifyIfRegistered(&err, "input type", definition.Name)
+	if definition, err = prepareInputType(definition); err != nil {
+		return err
+	}
+
+	reg := getInstance()
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	if _, exists := reg.inputTypes[definition.Name]; exists {
+		return fmt.Errorf("input type %q is already registered; each name must be unique within a schema", definition.Name)
+	}
+	reg.inputTypes[definition.Name] = definition
+	return nil
+}
+
+// prepareInputType validates an input type definition for RegisterInputType
+// and returns it as it is registered, with descriptions, scalar constraints,
+// source names and normalized defaults filled in.
+func prepareInputType(definition InputTypeDefinition) (InputTypeDefinition, error) {
+	if err := validateFieldTypes(definition.Name, definition.Fields); err != nil {
+		return definition, err
+	}
+	definition = withInputDescriptions(definition)
+	fields, err := withScalarConstraints(definition.Name, definition.Fields)
+	if err != nil {
+		return definition, err
+	}
+	if fields, err = withSourceNames(definition.Name, fields); err != nil {
+		return definition, err
+	}
+	definition.Fields = fields
+	for i, f := range definition.Fields {
+		if err := validateFieldFormat(definition.Name, f); err != nil {
+			return definition, err
+		}
+		if f, err = normalizePhoneDefault(f); err != nil {
+			return definition, fmt.Errorf("input type %q: %w", definition.Name, err)
+		}
+		definition.Fields[i] = f
+		if f.Internal {
+			return definition, fmt.Errorf(
+				"input type %q: field %q is internal, but only output type fields can be hidden from the public schema",
+				definition.Name, f.Name,
+			)
+		}
+		if f.Resolver != "" {
+			return definition, fmt.Errorf(
+				"input type %q: field %q has a resolver, but only output type fields can be computed",
+				definition.Name, f.Name,
+			)
+		}
+		if f.Computed {
+			return definition, fmt.Errorf(
+				"input type %q: field %q is computed, but input type fields are always written",
+				definition.Name, f.Name,
+			)
+		}
+		if f.Complexity != nil {
+			return definition, fmt.Errorf(
+				"input type %q: field %q has a complexity, but only output type fields are selected at a cost",
+				definition.Name, f.Name,
+			)
+		}
+		if f.SlugFrom != "" {
+			return definition, fmt.Errorf(
+				"input type %q: field %q has slugFrom, but slugs are only derived for output type fields",
+				definition.Name, f.Name,
+			)
+		}
+		if f.Default != nil && !defaultMatchesScalar(strings.TrimSuffix(f.Type, "!"), reflect.ValueOf(f.Default).Kind()) {
+			return definition, fmt.Errorf(
+				"input type %q: field %q has default %#v (%T) which is not compatible with type %s",
+				definition.Name, f.Name, f.Default, f.Default, f.Type,
+			)
+		}
+	}
+	if err := validateRequiredIf(definition); err != nil {
+		return definition, err
+	}
+	if definition.OneOf {
+		for _, f := range definition.Fields {
+			if !f.Nullable {
+				return definition, fmt.Errorf(
+					"input type %q is oneOf but field %q is non-nullable; all fields of a oneOf input must be nullable",
+					definition.Name, f.Name,
+				)
+			}
+			if f.Default != nil {
+				return definition, fmt.Errorf(
+					"input type %q is oneOf but field %q has a default; fields of a oneOf input cannot have defaults",
+					definition.Name, f.Name,
+				)
+			}
+		}
+	}
+
+	return definition, nil
+}
+
+// RegisterInputTypes extracts fields from Go struct types, as RegisterTypes
+// does, and registers each as an input type named after the struct. Fields may
+// declare a default with the `default=` tag key, e.g.
+// `fraiseql:"status,type=Status,default=ACTIVE"`.
+func RegisterInputTypes(types ...interface{}) error {
+	for _, t := range types {
+		structType := reflect.TypeOf(t)
+		if structType.Kind() == reflect.Pointer {
+			structType = structType.Elem()
+		}
+		if structType.Kind() != reflect.Struct {
+			return fmt.Errorf("expected struct type, got %v", structType.Kind())
+		}
+
+		fields, err := extractFieldList(structType)
+		if err != nil {
+			return fmt.Errorf("failed to extract fields from %s: %w", structType.Name(), err)
+		}
+		if err := RegisterInputType(InputTypeDefinition{Name: structType.Name(), Fields: fields}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateOneOfInput checks a runtime input value against a registered oneOf
+// input type: exactly one field must be provided, and its value must be non-null.
+func ValidateOneOfInput(typeName string, input map[string]interface{}) error {
+	reg := getInstance()
+	reg.mu.RLock()
+	def, exists := reg.inputTypes[typeName]
+	reg.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("input type %q is not registered", typeName)
+	}
+	if !def.OneOf {
+		return fmt.Errorf("input type %q is not a oneOf input", typeName)
+	}
+
+	known := make(map[string]struct{}, len(def.Fields))
+	for _, f := range def.Fields {
+		known[f.Name] = struct{}{}
+	}
+
+	provided := make([]string, 0, len(input))
+	for name, value := range input {
+		if _, ok := known[name]; !ok {
+			return fmt.Errorf("input type %q has no field %q", typeName, name)
+		}
+		if value == nil {
+			return fmt.Errorf("oneOf input %q: field %q must be non-null", typeName, name)
+		}
+		provided = append(provided, name)
+	}
+
+	if len(provided) != 1 {
+		sort.Strings(provided)
+		return fmt.Errorf(
+			"oneOf input %q requires exactly one field, got %d [%s]",
+			typeName, len(provided), strings.Join(provided, ", "),
+		)
+	}
+	return nil
+}
+
+// InputTypeBuilder provides a fluent interface for building input object types.
+type InputTypeBuilder struct {
+	name        string
+	fields      []FieldInfo
+	description string
+	oneOf       bool
+}
+
+// NewInputType creates a new input type builder with the given name.
+func NewInputType(name string) *InputTypeBuilder {
+	return &InputTypeBuilder{
+		name:   name,
+		fields: []FieldInfo{},
+	}
+}
+
+// Field adds a field to the input type.
+// nullable is a variadic bool (defaults to false if not provided)
+func (b *InputTypeBuilder) Field(name string, graphQLType string, nullable ...bool) *InputTypeBuilder {
+	b.fields = append(b.fields, FieldInfo{
+		Name:     name,
+		Type:     graphQLType,
+		Nullable: len(nullable) > 0 && nullable[0],
+	})
+	return b
+}
+
+// FieldWithDefault adds a field that takes defaultValue when omitted, e.g.
+// FieldWithDefault("status", "Status", "ACTIVE").
+// nullable is a variadic bool (defaults to false if not provided)
+func (b *InputTypeBuilder) FieldWithDefault(name string, graphQLType string, defaultValue interface{}, nullable ...bool) *InputTypeBuilder {
+	b.fields = append(b.fields, FieldInfo{
+		Name:     name,
+		Type:     graphQLType,
+		Nullable: len(nullable) > 0 && nullable[0],
+		Default:  defaultValue,
+	})
+	return b
+}
+
+// Description sets a human-readable description for this input type.
+func (b *InputTypeBuilder) Description(desc string) *InputTypeBuilder {
+	b.description = desc
+	return b
+}
+
+// OneOf marks the input as a `@oneOf` input object: exactly one field must be
+// provided at runtime. All fields must be nullable and have no default.
+func (b *InputTypeBuilder) OneOf() *InputTypeBuilder {
+	b.oneOf = true
+	return b
+}
+
+// Register registers the input type with the global schema registry.
+// Returns an error if the input type is already registered or fails validation.
+func (b *InputTypeBuilder) Register() error {
+	return RegisterInputType(InputTypeDefinition{
+		Name:        b.name,
+		Fields:      b.fields,
+		Description: b.description,
+		OneOf:       b.oneOf,
+	})
+}
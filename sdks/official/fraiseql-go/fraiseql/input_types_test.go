@@ -0,0 +1,368 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRegisterInputType(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	err := NewInputType("CreateUserInput").
+		Field("email", "String").
+		Field("name", "String", true).
+		Description("Input for creating a user").
+		Register()
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	schema := GetSchema()
+	if len(schema.InputTypes) != 1 {
+		t.Fatalf("expected 1 input type, got %d", len(schema.InputTypes))
+	}
+	in := schema.InputTypes[0]
+	if in.Name != "CreateUserInput" || len(in.Fields) != 2 {
+		t.Errorf("unexpected input type: %+v", in)
+	}
+	if in.Fields[0].Nullable || !in.Fields[1].Nullable {
+		t.Errorf("unexpected field nullability: %+v", in.Fields)
+	}
+
+	m := schemaMap(t)
+	inputs, _ := m["input_types"].([]interface{})
+	if len(inputs) != 1 {
+		t.Fatalf("expected input_types in JSON, got %v", m["input_types"])
+	}
+	if _, has := inputs[0].(map[string]interface{})["one_of"]; has {
+		t.Error("one_of should be omitted for regular input types")
+	}
+
+	if err := RegisterInputType(InputTypeDefinition{Name: "CreateUserInput"}); err == nil {
+		t.Error("expected duplicate input type registration to fail")
+	}
+}
+
+func TestOneOfInputType(t *testing.T) {
+	t.Run("exports one_of flag", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := NewInputType("UserBy").
+			Field("id", "ID", true).
+			Field("email", "String", true).
+			OneOf().
+			Register()
+		if err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+
+		inputs, _ := schemaMap(t)["input_types"].([]interface{})
+		if len(inputs) != 1 {
+			t.Fatalf("expected 1 input type, got %d", len(inputs))
+		}
+		if got := inputs[0].(map[string]interface{})["one_of"]; got != true {
+			t.Errorf("one_of: want true, got %v", got)
+		}
+	})
+
+	t.Run("rejects non-nullable field", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := NewInputType("UserBy").
+			Field("id", "ID").
+			Field("email", "String", true).
+			OneOf().
+			Register()
+		if err == nil {
+			t.Fatal("expected error for non-nullable field in oneOf input")
+		}
+		if !strings.Contains(err.Error(), `field "id"`) {
+			t.Errorf("error should name the offending field, got: %v", err)
+		}
+		if len(GetSchema().InputTypes) != 0 {
+			t.Error("invalid oneOf input should not be registered")
+		}
+	})
+
+	t.Run("rejects field default", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := RegisterInputType(InputTypeDefinition{Name: "UserBy", OneOf: true, Fields: []FieldInfo{
+			{Name: "id", Type: "ID", Nullable: true},
+			{Name: "email", Type: "String", Nullable: true, Default: "x"},
+		}})
+		if err == nil || !strings.Contains(err.Error(), `field "email" has a default`) {
+			t.Fatalf("expected a default error naming the field, got %v", err)
+		}
+		if len(GetSchema().InputTypes) != 0 {
+			t.Error("invalid oneOf input should not be registered")
+		}
+	})
+}
+
+func TestValidateOneOfInput(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewInputType("UserBy").
+		Field("id", "ID", true).
+		Field("email", "String", true).
+		OneOf().
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewInputType("UserFilter").Field("name", "String", true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		typeName string
+		input    map[string]interface{}
+		wantErr  string
+	}{
+		{name: "exactly one field", typeName: "UserBy", input: map[string]interface{}{"id": "u1"}},
+		{name: "no fields", typeName: "UserBy", input: map[string]interface{}{}, wantErr: "exactly one field"},
+		{name: "two fields", typeName: "UserBy", input: map[string]interface{}{"id": "u1", "email": "a@b.c"}, wantErr: "exactly one field"},
+		{name: "null field", typeName: "UserBy", input: map[string]interface{}{"id": nil}, wantErr: "non-null"},
+		{name: "unknown field", typeName: "UserBy", input: map[string]interface{}{"name": "x"}, wantErr: "no field"},
+		{name: "not a oneOf input", typeName: "UserFilter", input: map[string]interface{}{"name": "x"}, wantErr: "not a oneOf"},
+		{name: "unregistered input", typeName: "Missing", input: map[string]interface{}{}, wantErr: "not registered"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOneOfInput(tt.typeName, tt.input)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestArgListOfInputs(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewInputType("CreateItemInput").Field("name", "String").Register(); err != nil {
+		t.Fatalf("Register input: %v", err)
+	}
+	if err := NewMutation("bulkCreate").
+		ReturnType("Item").
+		ReturnsArray(true).
+		ArgList("items", "CreateItemInput", false).
+		Register(); err != nil {
+		t.Fatalf("Register mutation: %v", err)
+	}
+	if err := NewQuery("previewItems").
+		ReturnType("Item").
+		ReturnsArray(true).
+		ArgList("drafts", "CreateItemInput", true).
+		Register(); err != nil {
+		t.Fatalf("Register query: %v", err)
+	}
+
+	schema := GetSchema()
+	items := schema.Mutations[0].Arguments[0]
+	if items.Name != "items" || items.Type != "[CreateItemInput!]" || items.Nullable {
+		t.Errorf("want required items: [CreateItemInput!], got %+v", items)
+	}
+	drafts := schema.Queries[0].Arguments[0]
+	if drafts.Type != "[CreateItemInput]" || drafts.Nullable {
+		t.Errorf("want required drafts: [CreateItemInput], got %+v", drafts)
+	}
+}
+
+func TestArgListRequiresRegisteredInput(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	// A registered object type is not an input type.
+	if err := RegisterType("Item", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	for _, inputType := range []string{"MissingInput", "Item"} {
+		err := NewMutation("bulkCreate").
+			ReturnType("Item").
+			ArgList("items", inputType, false).
+			Register()
+		want := `argument "items": "` + inputType + `" is not a registered input type`
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+	if len(GetSchema().Mutations) != 0 {
+		t.Error("mutation with an unknown input list element should not be registered")
+	}
+}
+
+func TestInputFieldScalarDefault(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type ListPostsInput struct {
+		Limit     int     `fraiseql:"limit,default=20"`
+		MinScore  float64 `fraiseql:"minScore,default=0.5"`
+		Published bool    `fraiseql:"published,default=false"`
+		Query     string  `fraiseql:"query,default=all"`
+		Cursor    *string `fraiseql:"cursor"`
+	}
+
+	if err := RegisterInputTypes(ListPostsInput{}); err != nil {
+		t.Fatalf("RegisterInputTypes: %v", err)
+	}
+	fields := GetSchema().InputTypes[0].Fields
+	want := []interface{}{20, 0.5, false, "all", nil}
+	for i, w := range want {
+		if fields[i].Default != w {
+			t.Errorf("%s: want default %#v, got %#v", fields[i].Name, w, fields[i].Default)
+		}
+	}
+
+	m := schemaMap(t)
+	exported := m["input_types"].([]interface{})[0].(map[string]interface{})["fields"].([]interface{})
+	if d := exported[2].(map[string]interface{})["default"]; d != false {
+		t.Errorf("a false default should still be exported, got %v", d)
+	}
+	if _, has := exported[4].(map[string]interface{})["default"]; has {
+		t.Error("default should be omitted for fields without one")
+	}
+}
+
+func TestInputFieldEnumDefault(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	Enum("Status", map[string]string{"ACTIVE": "active", "ARCHIVED": "archived"})
+	if err := NewInputType("UserFilter").
+		FieldWithDefault("status", "Status", "ACTIVE").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if errs := ValidateSchema(); errs != nil {
+		t.Fatalf("a valid enum default should validate, got %v", errs)
+	}
+
+	if err := NewInputType("ArchiveFilter").
+		FieldWithDefault("status", "Status", "DELETED").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	errs := ValidateSchema()
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `field "status" has default DELETED, which is not a value of enum Status`) {
+		t.Errorf("expected an invalid enum default error, got %v", errs)
+	}
+}
+
+func TestFieldDefaultValidation(t *testing.T) {
+	t.Run("tag default does not parse", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		type BadInput struct {
+			Limit int `fraiseql:"limit,default=many"`
+		}
+		err := RegisterInputTypes(BadInput{})
+		if err == nil || !strings.Contains(err.Error(), `default "many" is not an Int`) {
+			t.Errorf("expected parse error, got %v", err)
+		}
+	})
+
+	t.Run("default does not match scalar", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := NewInputType("PageInput").FieldWithDefault("limit", "Int", "ten").Register()
+		if err == nil || !strings.Contains(err.Error(), `field "limit" has default "ten" (string) which is not compatible with type Int`) {
+			t.Errorf("expected type mismatch error, got %v", err)
+		}
+	})
+
+	t.Run("default on an output type field", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		type Post struct {
+			Status string `fraiseql:"status,default=DRAFT"`
+		}
+		err := RegisterTypes(Post{})
+		if err == nil || !strings.Contains(err.Error(), "defaults only apply to input type fields") {
+			t.Errorf("expected output-field default error, got %v", err)
+		}
+	})
+}
+
+func TestInputFieldRequiredIf(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type ReviewInput struct {
+		Status string  `fraiseql:"status"`
+		Reason *string `fraiseql:"reason,requiredIf=status:rejected"`
+	}
+	if err := RegisterInputTypes(ReviewInput{}); err != nil {
+		t.Fatalf("RegisterInputTypes: %v", err)
+	}
+
+	data, err := json.Marshal(GetSchema().InputTypes[0].Fields[1])
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"required_if":{"field":"status","value":"rejected"}`) {
+		t.Errorf("expected required_if rule in %s", data)
+	}
+}
+
+func TestInputFieldRequiredIfValidation(t *testing.T) {
+	t.Run("unknown referenced field", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		type ReviewInput struct {
+			Reason *string `fraiseql:"reason,requiredIf=status:rejected"`
+		}
+		err := RegisterInputTypes(ReviewInput{})
+		if err == nil || !strings.Contains(err.Error(), `requiredIf on "status", which is not a field of the input type`) {
+			t.Errorf("expected missing-field error, got %v", err)
+		}
+	})
+
+	t.Run("malformed rule", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		type ReviewInput struct {
+			Status string  `fraiseql:"status"`
+			Reason *string `fraiseql:"reason,requiredIf=status"`
+		}
+		err := RegisterInputTypes(ReviewInput{})
+		if err == nil || !strings.Contains(err.Error(), "requiredIf must have the form field:value") {
+			t.Errorf("expected parse error, got %v", err)
+		}
+	})
+
+	t.Run("non-nullable field", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		type ReviewInput struct {
+			Status string `fraiseql:"status"`
+			Reason string `fraiseql:"reason,requiredIf=status:rejected"`
+		}
+		err := RegisterInputTypes(ReviewInput{})
+		if err == nil || !strings.Contains(err.Error(), "conditionally required fields must be nullable") {
+			t.Errorf("expected nullability error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,646 @@
+package fraiseql
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// introspectionResult is the top-level shape of a GraphQL introspection query result.
+type introspectionResult struct {
+	Schema introspectionSchema `json:"__schema"`
+}
+
+type introspectionSchema struct {
+	QueryType        *introspectionNamedRef   `json:"queryType"`
+	MutationType     *introspectionNamedRef   `json:"mutationType"`
+	SubscriptionType *introspectionNamedRef   `json:"subscriptionType"`
+	Types            []introspectionType      `json:"types"`
+	Directives       []introspectionDirective `json:"directives"`
+}
+
+type introspectionDirective struct {
+	Name         string                    `json:"name"`
+	Description  *string                   `json:"description"`
+	Locations    []string                  `json:"locations"`
+	Args         []introspectionInputValue `json:"args"`
+	IsRepeatable bool                      `json:"isRepeatable"`
+}
+
+type introspectionNamedRef struct {
+	Name string `json:"name"`
+}
+
+// introspectionType is a __Type node. Slices that do not apply to a kind are
+// left nil so they serialize as null, as the introspection spec requires.
+type introspectionType struct {
+	Kind           string                    `json:"kind"`
+	Name           string                    `json:"name"`
+	Description    *string                   `json:"description"`
+	Fields         []introspectionField      `json:"fields"`
+	InputFields    []introspectionInputValue `json:"inputFields"`
+	Interfaces     []introspectionTypeRef    `json:"interfaces"`
+	EnumValues     []introspectionEnumValue  `json:"enumValues"`
+	PossibleTypes  []introspectionTypeRef    `json:"possibleTypes"`
+	SpecifiedByURL *string                   `json:"specifiedByURL"`
+	// IsOneOf is set for input object types only.
+	IsOneOf *bool `json:"isOneOf"`
+}
+
+type introspectionField struct {
+	Name              string                    `json:"name"`
+	Description       *string                   `json:"description"`
+	Args              []introspectionInputValue `json:"args"`
+	Type              introspectionTypeRef      `json:"type"`
+	IsDeprecated      bool                      `json:"isDeprecated"`
+	DeprecationReason *string                   `json:"deprecationReason"`
+}
+
+type introspectionInputValue struct {
+	Name              string               `json:"name"`
+	Description       *string              `json:"description"`
+	Type              introspectionTypeRef `json:"type"`
+	DefaultValue      *string              `json:"defaultValue"`
+	IsDeprecated      bool                 `json:"isDeprecated"`
+	DeprecationReason *string              `json:"deprecationReason"`
+}
+
+type introspectionEnumValue struct {
+	Name              string  `json:"name"`
+	Description       *string `json:"description"`
+	IsDeprecated      bool    `json:"isDeprecated"`
+	DeprecationReason *string `json:"deprecationReason"`
+}
+
+// introspectionTypeRef is a (possibly wrapped) type reference: NON_NULL and
+// LIST wrappers carry their inner type in OfType, named types carry Name.
+type introspectionTypeRef struct {
+	Kind   string                `json:"kind"`
+	Name   *string               `json:"name"`
+	OfType *introspectionTypeRef `json:"ofType"`
+}
+
+// ExportIntrospectionJSON exports the schema registry in the standard GraphQL
+// introspection result shape (`{"__schema": {...}}`), so that tools such as
+// GraphiQL or GraphQL Voyager can be pointed at a static file.
+//
+// This is purely a serialization adapter over GetSchema(): root operations
+// become fields of the Query, Mutation and Subscription object types, and
+// every referenced scalar is declared as a SCALAR type, with its
+// specifiedByURL when one is known. Input object types carry isOneOf. The
+// specification's @include, @skip, @deprecated and @specifiedBy directives are
+// always declared, @oneOf when a oneOf input type is registered, and the
+// directives declared with RegisterDirective after them.
+func ExportIntrospectionJSON() ([]byte, error) {
+	schema, err := buildSchema()
+	if err != nil {
+		return nil, err
+	}
+	kinds := introspectionKinds(schema)
+	inputs := make(map[string]InputTypeDefinition, len(schema.InputTypes))
+	for _, in := range schema.InputTypes {
+		inputs[in.Name] = in
+	}
+
+	var types []introspectionType
+
+	// Root operation types
+	queryFields := make([]introspectionField, 0, len(schema.Queries))
+	for _, q := range schema.Queries {
+		queryFields = append(queryFields, operationField(
+			q.Name, q.Description, q.Arguments, q.ReturnType, q.ReturnsList, q.Nullable, q.Deprecation, kinds, inputs,
+		))
+	}
+	types = append(types, objectType("Query", "", queryFields, nil))
+	result := introspectionResult{Schema: introspectionSchema{
+		QueryType:  &introspectionNamedRef{Name: "Query"},
+		Directives: specDirectives(kinds),
+	}}
+
+	if len(schema.Mutations) > 0 {
+		fields := make([]introspectionField, 0, len(schema.Mutations))
+		for _, m := range schema.Mutations {
+			fields = append(fields, operationField(
+				m.Name, m.Description, m.Arguments, m.ReturnType, m.ReturnsList, m.Nullable, m.Deprecation, kinds, inputs,
+			))
+		}
+		types = append(types, objectType("Mutation", "", fields, nil))
+		result.Schema.MutationType = &introspectionNamedRef{Name: "Mutation"}
+	}
+
+	if len(schema.Subscriptions) > 0 {
+		fields := make([]introspectionField, 0, len(schema.Subscriptions))
+		for _, s := range schema.Subscriptions {
+			fields = append(fields, operationField(
+				s.Name, s.Description, s.Arguments, s.EntityType, false, s.Nullable, nil, kinds, inputs,
+			))
+		}
+		types = append(types, objectType("Subscription", "", fields, nil))
+		result.Schema.SubscriptionType = &introspectionNamedRef{Name: "Subscription"}
+	}
+
+	// Object types
+	for _, t := range schema.Types {
+		fields := make([]introspectionField, 0, len(t.Fields))
+		for _, f := range t.Fields {
+			if f.Internal {
+				continue
+			}
+			fields = append(fields, objectField(f, kinds))
+		}
+		types = append(types, objectType(t.Name, t.Description, fields, typeRefs(t.Implements, kinds)))
+	}
+
+	// Interfaces, which the schema declares only through the types that
+	// implement them.
+	types = append(types, interfaceTypes(schema, kinds)...)
+
+	// Input object types
+	oneOf := false
+	for _, in := range schema.InputTypes {
+		isOneOf := in.OneOf
+		oneOf = oneOf || isOneOf
+		inputFields := make([]introspectionInputValue, 0, len(in.Fields))
+		for _, f := range in.Fields {
+			input := introspectionInputValue{
+				Name:        f.Name,
+				Description: optionalString(f.Description),
+				Type:        introspectionRef(f.Type, f.Nullable, kinds),
+			}
+			if f.Default != nil {
+				input.DefaultValue = defaultLiteral(f.Default, f.Type, kinds, inputs)
+			}
+			inputFields = append(inputFields, input)
+		}
+		types = append(types, introspectionType{
+			Kind:        "INPUT_OBJECT",
+			Name:        in.Name,
+			Description: optionalString(in.Description),
+			InputFields: inputFields,
+			IsOneOf:     &isOneOf,
+		})
+	}
+	if oneOf {
+		result.Schema.Directives = append(result.Schema.Directives, introspectionDirective{
+			Name:        "oneOf",
+			Description: optionalString("Indicates exactly one field must be supplied and this field must not be `null`."),
+			Locations:   []string{"INPUT_OBJECT"},
+			Args:        []introspectionInputValue{},
+		})
+	}
+
+	// Directives declared with RegisterDirective
+	for _, d := range schema.Directives {
+		args := make([]introspectionInputValue, 0, len(d.Arguments))
+		for _, a := range d.Arguments {
+			arg := introspectionInputValue{
+				Name:        a.Name,
+				Description: optionalString(a.Description),
+				Type:        introspectionRef(a.Type, a.Nullable, kinds),
+			}
+			if a.IsDefault || a.Default != nil {
+				arg.DefaultValue = defaultLiteral(a.Default, a.Type, kinds, inputs)
+			}
+			args = append(args, arg)
+		}
+		result.Schema.Directives = append(result.Schema.Directives, introspectionDirective{
+			Name:      d.Name,
+			Locations: d.Locations,
+			Args:      args,
+		})
+	}
+
+	// Enums
+	for _, e := range schema.Enums {
+		values := make([]introspectionEnumValue, 0, len(e.Values))
+		for _, v := range e.Values {
+			value := introspectionEnumValue{Name: v.Name, Description: optionalString(v.Description)}
+			if v.Deprecation != nil {
+				value.IsDeprecated = true
+				value.DeprecationReason = optionalString(v.Deprecation.Reason)
+			}
+			values = append(values, value)
+		}
+		types = append(types, introspectionType{Kind: "ENUM", Name: e.Name, EnumValues: values})
+	}
+
+	// Scalars
+	var scalars []string
+	for name, kind := range kinds {
+		if kind == "SCALAR" {
+			scalars = append(scalars, name)
+		}
+	}
+	sort.Strings(scalars)
+	for _, name := range scalars {
+		types = append(types, introspectionType{
+			Kind:           "SCALAR",
+			Name:           name,
+			SpecifiedByURL: optionalString(ScalarSpecifiedByURL(name)),
+		})
+	}
+
+	result.Schema.Types = types
+	return json.MarshalIndent(result, "", "  ")
+}
+
+// introspectionKinds maps every type name referenced by the schema to its
+// introspection kind. Built-in scalars are always present, and a name in a
+// type's Implements that is not a registered type is an interface; any other
+// name that is not a registered object, input or enum is treated as a scalar.
+func introspectionKinds(schema Schema) map[string]string {
+	kinds := make(map[string]string)
+	for name := range builtinScalars {
+		kinds[name] = "SCALAR"
+	}
+	for _, t := range schema.Types {
+		kinds[t.Name] = "OBJECT"
+	}
+	for _, t := range schema.Types {
+		for _, iface := range t.Implements {
+			if _, known := kinds[iface]; !known {
+				kinds[iface] = "INTERFACE"
+			}
+		}
+	}
+	for _, in := range schema.InputTypes {
+		kinds[in.Name] = "INPUT_OBJECT"
+	}
+	for _, e := range schema.Enums {
+		kinds[e.Name] = "ENUM"
+	}
+	for _, cs := range schema.CustomScalars {
+		if name, ok := cs["name"].(string); ok {
+			kinds[name] = "SCALAR"
+		}
+	}
+
+	addRef := func(typeStr string) {
+		name := namedType(typeStr)
+		if _, known := kinds[name]; !known && name != "" {
+			kinds[name] = "SCALAR"
+		}
+	}
+	for _, t := range schema.Types {
+		for _, f := range t.Fields {
+			addRef(f.Type)
+		}
+	}
+	for _, in := range schema.InputTypes {
+		for _, f := range in.Fields {
+			addRef(f.Type)
+		}
+	}
+	for _, q := range schema.Queries {
+		addRef(q.ReturnType)
+		for _, a := range q.Arguments {
+			addRef(a.Type)
+		}
+	}
+	for _, m := range schema.Mutations {
+		addRef(m.ReturnType)
+		for _, a := range m.Arguments {
+			addRef(a.Type)
+		}
+	}
+	for _, s := range schema.Subscriptions {
+		addRef(s.EntityType)
+		for _, a := range s.Arguments {
+			addRef(a.Type)
+		}
+	}
+	return kinds
+}
+
+// namedType strips list brackets and non-null markers from a type string:
+// "[User!]!" -> "User".
+func namedType(typeStr string) string {
+	return strings.Trim(typeStr, "[]!")
+}
+
+// introspectionRef builds a type reference from a FraiseQL type string such as
+// "String", "[Post!]" or "ID!". When nullable is false the outermost type is
+// wrapped in NON_NULL (unless the string already ends with "!").
+func introspectionRef(typeStr string, nullable bool, kinds map[string]string) introspectionTypeRef {
+	ref := parseTypeRef(typeStr, kinds)
+	if !nullable && ref.Kind != "NON_NULL" {
+		inner := ref
+		ref = introspectionTypeRef{Kind: "NON_NULL", OfType: &inner}
+	}
+	return ref
+}
+
+func parseTypeRef(typeStr string, kinds map[string]string) introspectionTypeRef {
+	typeStr = strings.TrimSpace(typeStr)
+	if strings.HasSuffix(typeStr, "!") {
+		inner := parseTypeRef(strings.TrimSuffix(typeStr, "!"), kinds)
+		return introspectionTypeRef{Kind: "NON_NULL", OfType: &inner}
+	}
+	if strings.HasPrefix(typeStr, "[") && strings.HasSuffix(typeStr, "]") {
+		inner := parseTypeRef(typeStr[1:len(typeStr)-1], kinds)
+		return introspectionTypeRef{Kind: "LIST", OfType: &inner}
+	}
+	kind, ok := kinds[typeStr]
+	if !ok {
+		kind = "SCALAR"
+	}
+	name := typeStr
+	return introspectionTypeRef{Kind: kind, Name: &name}
+}
+
+// operationField builds a root-type field for a query, mutation or subscription.
+// List results are modelled as lists of non-null elements.
+func operationField(
+	name, description string,
+	args []ArgumentDefinition,
+	returnType string,
+	returnsList, nullable bool,
+	deprecation *DeprecationInfo,
+	kinds map[string]string,
+	inputTypes map[string]InputTypeDefinition,
+) introspectionField {
+	typeStr := returnType
+	if returnsList {
+		typeStr = "[" + returnType + "!]"
+	}
+
+	inputs := make([]introspectionInputValue, 0, len(args))
+	for _, a := range args {
+		if a.Hidden {
+			continue
+		}
+		input := introspectionInputValue{
+			Name:        a.Name,
+			Description: optionalString(a.Description),
+			Type:        introspectionRef(a.Type, a.Nullable, kinds),
+		}
+		if a.IsDefault {
+			input.DefaultValue = defaultLiteral(a.Default, a.Type, kinds, inputTypes)
+		}
+		if a.Deprecated != nil {
+			input.IsDeprecated = true
+			input.DeprecationReason = optionalString(a.Deprecated.Reason)
+		}
+		inputs = append(inputs, input)
+	}
+
+	field := introspectionField{
+		Name:        name,
+		Description: optionalString(description),
+		Args:        inputs,
+		Type:        introspectionRef(typeStr, nullable, kinds),
+	}
+	if deprecation != nil {
+		field.IsDeprecated = true
+		field.DeprecationReason = optionalString(deprecation.Reason)
+	}
+	return field
+}
+
+func objectType(name, description string, fields []introspectionField, interfaces []introspectionTypeRef) introspectionType {
+	if interfaces == nil {
+		interfaces = []introspectionTypeRef{}
+	}
+	return introspectionType{
+		Kind:        "OBJECT",
+		Name:        name,
+		Description: optionalString(description),
+		Fields:      fields,
+		Interfaces:  interfaces,
+	}
+}
+
+// objectField builds the field of an object or interface type.
+func objectField(f FieldInfo, kinds map[string]string) introspectionField {
+	return introspectionField{
+		Name:        f.Name,
+		Description: optionalString(f.Description),
+		Args:        []introspectionInputValue{},
+		Type:        introspectionRef(f.Type, f.Nullable, kinds),
+	}
+}
+
+// typeRefs builds named type references, e.g. to the interfaces a type
+// implements.
+func typeRefs(names []string, kinds map[string]string) []introspectionTypeRef {
+	refs := make([]introspectionTypeRef, 0, len(names))
+	for _, name := range names {
+		refs = append(refs, parseTypeRef(name, kinds))
+	}
+	return refs
+}
+
+// interfaceTypes builds an INTERFACE type for each interface named in a
+// type's Implements. Since interfaces have no definition of their own, an
+// interface's fields are those every implementing type has with the same
+// type and nullability, in the first implementer's order.
+func interfaceTypes(schema Schema, kinds map[string]string) []introspectionType {
+	implementors := make(map[string][]TypeDefinition)
+	var names []string
+	for _, t := range schema.Types {
+		for _, iface := range t.Implements {
+			if kinds[iface] != "INTERFACE" {
+				continue
+			}
+			if _, seen := implementors[iface]; !seen {
+				names = append(names, iface)
+			}
+			implementors[iface] = append(implementors[iface], t)
+		}
+	}
+	sort.Strings(names)
+
+	types := make([]introspectionType, 0, len(names))
+	for _, name := range names {
+		impls := implementors[name]
+		fields := []introspectionField{}
+		for _, f := range impls[0].Fields {
+			if f.Internal {
+				continue
+			}
+			shared := true
+			for _, impl := range impls[1:] {
+				if !hasField(impl, f) {
+					shared = false
+					break
+				}
+			}
+			if shared {
+				fields = append(fields, objectField(f, kinds))
+			}
+		}
+		possible := make([]string, 0, len(impls))
+		for _, impl := range impls {
+			possible = append(possible, impl.Name)
+		}
+		types = append(types, introspectionType{
+			Kind:          "INTERFACE",
+			Name:          name,
+			Fields:        fields,
+			Interfaces:    []introspectionTypeRef{},
+			PossibleTypes: typeRefs(possible, kinds),
+		})
+	}
+	return types
+}
+
+// hasField reports whether t has a public field with f's name, type and
+// nullability.
+func hasField(t TypeDefinition, f FieldInfo) bool {
+	for _, other := range t.Fields {
+		if other.Name == f.Name {
+			return !other.Internal && other.Type == f.Type && other.Nullable == f.Nullable
+		}
+	}
+	return false
+}
+
+// specDirectives declares the directives of the GraphQL specification, in the
+// order graphql-js lists them.
+func specDirectives(kinds map[string]string) []introspectionDirective {
+	arg := func(name, typeStr string, nullable bool, description string) introspectionInputValue {
+		return introspectionInputValue{
+			Name:        name,
+			Description: optionalString(description),
+			Type:        introspectionRef(typeStr, nullable, kinds),
+		}
+	}
+	reason := arg("reason", "String", true,
+		"Explains why this element was deprecated, usually also including a suggestion for how to access "+
+			"supported similar data. Formatted using the Markdown syntax, as specified by "+
+			"[CommonMark](https://commonmark.org/).")
+	reason.DefaultValue = optionalString(`"No longer supported"`)
+
+	return []introspectionDirective{
+		{
+			Name:        "include",
+			Description: optionalString("Directs the executor to include this field or fragment only when the `if` argument is true."),
+			Locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+			Args:        []introspectionInputValue{arg("if", "Boolean", false, "Included when true.")},
+		},
+		{
+			Name:        "skip",
+			Description: optionalString("Directs the executor to skip this field or fragment when the `if` argument is true."),
+			Locations:   []string{"FIELD", "FRAGMENT_SPREAD", "INLINE_FRAGMENT"},
+			Args:        []introspectionInputValue{arg("if", "Boolean", false, "Skipped when true.")},
+		},
+		{
+			Name:        "deprecated",
+			Description: optionalString("Marks an element of a GraphQL schema as no longer supported."),
+			Locations:   []string{"FIELD_DEFINITION", "ARGUMENT_DEFINITION", "INPUT_FIELD_DEFINITION", "ENUM_VALUE"},
+			Args:        []introspectionInputValue{reason},
+		},
+		{
+			Name:        "specifiedBy",
+			Description: optionalString("Exposes a URL that specifies the behavior of this scalar."),
+			Locations:   []string{"SCALAR"},
+			Args:        []introspectionInputValue{arg("url", "String", false, "The URL that specifies the behavior of this scalar.")},
+		},
+	}
+}
+
+// defaultLiteral renders a default value as the defaultValue literal: a
+// GraphQL value in the syntax of the argument or field type typeStr, with
+// enum values bare and input objects written as {name: value}. It returns nil
+// if the value cannot be marshaled.
+func defaultLiteral(value interface{}, typeStr string, kinds map[string]string, inputs map[string]InputTypeDefinition) *string {
+	// Round-trip through JSON so structs, typed maps and named types are
+	// reduced to strings, numbers, bools, slices and maps.
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var plain interface{}
+	if err := dec.Decode(&plain); err != nil {
+		return nil
+	}
+	var b strings.Builder
+	writeValueLiteral(&b, plain, typeStr, kinds, inputs)
+	s := b.String()
+	return &s
+}
+
+// writeValueLiteral writes value, as decoded from JSON, as a GraphQL value
+// literal of type typeStr.
+func writeValueLiteral(b *strings.Builder, value interface{}, typeStr string, kinds map[string]string, inputs map[string]InputTypeDefinition) {
+	typeStr = strings.TrimSuffix(strings.TrimSpace(typeStr), "!")
+	elemType := typeStr
+	if strings.HasPrefix(typeStr, "[") && strings.HasSuffix(typeStr, "]") {
+		elemType = typeStr[1 : len(typeStr)-1]
+	}
+
+	switch v := value.(type) {
+	case nil:
+		b.WriteString("null")
+	case bool, json.Number:
+		b.WriteString(jsonString(v))
+	case string:
+		if kinds[namedType(typeStr)] == "ENUM" {
+			b.WriteString(v)
+		} else {
+			b.WriteString(jsonString(v))
+		}
+	case []interface{}:
+		b.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			writeValueLiteral(b, elem, elemType, kinds, inputs)
+		}
+		b.WriteByte(']')
+	case map[string]interface{}:
+		// Fields of a registered input type are written in declaration
+		// order with their declared types, any others sorted by name.
+		fieldTypes := make(map[string]string)
+		var names []string
+		if in, ok := inputs[namedType(typeStr)]; ok {
+			for _, f := range in.Fields {
+				if _, set := v[f.Name]; set {
+					fieldTypes[f.Name] = f.Type
+					names = append(names, f.Name)
+				}
+			}
+		}
+		var rest []string
+		for name := range v {
+			if _, known := fieldTypes[name]; !known {
+				rest = append(rest, name)
+			}
+		}
+		sort.Strings(rest)
+		names = append(names, rest...)
+
+		b.WriteByte('{')
+		for i, name := range names {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(name)
+			b.WriteString(": ")
+			writeValueLiteral(b, v[name], fieldTypes[name], kinds, inputs)
+		}
+		b.WriteByte('}')
+	}
+}
+
+// jsonString marshals a string, bool or number without HTML escaping; for
+// these the JSON and GraphQL literal syntaxes coincide.
+func jsonString(v interface{}) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v) //nolint:errcheck
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// optionalString returns nil for the empty string so it serializes as null.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
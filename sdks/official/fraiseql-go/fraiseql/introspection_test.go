@@ -0,0 +1,541 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// introspectionMap exports the introspection JSON and returns its __schema node.
+func introspectionMap(t *testing.T) map[string]interface{} {
+	t.Helper()
+	data, err := ExportIntrospectionJSON()
+	if err != nil {
+		t.Fatalf("ExportIntrospectionJSON: %v", err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	schema, ok := m["__schema"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("missing __schema in %s", data)
+	}
+	return schema
+}
+
+// findIntrospectionType looks up a __Type node by name.
+func findIntrospectionType(schema map[string]interface{}, name string) map[string]interface{} {
+	types, _ := schema["types"].([]interface{})
+	for _, tp := range types {
+		tm, ok := tp.(map[string]interface{})
+		if ok && tm["name"] == name {
+			return tm
+		}
+	}
+	return nil
+}
+
+// findIntrospectionField looks up a field by name on a __Type node.
+func findIntrospectionField(typ map[string]interface{}, name string) map[string]interface{} {
+	fields, _ := typ["fields"].([]interface{})
+	for _, f := range fields {
+		fm, ok := f.(map[string]interface{})
+		if ok && fm["name"] == name {
+			return fm
+		}
+	}
+	return nil
+}
+
+// findIntrospectionDirective looks up a __Directive node by name.
+func findIntrospectionDirective(schema map[string]interface{}, name string) map[string]interface{} {
+	directives, _ := schema["directives"].([]interface{})
+	for _, d := range directives {
+		dm, ok := d.(map[string]interface{})
+		if ok && dm["name"] == name {
+			return dm
+		}
+	}
+	return nil
+}
+
+func TestExportIntrospectionJSON(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterType("User", []FieldInfo{
+		{Name: "id", Type: "ID", Nullable: false},
+		{Name: "email", Type: "Email", Nullable: true},
+		{Name: "tags", Type: "[String!]", Nullable: false},
+	}, "A user"); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	Enum("Role", map[string]string{"ADMIN": "admin", "MEMBER": "member"})
+	if err := NewQuery("users").
+		ReturnType("User").
+		ReturnsArray(true).
+		Arg("limit", "Int", 10).
+		Arg("role", "Role", nil, true).
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewMutation("createUser").ReturnType("User").Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	schema := introspectionMap(t)
+
+	if qt, _ := schema["queryType"].(map[string]interface{}); qt["name"] != "Query" {
+		t.Errorf("queryType: want Query, got %v", schema["queryType"])
+	}
+	if mt, _ := schema["mutationType"].(map[string]interface{}); mt["name"] != "Mutation" {
+		t.Errorf("mutationType: want Mutation, got %v", schema["mutationType"])
+	}
+	if schema["subscriptionType"] != nil {
+		t.Errorf("subscriptionType: want null, got %v", schema["subscriptionType"])
+	}
+
+	user := findIntrospectionType(schema, "User")
+	if user == nil || user["kind"] != "OBJECT" {
+		t.Fatalf("expected OBJECT type User, got %v", user)
+	}
+	if user["description"] != "A user" {
+		t.Errorf("User description: got %v", user["description"])
+	}
+
+	// id: ID!  -> NON_NULL(SCALAR ID)
+	id := findIntrospectionField(user, "id")["type"].(map[string]interface{})
+	if id["kind"] != "NON_NULL" {
+		t.Errorf("id kind: want NON_NULL, got %v", id["kind"])
+	}
+	if inner := id["ofType"].(map[string]interface{}); inner["kind"] != "SCALAR" || inner["name"] != "ID" {
+		t.Errorf("id ofType: want SCALAR ID, got %v", inner)
+	}
+
+	// email: Email (nullable) -> SCALAR Email, declared as a scalar type
+	email := findIntrospectionField(user, "email")["type"].(map[string]interface{})
+	if email["kind"] != "SCALAR" || email["name"] != "Email" {
+		t.Errorf("email: want SCALAR Email, got %v", email)
+	}
+	if s := findIntrospectionType(schema, "Email"); s == nil || s["kind"] != "SCALAR" {
+		t.Errorf("expected SCALAR type Email to be declared, got %v", s)
+	} else if s["specifiedByURL"] != "https://tools.ietf.org/html/rfc5322" {
+		t.Errorf("Email specifiedByURL: got %v", s["specifiedByURL"])
+	}
+	if s := findIntrospectionType(schema, "String"); s["specifiedByURL"] != nil {
+		t.Errorf("String specifiedByURL should be null, got %v", s["specifiedByURL"])
+	}
+
+	// tags: [String!]! -> NON_NULL(LIST(NON_NULL(SCALAR String)))
+	tags := findIntrospectionField(user, "tags")["type"].(map[string]interface{})
+	list := tags["ofType"].(map[string]interface{})
+	elem := list["ofType"].(map[string]interface{})
+	named := elem["ofType"].(map[string]interface{})
+	if tags["kind"] != "NON_NULL" || list["kind"] != "LIST" || elem["kind"] != "NON_NULL" || named["name"] != "String" {
+		t.Errorf("tags: unexpected type ref %v", tags)
+	}
+
+	// users(limit: Int! = 10, role: Role): [User!]!
+	query := findIntrospectionType(schema, "Query")
+	users := findIntrospectionField(query, "users")
+	if users == nil {
+		t.Fatal("Query.users not found")
+	}
+	ret := users["type"].(map[string]interface{})
+	if ret["kind"] != "NON_NULL" || ret["ofType"].(map[string]interface{})["kind"] != "LIST" {
+		t.Errorf("users return type: want NON_NULL(LIST), got %v", ret)
+	}
+	args := users["args"].([]interface{})
+	if len(args) != 2 {
+		t.Fatalf("expected 2 args, got %d", len(args))
+	}
+	limit := args[0].(map[string]interface{})
+	if limit["defaultValue"] != "10" {
+		t.Errorf("limit defaultValue: want \"10\", got %v", limit["defaultValue"])
+	}
+	role := args[1].(map[string]interface{})["type"].(map[string]interface{})
+	if role["kind"] != "ENUM" || role["name"] != "Role" {
+		t.Errorf("role arg: want ENUM Role, got %v", role)
+	}
+
+	roleType := findIntrospectionType(schema, "Role")
+	if roleType == nil || len(roleType["enumValues"].([]interface{})) != 2 {
+		t.Errorf("expected ENUM Role with 2 values, got %v", roleType)
+	}
+	if roleType["fields"] != nil {
+		t.Errorf("enum fields should be null, got %v", roleType["fields"])
+	}
+}
+
+func TestIntrospectionFieldDescriptionsAndDefaults(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterType("User", []FieldInfo{
+		{Name: "id", Type: "ID", Description: "Primary key"},
+	}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := RegisterInputType(InputTypeDefinition{Name: "UserFilter", Fields: []FieldInfo{
+		{Name: "limit", Type: "Int", Nullable: true, Default: 20, Description: "Page size"},
+	}}); err != nil {
+		t.Fatalf("RegisterInputType: %v", err)
+	}
+	if err := NewQuery("users").ReturnType("User").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	schema := introspectionMap(t)
+	id := findIntrospectionField(findIntrospectionType(schema, "User"), "id")
+	if id["description"] != "Primary key" {
+		t.Errorf("User.id description = %v, want Primary key", id["description"])
+	}
+	inputFields, _ := findIntrospectionType(schema, "UserFilter")["inputFields"].([]interface{})
+	if len(inputFields) != 1 {
+		t.Fatalf("expected one input field, got %v", inputFields)
+	}
+	limit := inputFields[0].(map[string]interface{})
+	if limit["description"] != "Page size" || limit["defaultValue"] != "20" {
+		t.Errorf("UserFilter.limit = %v, want its description and defaultValue 20", limit)
+	}
+}
+
+func TestIntrospectionInterfacesAndEnumValues(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	reg := getInstance()
+	reg.mu.Lock()
+	reg.types["Post"] = TypeDefinition{
+		Name:       "Post",
+		Fields:     []FieldInfo{{Name: "id", Type: "ID"}, {Name: "title", Type: "String"}},
+		Implements: []string{"Node"},
+	}
+	reg.types["Comment"] = TypeDefinition{
+		Name:       "Comment",
+		Fields:     []FieldInfo{{Name: "id", Type: "ID"}, {Name: "body", Type: "String"}},
+		Implements: []string{"Node"},
+	}
+	reg.mu.Unlock()
+	if err := NewQuery("node").ReturnType("Node").Nullable(true).Arg("id", "ID", nil).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	SetDescriptions(map[string]string{"Role.ADMIN": "Full access"})
+	Enum("Role", map[string]string{"ADMIN": "admin", "GUEST": "guest"})
+	if err := DeprecateEnumValue("Role", "GUEST", "use MEMBER"); err != nil {
+		t.Fatalf("DeprecateEnumValue: %v", err)
+	}
+
+	schema := introspectionMap(t)
+	post := findIntrospectionType(schema, "Post")
+	interfaces, _ := post["interfaces"].([]interface{})
+	if len(interfaces) != 1 {
+		t.Fatalf("Post interfaces = %v, want Node", post["interfaces"])
+	}
+	if ref := interfaces[0].(map[string]interface{}); ref["kind"] != "INTERFACE" || ref["name"] != "Node" {
+		t.Errorf("Post interface ref = %v, want INTERFACE Node", ref)
+	}
+
+	node := findIntrospectionType(schema, "Node")
+	if node == nil || node["kind"] != "INTERFACE" {
+		t.Fatalf("expected an INTERFACE Node type, got %v", node)
+	}
+	if fields, _ := node["fields"].([]interface{}); len(fields) != 1 || findIntrospectionField(node, "id") == nil {
+		t.Errorf("Node fields = %v, want only the shared id field", node["fields"])
+	}
+	if possible, _ := node["possibleTypes"].([]interface{}); len(possible) != 2 {
+		t.Errorf("Node possibleTypes = %v, want Comment and Post", node["possibleTypes"])
+	}
+
+	values, _ := findIntrospectionType(schema, "Role")["enumValues"].([]interface{})
+	if len(values) != 2 {
+		t.Fatalf("Role enumValues = %v", values)
+	}
+	admin, guest := values[0].(map[string]interface{}), values[1].(map[string]interface{})
+	if admin["description"] != "Full access" || admin["isDeprecated"] != false {
+		t.Errorf("ADMIN = %v, want its description and not deprecated", admin)
+	}
+	if guest["isDeprecated"] != true || guest["deprecationReason"] != "use MEMBER" {
+		t.Errorf("GUEST = %v, want deprecated with its reason", guest)
+	}
+
+	if err := DeprecateEnumValue("Role", "OWNER", "gone"); err == nil || !strings.Contains(err.Error(), `enum "Role" has no value "OWNER"`) {
+		t.Errorf("expected an unknown value error, got %v", err)
+	}
+}
+
+func TestIntrospectionOneOf(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewInputType("UserBy").Field("id", "ID", true).Field("email", "String", true).OneOf().Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewInputType("UserFilter").Field("name", "String", true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	schema := introspectionMap(t)
+	if got := findIntrospectionType(schema, "UserBy")["isOneOf"]; got != true {
+		t.Errorf("UserBy isOneOf = %v, want true", got)
+	}
+	if got := findIntrospectionType(schema, "UserFilter")["isOneOf"]; got != false {
+		t.Errorf("UserFilter isOneOf = %v, want false", got)
+	}
+	oneOf := findIntrospectionDirective(schema, "oneOf")
+	if oneOf == nil {
+		t.Fatalf("directives = %v, want the @oneOf directive", schema["directives"])
+	}
+	if locations := oneOf["locations"]; fmt.Sprint(locations) != "[INPUT_OBJECT]" {
+		t.Errorf("@oneOf locations = %v, want [INPUT_OBJECT]", locations)
+	}
+}
+
+func TestIntrospectionSpecDirectives(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := NewQuery("users").ReturnType("User").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	schema := introspectionMap(t)
+	var names []string
+	for _, d := range schema["directives"].([]interface{}) {
+		names = append(names, d.(map[string]interface{})["name"].(string))
+	}
+	if got := strings.Join(names, ","); got != "include,skip,deprecated,specifiedBy" {
+		t.Errorf("directives = %s, want the specification's directives without @oneOf", got)
+	}
+
+	args := findIntrospectionDirective(schema, "skip")["args"].([]interface{})
+	ifArg := args[0].(map[string]interface{})
+	if ifArg["name"] != "if" || ifArg["type"].(map[string]interface{})["kind"] != "NON_NULL" {
+		t.Errorf("@skip args = %v, want if: Boolean!", args)
+	}
+	reason := findIntrospectionDirective(schema, "deprecated")["args"].([]interface{})[0].(map[string]interface{})
+	if reason["defaultValue"] != `"No longer supported"` {
+		t.Errorf("@deprecated reason defaultValue = %v", reason["defaultValue"])
+	}
+}
+
+func TestIntrospectionDefaultValueLiterals(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	Enum("Status", map[string]string{"ACTIVE": "active", "ARCHIVED": "archived"})
+	if err := RegisterInputType(InputTypeDefinition{Name: "Page", Fields: []FieldInfo{
+		{Name: "size", Type: "Int", Nullable: true},
+		{Name: "status", Type: "Status", Nullable: true},
+		{Name: "after", Type: "String", Nullable: true},
+	}}); err != nil {
+		t.Fatalf("RegisterInputType: %v", err)
+	}
+	if err := RegisterType("Post", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	err := NewQuery("posts").ReturnType("Post").ReturnsArray(true).
+		Arg("status", "Status", "ACTIVE", true).
+		Arg("statuses", "[Status!]", []string{"ACTIVE", "ARCHIVED"}, true).
+		Arg("title", "String", `say "hi"`, true).
+		Arg("page", "Page", map[string]interface{}{"after": "x", "status": "ARCHIVED", "size": 10}, true).
+		Register()
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	want := map[string]string{
+		"status":   "ACTIVE",
+		"statuses": "[ACTIVE, ARCHIVED]",
+		"title":    `"say \"hi\""`,
+		"page":     `{size: 10, status: ARCHIVED, after: "x"}`,
+	}
+	posts := findIntrospectionField(findIntrospectionType(introspectionMap(t), "Query"), "posts")
+	for _, a := range posts["args"].([]interface{}) {
+		arg := a.(map[string]interface{})
+		name := arg["name"].(string)
+		if arg["defaultValue"] != want[name] {
+			t.Errorf("%s defaultValue = %v, want %s", name, arg["defaultValue"], want[name])
+		}
+	}
+}
+
+func TestInternalFieldsHiddenFromPublicSchema(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Post struct {
+		ID        ID     `fraiseql:"id"`
+		Title     string `fraiseql:"title"`
+		DeletedAt string `fraiseql:"deletedAt,type=DateTime,nullable=true,internal=true"`
+	}
+	if err := RegisterTypes(Post{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+
+	data, err := ExportTypes(false)
+	if err != nil {
+		t.Fatalf("ExportTypes: %v", err)
+	}
+	var exported struct {
+		Types []struct {
+			Fields []FieldInfo `json:"fields"`
+		} `json:"types"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	fields := exported.Types[0].Fields
+	if len(fields) != 3 || fields[2].Name != "deletedAt" || !fields[2].Internal {
+		t.Errorf("compiler JSON should keep deletedAt marked internal, got %+v", fields)
+	}
+
+	post := findIntrospectionType(introspectionMap(t), "Post")
+	if post == nil {
+		t.Fatal("Post missing from introspection")
+	}
+	if findIntrospectionField(post, "deletedAt") != nil {
+		t.Error("internal field deletedAt should be hidden from introspection")
+	}
+	if findIntrospectionField(post, "title") == nil {
+		t.Error("public field title missing from introspection")
+	}
+
+	ts, err := ExportTypeScript()
+	if err != nil {
+		t.Fatalf("ExportTypeScript: %v", err)
+	}
+	if strings.Contains(ts, "deletedAt") {
+		t.Errorf("internal field deletedAt should be hidden from TypeScript output:\n%s", ts)
+	}
+}
+
+func TestInternalFieldRejectedOnInputType(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	err := RegisterInputType(InputTypeDefinition{
+		Name:   "PostInput",
+		Fields: []FieldInfo{{Name: "deletedAt", Type: "DateTime", Nullable: true, Internal: true}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "is internal") {
+		t.Fatalf("expected internal input field to be rejected, got %v", err)
+	}
+}
+
+func TestHiddenArgsOmittedFromIntrospection(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Post struct {
+		ID    ID     `fraiseql:"id"`
+		Title string `fraiseql:"title"`
+	}
+	if err := RegisterTypes(Post{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := NewQuery("posts").
+		ReturnType("Post").
+		ReturnsArray(true).
+		Arg("limit", "Int", 20).
+		Arg("includeDeleted", "Boolean", false).
+		HideArg("includeDeleted").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewMutation("deletePost").
+		ReturnType("Post").
+		Arg("id", "ID", nil).
+		Arg("auditReason", "String", nil, true).
+		HideArg("auditReason").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	args := findQuery(schemaMap(t), "posts")["arguments"].([]interface{})
+	if len(args) != 2 || args[1].(map[string]interface{})["hidden"] != true {
+		t.Errorf("compiler JSON should keep includeDeleted marked hidden, got %v", args)
+	}
+	if _, has := args[0].(map[string]interface{})["hidden"]; has {
+		t.Errorf("visible argument should not export hidden, got %v", args[0])
+	}
+
+	argNames := func(field map[string]interface{}) []string {
+		var names []string
+		for _, a := range field["args"].([]interface{}) {
+			names = append(names, a.(map[string]interface{})["name"].(string))
+		}
+		return names
+	}
+	schema := introspectionMap(t)
+	posts := findIntrospectionField(findIntrospectionType(schema, "Query"), "posts")
+	if got := argNames(posts); len(got) != 1 || got[0] != "limit" {
+		t.Errorf("posts introspection args = %v, want only limit", got)
+	}
+	deletePost := findIntrospectionField(findIntrospectionType(schema, "Mutation"), "deletePost")
+	if got := argNames(deletePost); len(got) != 1 || got[0] != "id" {
+		t.Errorf("deletePost introspection args = %v, want only id", got)
+	}
+}
+
+func TestHideArgValidation(t *testing.T) {
+	t.Run("unknown argument", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := NewQuery("posts").ReturnType("Post").HideArg("missing").Register()
+		if err == nil || !strings.Contains(err.Error(), `HideArg: no argument named "missing"`) {
+			t.Errorf("expected error naming the missing argument, got %v", err)
+		}
+	})
+
+	t.Run("required argument", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		err := NewMutation("deletePost").ReturnType("Post").Arg("id", "ID", nil).HideArg("id").Register()
+		if err == nil || !strings.Contains(err.Error(), "is required and cannot be hidden") {
+			t.Errorf("expected error for hiding a required argument, got %v", err)
+		}
+		if len(GetSchema().Mutations) != 0 {
+			t.Error("invalid mutation should not be registered")
+		}
+	})
+}
+
+func TestIntrospectionRegisteredDirectives(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	maxAge := []ArgumentDefinition{{Name: "maxAge", Type: "Int", Nullable: true, Default: 60, Description: "Seconds"}}
+	if err := RegisterDirective("cached", []string{"OBJECT", "FIELD_DEFINITION"}, maxAge); err != nil {
+		t.Fatalf("RegisterDirective: %v", err)
+	}
+	if err := RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+
+	cached := findIntrospectionDirective(introspectionMap(t), "cached")
+	if cached == nil {
+		t.Fatal("expected the registered @cached directive")
+	}
+	if locations := fmt.Sprint(cached["locations"]); locations != "[OBJECT FIELD_DEFINITION]" {
+		t.Errorf("@cached locations = %s", locations)
+	}
+	args, _ := cached["args"].([]interface{})
+	if len(args) != 1 {
+		t.Fatalf("@cached args = %v, want maxAge", args)
+	}
+	arg := args[0].(map[string]interface{})
+	if arg["name"] != "maxAge" || arg["defaultValue"] != "60" || arg["description"] != "Seconds" {
+		t.Errorf("@cached maxAge = %v", arg)
+	}
+	if arg["type"].(map[string]interface{})["name"] != "Int" {
+		t.Errorf("@cached maxAge type = %v, want nullable Int", arg["type"])
+	}
+}
@@ -0,0 +1,64 @@
+package fraiseql
+
+import "fmt"
+
+// ipRangeFilter is a network containment filter added by IPInRange.
+type ipRangeFilter struct {
+	field string
+	arg   string
+}
+
+// ipAddressTypes are the scalars IPInRange can filter on.
+var ipAddressTypes = []string{"IPAddress", "IPv4", "IPv6"}
+
+// IPInRange restricts the query to rows whose IP address field lies within
+// the CIDR block passed in the named argument. The compiler casts both sides
+// to inet and generates a `field::inet << $arg::inet` WHERE clause.
+//
+// The argument is declared as a required CIDR unless it was already added
+// with Arg. Register checks that field is IPAddress-, IPv4- or IPv6-typed on
+// the return type.
+//
+// Example:
+//
+//	fraiseql.NewQuery("devicesInSubnet").
+//		ReturnType(Device{}).
+//		ReturnsArray(true).
+//		IPInRange("ipAddress", "subnet").
+//		Register()
+func (qb *QueryBuilder) IPInRange(field, cidrArg string) *QueryBuilder {
+	if qb.argIndex(cidrArg) < 0 {
+		qb.addArg(cidrArg, "CIDR", nil)
+	}
+	qb.ipRangeFilters = append(qb.ipRangeFilters, ipRangeFilter{field: field, arg: cidrArg})
+	return qb
+}
+
+// validateIPRangeFilters checks each IPInRange filter against the registered
+// return type and the query's arguments.
+func (qb *QueryBuilder) validateIPRangeFilters() error {
+	for _, f := range qb.ipRangeFilters {
+		if err := qb.requireReturnFieldType("IP range filters", f.field, ipAddressTypes...); err != nil {
+			return err
+		}
+		arg := qb.arguments[qb.argIndex(f.arg)]
+		if namedType(arg.Type) != "CIDR" {
+			return fmt.Errorf("query %q: argument %q must be of type CIDR, got %s", qb.name, f.arg, arg.Type)
+		}
+	}
+	return nil
+}
+
+// ipRangeFilterConfig returns the "ip_range_filters" config value in call order.
+func (qb *QueryBuilder) ipRangeFilterConfig() []map[string]interface{} {
+	filters := make([]map[string]interface{}, len(qb.ipRangeFilters))
+	for i, f := range qb.ipRangeFilters {
+		filters[i] = map[string]interface{}{
+			"field":    f.field,
+			"operator": "<<",
+			"cast":     "inet",
+			"arg":      f.arg,
+		}
+	}
+	return filters
+}
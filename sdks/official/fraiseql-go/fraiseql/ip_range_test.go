@@ -0,0 +1,114 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// networkDevice has one field of each IP address scalar.
+type networkDevice struct {
+	ID       ID        `fraiseql:"id"`
+	Hostname string    `fraiseql:"hostname"`
+	Address  IPAddress `fraiseql:"address,type=IPAddress"`
+	V4       IPv4      `fraiseql:"v4,type=IPv4"`
+	V6       IPv6      `fraiseql:"v6,type=IPv6,nullable=true"`
+}
+
+func TestIPInRangeConfig(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(networkDevice{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := NewQuery("devicesInSubnet").
+		ReturnType(networkDevice{}).
+		ReturnsArray(true).
+		IPInRange("address", "subnet").
+		IPInRange("v4", "v4Subnet").
+		IPInRange("v6", "v6Subnet").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	var exported struct {
+		Queries []struct {
+			Arguments []ArgumentDefinition `json:"arguments"`
+			Config    struct {
+				IPRangeFilters []map[string]string `json:"ip_range_filters"`
+			} `json:"config"`
+		} `json:"queries"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	q := exported.Queries[0]
+
+	filters := q.Config.IPRangeFilters
+	if len(filters) != 3 {
+		t.Fatalf("expected 3 ip range filters, got %v", filters)
+	}
+	want := map[string]string{"field": "address", "operator": "<<", "cast": "inet", "arg": "subnet"}
+	for k, v := range want {
+		if filters[0][k] != v {
+			t.Errorf("filter %s: want %q, got %q", k, v, filters[0][k])
+		}
+	}
+	if filters[2]["field"] != "v6" || filters[2]["arg"] != "v6Subnet" {
+		t.Errorf("unexpected IPv6 filter: %v", filters[2])
+	}
+
+	if len(q.Arguments) != 3 || q.Arguments[0].Type != "CIDR" || q.Arguments[0].Nullable {
+		t.Errorf("expected required CIDR arguments to be declared, got %+v", q.Arguments)
+	}
+}
+
+func TestIPInRangeValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func() *QueryBuilder
+		wantErr string
+	}{
+		{
+			name: "field is not an IP address",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(networkDevice{}).IPInRange("hostname", "subnet")
+			},
+			wantErr: `field "hostname" on type "networkDevice" is String, IP range filters require a field of type IPAddress, IPv4, IPv6`,
+		},
+		{
+			name: "argument is not CIDR",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(networkDevice{}).Arg("subnet", "String", nil).IPInRange("address", "subnet")
+			},
+			wantErr: `argument "subnet" must be of type CIDR`,
+		},
+		{
+			name: "return type not registered",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType("Unregistered").IPInRange("address", "subnet")
+			},
+			wantErr: "to be registered first",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+			if err := RegisterTypes(networkDevice{}); err != nil {
+				t.Fatalf("RegisterTypes: %v", err)
+			}
+
+			err := tt.build().Register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
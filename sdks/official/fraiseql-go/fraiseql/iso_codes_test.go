@@ -0,0 +1,78 @@
+package fraiseql
+
+import "testing"
+
+func TestISOCodeValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid func(string) bool
+		code  string
+		want  bool
+	}{
+		{"country US", IsValidCountryCode, "US", true},
+		{"country GB", IsValidCountryCode, "GB", true},
+		{"country JP", IsValidCountryCode, "JP", true},
+		{"country unassigned", IsValidCountryCode, "XX", false},
+		{"country lower case", IsValidCountryCode, "us", false},
+		{"country alpha-3", IsValidCountryCode, "USA", false},
+		{"country empty", IsValidCountryCode, "", false},
+		{"currency USD", IsValidCurrencyCode, "USD", true},
+		{"currency EUR", IsValidCurrencyCode, "EUR", true},
+		{"currency JPY", IsValidCurrencyCode, "JPY", true},
+		{"currency lower case", IsValidCurrencyCode, "usd", false},
+		{"currency unknown", IsValidCurrencyCode, "ZZZ", false},
+		{"currency withdrawn", IsValidCurrencyCode, "DEM", false},
+		{"language en", IsValidLanguageCode, "en", true},
+		{"language fr", IsValidLanguageCode, "fr", true},
+		{"language zh", IsValidLanguageCode, "zh", true},
+		{"language upper case", IsValidLanguageCode, "EN", false},
+		{"language name", IsValidLanguageCode, "english", false},
+		{"language unknown", IsValidLanguageCode, "qq", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.valid(tt.code); got != tt.want {
+				t.Errorf("validating %q = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestISOCodeSetSizes(t *testing.T) {
+	if n := len(countryCodeSet); n != 249 {
+		t.Errorf("country codes = %d, want 249", n)
+	}
+	if n := len(languageCodeSet); n != 183 {
+		t.Errorf("language codes = %d, want 183", n)
+	}
+}
+
+func TestGenerateCodeEnums(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	GenerateCountryCodeEnum()
+	GenerateCurrencyCodeEnum()
+	GenerateLanguageCodeEnum()
+
+	enums := getInstance().enums
+	for name, want := range map[string]int{
+		"CountryCode":  len(countryCodeSet),
+		"CurrencyCode": len(currencyCodeSet),
+		"LanguageCode": len(languageCodeSet),
+	} {
+		def, ok := enums[name]
+		if !ok {
+			t.Fatalf("enum %s not registered", name)
+		}
+		if len(def.Values) != want {
+			t.Errorf("enum %s has %d values, want %d", name, len(def.Values), want)
+		}
+	}
+	if first := enums["CountryCode"].Values[0].Name; first != "AD" {
+		t.Errorf("first CountryCode value = %q, want AD", first)
+	}
+	if first := enums["LanguageCode"].Values[0].Name; first != "AA" {
+		t.Errorf("first LanguageCode value = %q, want AA", first)
+	}
+}
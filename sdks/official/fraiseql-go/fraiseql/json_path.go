@@ -0,0 +1,34 @@
+package fraiseql
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// jsonPathPattern matches the JSON paths a field may be extracted from: "$"
+// followed by one or more ".key" or "[index]" steps.
+var jsonPathPattern = regexp.MustCompile(`^\$(\.[A-Za-z0-9_]+|\[[0-9]+\])+$`)
+
+// applyJSONPath fills in and checks the JSONB extraction of a parsed field. A
+// field with only a `jsonb=` column reads the top-level key of its own name.
+func applyJSONPath(info *FieldInfo, hasJSONPath bool) error {
+	if hasJSONPath && info.JsonPath == "" {
+		return fmt.Errorf("field %s: jsonPath must not be empty", info.Name)
+	}
+	if info.JsonbColumn == "" && info.JsonPath == "" {
+		return nil
+	}
+	if info.JsonbColumn == "" {
+		return fmt.Errorf("field %s: jsonPath %q requires a jsonb= column to extract from", info.Name, info.JsonPath)
+	}
+	if info.JsonPath == "" {
+		info.JsonPath = "$." + info.Name
+	}
+	if !jsonPathPattern.MatchString(info.JsonPath) {
+		return fmt.Errorf(
+			"field %s: invalid jsonPath %q; use $ followed by .key or [index] steps, e.g. $.profile.name",
+			info.Name, info.JsonPath,
+		)
+	}
+	return nil
+}
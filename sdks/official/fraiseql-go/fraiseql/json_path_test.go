@@ -0,0 +1,78 @@
+package fraiseql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSONPathFields(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Customer struct {
+		ID      ID     `fraiseql:"id"`
+		Email   string `fraiseql:"email,jsonb=data"`
+		Name    string `fraiseql:"name,jsonb=data,jsonPath=$.profile.name"`
+		Primary string `fraiseql:"primaryPhone,jsonb=data,jsonPath=$.phones[0].number"`
+	}
+	if err := RegisterTypes(Customer{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	for _, want := range []string{
+		`{"name":"email","type":"String","nullable":false,"jsonb_column":"data","json_path":"$.email"}`,
+		`{"name":"name","type":"String","nullable":false,"jsonb_column":"data","json_path":"$.profile.name"}`,
+		`"json_path":"$.phones[0].number"`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("missing %s in %s", want, data)
+		}
+	}
+	if id := GetSchema().Types[0].Fields[0]; id.JsonbColumn != "" || id.JsonPath != "" {
+		t.Errorf("id should not be a JSONB field, got %+v", id)
+	}
+}
+
+func TestJSONPathValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		wantErr string
+	}{
+		{
+			name: "empty path",
+			value: struct {
+				Name string `fraiseql:"name,jsonb=data,jsonPath="`
+			}{},
+			wantErr: "jsonPath must not be empty",
+		},
+		{
+			name: "path without a column",
+			value: struct {
+				Name string `fraiseql:"name,jsonPath=$.profile.name"`
+			}{},
+			wantErr: "requires a jsonb= column",
+		},
+		{
+			name: "malformed path",
+			value: struct {
+				Name string `fraiseql:"name,jsonb=data,jsonPath=profile..name"`
+			}{},
+			wantErr: `invalid jsonPath "profile..name"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExtractFields(reflect.TypeOf(tt.value))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
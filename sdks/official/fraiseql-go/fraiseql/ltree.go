@@ -0,0 +1,102 @@
+package fraiseql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ltreeFilter is a hierarchy filter added by DescendantsOf or AncestorsOf.
+type ltreeFilter struct {
+	field    string
+	arg      string
+	operator string
+}
+
+// ltreeLabelPattern matches a single ltree label.
+var ltreeLabelPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// isValidLTreePath reports whether path is a well-formed ltree path: one or
+// more labels of letters, digits, underscores or hyphens separated by dots.
+func isValidLTreePath(path string) bool {
+	if path == "" {
+		return false
+	}
+	for _, label := range strings.Split(path, ".") {
+		if !ltreeLabelPattern.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// DescendantsOf restricts the query to rows whose LTree field is a descendant
+// of (or equal to) the path passed in the named argument. The compiler
+// generates a `field <@ $arg` WHERE clause.
+//
+// The argument is declared as a required LTree unless it was already added
+// with Arg. Register checks that field is LTree-typed on the return type.
+//
+// Example:
+//
+//	fraiseql.NewQuery("subcategories").
+//		ReturnType(Category{}).
+//		ReturnsArray(true).
+//		DescendantsOf("path", "ancestor").
+//		Register()
+func (qb *QueryBuilder) DescendantsOf(field, arg string) *QueryBuilder {
+	return qb.addLTreeFilter(field, arg, "<@")
+}
+
+// AncestorsOf restricts the query to rows whose LTree field is an ancestor of
+// (or equal to) the path passed in the named argument. The compiler generates
+// a `field @> $arg` WHERE clause. See DescendantsOf.
+func (qb *QueryBuilder) AncestorsOf(field, arg string) *QueryBuilder {
+	return qb.addLTreeFilter(field, arg, "@>")
+}
+
+func (qb *QueryBuilder) addLTreeFilter(field, arg, operator string) *QueryBuilder {
+	if qb.argIndex(arg) < 0 {
+		qb.addArg(arg, "LTree", nil)
+	}
+	qb.ltreeFilters = append(qb.ltreeFilters, ltreeFilter{field: field, arg: arg, operator: operator})
+	return qb
+}
+
+// validateLTreeFilters checks each hierarchy filter against the registered
+// return type and the query's arguments.
+func (qb *QueryBuilder) validateLTreeFilters() error {
+	if len(qb.ltreeFilters) == 0 {
+		return nil
+	}
+
+	for _, f := range qb.ltreeFilters {
+		if err := qb.requireReturnFieldType("hierarchy filters", f.field, "LTree"); err != nil {
+			return err
+		}
+
+		arg := qb.arguments[qb.argIndex(f.arg)]
+		if namedType(arg.Type) != "LTree" {
+			return fmt.Errorf("query %q: argument %q must be of type LTree, got %s", qb.name, f.arg, arg.Type)
+		}
+		if arg.IsDefault {
+			if path, ok := arg.Default.(string); !ok || !isValidLTreePath(path) {
+				return fmt.Errorf("query %q: argument %q has invalid ltree path default %#v", qb.name, f.arg, arg.Default)
+			}
+		}
+	}
+	return nil
+}
+
+// ltreeFilterConfig returns the "ltree_filters" config value in call order.
+func (qb *QueryBuilder) ltreeFilterConfig() []map[string]interface{} {
+	filters := make([]map[string]interface{}, len(qb.ltreeFilters))
+	for i, f := range qb.ltreeFilters {
+		filters[i] = map[string]interface{}{
+			"field":    f.field,
+			"operator": f.operator,
+			"arg":      f.arg,
+		}
+	}
+	return filters
+}
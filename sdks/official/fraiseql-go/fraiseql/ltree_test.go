@@ -0,0 +1,160 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// treeCategory is a category in an ltree-backed hierarchy.
+type treeCategory struct {
+	ID   ID     `fraiseql:"id"`
+	Name string `fraiseql:"name"`
+	Path LTree  `fraiseql:"path,type=LTree"`
+}
+
+func TestLTreeHierarchyFilters(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(treeCategory{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := NewQuery("categoryTree").
+		ReturnType(treeCategory{}).
+		ReturnsArray(true).
+		DescendantsOf("path", "under").
+		AncestorsOf("path", "above").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	var exported struct {
+		Queries []struct {
+			Arguments []ArgumentDefinition `json:"arguments"`
+			Config    struct {
+				LTreeFilters []map[string]string `json:"ltree_filters"`
+			} `json:"config"`
+		} `json:"queries"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	q := exported.Queries[0]
+
+	filters := q.Config.LTreeFilters
+	if len(filters) != 2 {
+		t.Fatalf("expected 2 ltree filters, got %v", filters)
+	}
+	if filters[0]["field"] != "path" || filters[0]["operator"] != "<@" || filters[0]["arg"] != "under" {
+		t.Errorf("unexpected DescendantsOf filter: %v", filters[0])
+	}
+	if filters[1]["operator"] != "@>" || filters[1]["arg"] != "above" {
+		t.Errorf("unexpected AncestorsOf filter: %v", filters[1])
+	}
+
+	if len(q.Arguments) != 2 || q.Arguments[0].Type != "LTree" || q.Arguments[0].Nullable {
+		t.Errorf("expected required LTree arguments to be declared, got %+v", q.Arguments)
+	}
+}
+
+func TestLTreeFilterReusesDeclaredArg(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(treeCategory{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := NewQuery("subcategories").
+		ReturnType(treeCategory{}).
+		ReturnsArray(true).
+		Arg("root", "LTree", "catalog.electronics").
+		DescendantsOf("path", "root").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	args := GetSchema().Queries[0].Arguments
+	if len(args) != 1 || args[0].Default != "catalog.electronics" {
+		t.Errorf("expected the declared argument to be kept as-is, got %+v", args)
+	}
+}
+
+func TestLTreeFilterValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func() *QueryBuilder
+		wantErr string
+	}{
+		{
+			name: "field is not LTree",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(treeCategory{}).DescendantsOf("name", "under")
+			},
+			wantErr: `field "name" on type "treeCategory" is String`,
+		},
+		{
+			name: "unknown field",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(treeCategory{}).AncestorsOf("parent", "above")
+			},
+			wantErr: `has no field "parent"`,
+		},
+		{
+			name: "return type not registered",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType("Unregistered").DescendantsOf("path", "under")
+			},
+			wantErr: "to be registered first",
+		},
+		{
+			name: "argument is not LTree",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(treeCategory{}).Arg("under", "String", nil).DescendantsOf("path", "under")
+			},
+			wantErr: `argument "under" must be of type LTree`,
+		},
+		{
+			name: "invalid default path",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(treeCategory{}).Arg("under", "LTree", "catalog..bad").DescendantsOf("path", "under")
+			},
+			wantErr: "invalid ltree path default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+			if err := RegisterTypes(treeCategory{}); err != nil {
+				t.Fatalf("RegisterTypes: %v", err)
+			}
+
+			err := tt.build().Register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestIsValidLTreePath(t *testing.T) {
+	for path, want := range map[string]bool{
+		"root":                true,
+		"catalog.electronics": true,
+		"org.team_a.sub-team": true,
+		"":                    false,
+		"catalog.":            false,
+		"catalog..phones":     false,
+		"has space":           false,
+	} {
+		if got := isValidLTreePath(path); got != want {
+			t.Errorf("isValidLTreePath(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -1,11 +1,19 @@
 package fraiseql
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
 
 // ObserverAction represents a single action to execute when an observer fires.
 type ObserverAction struct {
 	Type   string                 `json:"type"`
 	Config map[string]interface{} `json:"config"`
+
+	// template names the action template this action refers to (see ActionRef).
+	// It is resolved when the observer is registered and never exported.
+	template string
 }
 
 // RetryConfig controls retry behaviour for observer actions.
@@ -18,12 +26,23 @@ type RetryConfig struct {
 
 // ObserverDefinition represents a database event observer.
 type ObserverDefinition struct {
-	Name      string           `json:"name"`
-	Entity    string           `json:"entity"`
-	Event     string           `json:"event"`
-	Condition string           `json:"condition,omitempty"`
-	Actions   []ObserverAction `json:"actions"`
-	Retry     *RetryConfig     `json:"retry,omitempty"`
+	Name       string           `json:"name"`
+	Entity     string           `json:"entity"`
+	Event      string           `json:"event"`
+	Condition  string           `json:"condition,omitempty"`
+	Actions    []ObserverAction `json:"actions"`
+	Retry      *RetryConfig     `json:"retry,omitempty"`
+	DebounceMs int              `json:"debounce_ms,omitempty"`
+	ThrottleMs int              `json:"throttle_ms,omitempty"`
+	// PayloadFields lists the entity fields the runtime includes in action
+	// payloads; empty means the whole row.
+	PayloadFields []string `json:"payload_fields,omitempty"`
+	// Priority orders observers that fire on the same entity and event:
+	// higher priorities run first. The default is 0.
+	Priority int `json:"priority,omitempty"`
+	// ChangedColumns restricts an UPDATE observer to updates that change at
+	// least one of these entity fields.
+	ChangedColumns []string `json:"changed_columns,omitempty"`
 }
 
 // ObserverBuilder provides a fluent interface for building observer definitions.
@@ -34,6 +53,11 @@ type ObserverBuilder struct {
 	condition string
 	actions   []ObserverAction
 	retry     *RetryConfig
+	debounce  *int
+	throttle  *int
+	fields    []string
+	priority  int
+	changed   []string
 }
 
 // NewObserver creates a new observer builder with the given name.
@@ -80,27 +104,316 @@ func (b *ObserverBuilder) Retry(cfg RetryConfig) *ObserverBuilder {
 	return b
 }
 
+// Debounce delays firing until the entity has seen no further events for ms
+// milliseconds, so a burst of UPDATEs fires the observer once.
+// It cannot be combined with Throttle.
+func (b *ObserverBuilder) Debounce(ms int) *ObserverBuilder {
+	b.debounce = &ms
+	return b
+}
+
+// Throttle fires the observer at most once per window of ms milliseconds.
+// It cannot be combined with Debounce.
+func (b *ObserverBuilder) Throttle(ms int) *ObserverBuilder {
+	b.throttle = &ms
+	return b
+}
+
+// Fields restricts the payload sent by the observer's actions to the named
+// fields of the entity, so a webhook to a third party receives only what it
+// needs instead of the whole row. The entity type must be registered before
+// the observer, and each name must be one of its fields.
+func (b *ObserverBuilder) Fields(fields ...string) *ObserverBuilder {
+	b.fields = append(b.fields, fields...)
+	return b
+}
+
+// Priority sets the observer's place among the observers that fire on the
+// same entity and event: the runtime runs higher priorities first, so a
+// validation observer at 100 runs before a notification observer at the
+// default 0, and a negative priority runs after the default. Observers with
+// equal priorities run in name order. See SortObserversByPriority.
+func (b *ObserverBuilder) Priority(priority int) *ObserverBuilder {
+	b.priority = priority
+	return b
+}
+
+// OnColumnsChanged makes an UPDATE observer fire only when the update
+// changes at least one of the named fields of the entity, e.g. only when an
+// order's status or total changes rather than on every touch of the row. The
+// runtime compares the old and new row before firing, which is more precise
+// than a `status.changed()` Condition. The entity type must be registered
+// before the observer, and each name must be one of its stored fields.
+func (b *ObserverBuilder) OnColumnsChanged(columns ...string) *ObserverBuilder {
+	b.changed = append(b.changed, columns...)
+	return b
+}
+
 // Register registers the observer with the global schema registry.
-// Returns an error if an observer with the same name is already registered.
-func (b *ObserverBuilder) Register() error {
+// Returns an error if an observer with the same name is already registered,
+// if its debounce/throttle settings are invalid, or if its payload fields or
+// changed columns are not fields of the entity type.
+func (b *ObserverBuilder) Register() (err error) {
+	defer notifyIfRegistered(&err, "observer", b.name)
 	reg := getInstance()
 	reg.mu.Lock()
 	defer reg.mu.Unlock()
 
+	def, err := reg.buildObserver(b)
+	if err != nil {
+		return err
+	}
+	reg.observers[b.name] = def
+	return nil
+}
+
+// buildObserver checks the observer name is free and resolves its action
+// templates. Callers must hold reg.mu.
+func (reg *SchemaRegistry) buildObserver(b *ObserverBuilder) (ObserverDefinition, error) {
 	if _, exists := reg.observers[b.name]; exists {
-		return fmt.Errorf("observer %q is already registered; each name must be unique within a schema", b.name)
+		return ObserverDefinition{}, fmt.Errorf("observer %q is already registered; each name must be unique within a schema", b.name)
+	}
+	if b.debounce != nil && b.throttle != nil {
+		return ObserverDefinition{}, fmt.Errorf("observer %q: Debounce and Throttle are mutually exclusive; set only one", b.name)
+	}
+	if b.debounce != nil && *b.debounce <= 0 {
+		return ObserverDefinition{}, fmt.Errorf("observer %q: debounce must be a positive number of milliseconds, got %d", b.name, *b.debounce)
+	}
+	if b.throttle != nil && *b.throttle <= 0 {
+		return ObserverDefinition{}, fmt.Errorf("observer %q: throttle must be a positive number of milliseconds, got %d", b.name, *b.throttle)
+	}
+
+	if err := reg.validatePayloadFields(b); err != nil {
+		return ObserverDefinition{}, err
 	}
-	reg.observers[b.name] = ObserverDefinition{
+	if err := reg.validateChangedColumns(b); err != nil {
+		return ObserverDefinition{}, err
+	}
+
+	actions := make([]ObserverAction, len(b.actions))
+	for i, action := range b.actions {
+		resolved, err := reg.resolveAction(action)
+		if err != nil {
+			return ObserverDefinition{}, fmt.Errorf("observer %q: %w", b.name, err)
+		}
+		actions[i] = resolved
+	}
+
+	def := ObserverDefinition{
 		Name:      b.name,
 		Entity:    b.entity,
 		Event:     b.event,
 		Condition: b.condition,
-		Actions:   b.actions,
+		Actions:   actions,
 		Retry:     b.retry,
+		Priority:  b.priority,
+	}
+	if len(b.fields) > 0 {
+		def.PayloadFields = append([]string(nil), b.fields...)
+	}
+	if len(b.changed) > 0 {
+		def.ChangedColumns = append([]string(nil), b.changed...)
+	}
+	if b.debounce != nil {
+		def.DebounceMs = *b.debounce
+	}
+	if b.throttle != nil {
+		def.ThrottleMs = *b.throttle
+	}
+	return def, nil
+}
+
+// validatePayloadFields checks the observer's payload fields against its
+// entity type. Callers must hold reg.mu.
+func (reg *SchemaRegistry) validatePayloadFields(b *ObserverBuilder) error {
+	_, err := reg.entityFields(b, "Fields", "payload field", b.fields)
+	return err
+}
+
+// validateChangedColumns checks the observer's OnColumnsChanged columns: the
+// observer must watch UPDATE, and each column must be a stored field of its
+// entity type. Callers must hold reg.mu.
+func (reg *SchemaRegistry) validateChangedColumns(b *ObserverBuilder) error {
+	if len(b.changed) == 0 {
+		return nil
+	}
+	if !strings.EqualFold(b.event, "UPDATE") {
+		return fmt.Errorf(
+			"observer %q: OnColumnsChanged only applies to UPDATE events, but the observer watches %q", b.name, b.event,
+		)
+	}
+	fields, err := reg.entityFields(b, "OnColumnsChanged", "changed column", b.changed)
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if f.Computed {
+			return fmt.Errorf("observer %q: changed column %q is computed, so no update changes it", b.name, f.Name)
+		}
 	}
 	return nil
 }
 
+// entityFields returns the fields of the observer's entity type that names
+// refer to, in order. method and what name the builder method and the kind
+// of name for errors. Callers must hold reg.mu.
+func (reg *SchemaRegistry) entityFields(b *ObserverBuilder, method, what string, names []string) ([]FieldInfo, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	entity, exists := reg.types[b.entity]
+	if !exists {
+		return nil, fmt.Errorf(
+			"observer %q: %s requires entity type %q to be registered before the observer", b.name, method, b.entity,
+		)
+	}
+	known := make(map[string]FieldInfo, len(entity.Fields))
+	for _, f := range entity.Fields {
+		known[f.Name] = f
+	}
+	fields := make([]FieldInfo, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		f, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("observer %q: %s %q is not a field of type %q", b.name, what, name, b.entity)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("observer %q: %s %q is listed more than once", b.name, what, name)
+		}
+		seen[name] = true
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// ObserverGroupBuilder registers several observers that share an entity and
+// retry policy.
+type ObserverGroupBuilder struct {
+	entity  string
+	retry   *RetryConfig
+	members []*ObserverBuilder
+}
+
+// ObserverGroup starts a group of observers on the given entity. Members added
+// to the group inherit its entity and retry policy unless they set their own.
+//
+// Example:
+//
+//	fraiseql.ObserverGroup("Order").
+//	    Retry(fraiseql.RetryConfig{MaxAttempts: 3, BackoffStrategy: "exponential"}).
+//	    Add("onOrderCreated", "INSERT", fraiseql.Slack("#orders", "New order {id}")).
+//	    Add("onOrderCancelled", "UPDATE", fraiseql.Slack("#orders", "Order {id} cancelled")).
+//	    Register()
+func ObserverGroup(entity string) *ObserverGroupBuilder {
+	return &ObserverGroupBuilder{entity: entity}
+}
+
+// Retry sets the retry configuration shared by the group's members.
+func (g *ObserverGroupBuilder) Retry(cfg RetryConfig) *ObserverGroupBuilder {
+	g.retry = &cfg
+	return g
+}
+
+// Add adds an observer on the group's entity for the given event.
+func (g *ObserverGroupBuilder) Add(name, event string, actions ...ObserverAction) *ObserverGroupBuilder {
+	return g.AddObserver(NewObserver(name).Event(event).Actions(actions...))
+}
+
+// AddObserver adds a fully configured observer to the group. The group's
+// entity and retry policy fill in only what the observer leaves unset, so a
+// member can override either (e.g. with its own Retry).
+func (g *ObserverGroupBuilder) AddObserver(b *ObserverBuilder) *ObserverGroupBuilder {
+	g.members = append(g.members, b)
+	return g
+}
+
+// Register registers every observer in the group with the global schema
+// registry. Registration is all-or-nothing: if any member fails (for example
+// a duplicate name or an unknown action template), none are registered.
+func (g *ObserverGroupBuilder) Register() (err error) {
+	defer func() {
+		for _, member := range g.members {
+			notifyIfRegistered(&err, "observer", member.name)
+		}
+	}()
+	reg := getInstance()
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	defs := make([]ObserverDefinition, 0, len(g.members))
+	seen := make(map[string]bool, len(g.members))
+	for _, member := range g.members {
+		if seen[member.name] {
+			return fmt.Errorf("observer %q is added to the group more than once", member.name)
+		}
+		seen[member.name] = true
+
+		b := *member
+		if b.entity == "" {
+			b.entity = g.entity
+		}
+		if b.retry == nil {
+			b.retry = g.retry
+		}
+		def, err := reg.buildObserver(&b)
+		if err != nil {
+			return err
+		}
+		defs = append(defs, def)
+	}
+
+	for _, def := range defs {
+		reg.observers[def.Name] = def
+	}
+	return nil
+}
+
+// RegisterActionTemplate registers a reusable observer action under a name.
+// Observers reference it with ActionRef, overriding only the config keys that
+// differ (e.g. the message), so shared webhook URLs and channels live in one place.
+// Returns an error if a template with the same name is already registered.
+func RegisterActionTemplate(name string, action ObserverAction) (err error) {
+	defer notifyIfRegistered(&err, "action template", name)
+	reg := getInstance()
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	if _, exists := reg.actionTemplates[name]; exists {
+		return fmt.Errorf("action template %q is already registered; each name must be unique within a schema", name)
+	}
+	reg.actionTemplates[name] = action
+	return nil
+}
+
+// ActionRef creates an observer action that refers to a registered action template.
+// The overrides are merged on top of the template's config. The reference is
+// resolved when the observer is registered, so the exported schema contains the
+// fully inlined action; the template must be registered before the observer.
+func ActionRef(name string, overrides map[string]interface{}) ObserverAction {
+	return ObserverAction{Config: overrides, template: name}
+}
+
+// resolveAction inlines a template reference. Callers must hold reg.mu.
+func (reg *SchemaRegistry) resolveAction(action ObserverAction) (ObserverAction, error) {
+	if action.template == "" {
+		return action, nil
+	}
+	tmpl, exists := reg.actionTemplates[action.template]
+	if !exists {
+		return ObserverAction{}, fmt.Errorf("action template %q is not registered", action.template)
+	}
+
+	cfg := make(map[string]interface{}, len(tmpl.Config)+len(action.Config))
+	for k, v := range tmpl.Config {
+		cfg[k] = v
+	}
+	for k, v := range action.Config {
+		cfg[k] = v
+	}
+	return ObserverAction{Type: tmpl.Type, Config: cfg}, nil
+}
+
 // Webhook creates a webhook observer action.
 // The first argument is the URL. An optional second argument provides extra
 // configuration (headers, body_template, etc.).
@@ -153,3 +466,23 @@ func EmailAction(to, subject, body string, opts ...map[string]interface{}) Obser
 	}
 	return ObserverAction{Type: "email", Config: cfg}
 }
+
+// SortObserversByPriority returns observers in the order the runtime runs
+// them when they fire on the same entity and event: by descending Priority,
+// then by name. The input is not modified.
+//
+// Example:
+//
+//	for _, o := range fraiseql.SortObserversByPriority(fraiseql.GetSchema().Observers) {
+//		fmt.Println(o.Priority, o.Name)
+//	}
+func SortObserversByPriority(observers []ObserverDefinition) []ObserverDefinition {
+	sorted := append([]ObserverDefinition(nil), observers...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority > sorted[j].Priority
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
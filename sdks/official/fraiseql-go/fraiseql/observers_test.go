@@ -0,0 +1,457 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegisterActionTemplate(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterActionTemplate("salesSlack", Slack("#sales", "default message")); err != nil {
+		t.Fatalf("RegisterActionTemplate: %v", err)
+	}
+	err := RegisterActionTemplate("salesSlack", Slack("#other", "x"))
+	if err == nil {
+		t.Fatal("expected error for duplicate action template")
+	}
+	if !strings.Contains(err.Error(), "already registered") {
+		t.Errorf("error should mention 'already registered', got: %v", err)
+	}
+}
+
+func TestActionRefResolvesTemplate(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterActionTemplate("salesSlack", Slack("#sales", "default message")); err != nil {
+		t.Fatalf("RegisterActionTemplate: %v", err)
+	}
+
+	if err := NewObserver("onHighValueOrder").
+		Entity("Order").
+		Event("INSERT").
+		Action(ActionRef("salesSlack", map[string]interface{}{"message": "High-value order {id}"})).
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewObserver("onOrderCancelled").
+		Entity("Order").
+		Event("UPDATE").
+		Action(ActionRef("salesSlack", nil)).
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	obs := GetSchema().Observers
+	if len(obs) != 2 {
+		t.Fatalf("expected 2 observers, got %d", len(obs))
+	}
+
+	// Sorted by name: onHighValueOrder, onOrderCancelled.
+	overridden := obs[0].Actions[0]
+	if overridden.Type != "slack" {
+		t.Errorf("type: want slack, got %q", overridden.Type)
+	}
+	if overridden.Config["channel"] != "#sales" {
+		t.Errorf("channel should come from template, got %v", overridden.Config["channel"])
+	}
+	if overridden.Config["message"] != "High-value order {id}" {
+		t.Errorf("message should be overridden, got %v", overridden.Config["message"])
+	}
+
+	inherited := obs[1].Actions[0]
+	if inherited.Config["message"] != "default message" {
+		t.Errorf("message should come from template, got %v", inherited.Config["message"])
+	}
+
+	// Overrides must not leak back into the template.
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	if strings.Count(string(data), "default message") != 1 {
+		t.Errorf("expected template message exactly once in export, got %s", data)
+	}
+}
+
+func TestActionRefUnknownTemplate(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	err := NewObserver("onOrder").
+		Entity("Order").
+		Event("INSERT").
+		Action(ActionRef("missing", nil)).
+		Register()
+	if err == nil {
+		t.Fatal("expected error for unknown action template")
+	}
+	if !strings.Contains(err.Error(), `"missing"`) {
+		t.Errorf("error should name the template, got: %v", err)
+	}
+	if len(GetSchema().Observers) != 0 {
+		t.Error("observer with unresolved template should not be registered")
+	}
+}
+
+func TestObserverGroupDefaults(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	groupRetry := RetryConfig{MaxAttempts: 3, BackoffStrategy: "exponential", InitialDelayMs: 100, MaxDelayMs: 5000}
+	memberRetry := RetryConfig{MaxAttempts: 10, BackoffStrategy: "linear"}
+
+	err := ObserverGroup("Order").
+		Retry(groupRetry).
+		Add("onOrderCreated", "INSERT", Slack("#orders", "New order {id}")).
+		Add("onOrderDeleted", "DELETE", Webhook("https://example.com/deleted")).
+		AddObserver(NewObserver("onOrderPaid").
+			Event("UPDATE").
+			Condition("status = 'paid'").
+			Retry(memberRetry).
+			Action(Slack("#finance", "Order {id} paid"))).
+		AddObserver(NewObserver("onRefundIssued").
+			Entity("Refund").
+			Event("INSERT").
+			Action(Slack("#finance", "Refund {id}"))).
+		Register()
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	obs := make(map[string]ObserverDefinition)
+	for _, o := range GetSchema().Observers {
+		obs[o.Name] = o
+	}
+	if len(obs) != 4 {
+		t.Fatalf("expected 4 observers, got %d", len(obs))
+	}
+
+	for _, name := range []string{"onOrderCreated", "onOrderDeleted"} {
+		o := obs[name]
+		if o.Entity != "Order" {
+			t.Errorf("%s: entity should default to Order, got %q", name, o.Entity)
+		}
+		if o.Retry == nil || *o.Retry != groupRetry {
+			t.Errorf("%s: retry should default to the group policy, got %+v", name, o.Retry)
+		}
+	}
+	if obs["onOrderCreated"].Event != "INSERT" || obs["onOrderDeleted"].Event != "DELETE" {
+		t.Error("member events should be preserved")
+	}
+
+	paid := obs["onOrderPaid"]
+	if paid.Retry == nil || *paid.Retry != memberRetry {
+		t.Errorf("member retry should win over the group, got %+v", paid.Retry)
+	}
+	if paid.Entity != "Order" || paid.Condition != "status = 'paid'" {
+		t.Errorf("unexpected onOrderPaid definition: %+v", paid)
+	}
+
+	refund := obs["onRefundIssued"]
+	if refund.Entity != "Refund" {
+		t.Errorf("member entity should win over the group, got %q", refund.Entity)
+	}
+	if refund.Retry == nil || *refund.Retry != groupRetry {
+		t.Errorf("onRefundIssued should still inherit the group retry, got %+v", refund.Retry)
+	}
+}
+
+func TestObserverGroupIsAllOrNothing(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewObserver("onOrderCancelled").Entity("Order").Event("UPDATE").Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	err := ObserverGroup("Order").
+		Add("onOrderCreated", "INSERT", Slack("#orders", "New order")).
+		Add("onOrderCancelled", "UPDATE", Slack("#orders", "Cancelled")).
+		Register()
+	if err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Fatalf("expected duplicate error, got %v", err)
+	}
+	if n := len(GetSchema().Observers); n != 1 {
+		t.Errorf("failed group should register nothing, got %d observers", n)
+	}
+
+	err = ObserverGroup("Order").
+		Add("onOrderShipped", "UPDATE").
+		Add("onOrderShipped", "UPDATE").
+		Register()
+	if err == nil || !strings.Contains(err.Error(), "more than once") {
+		t.Errorf("expected in-group duplicate error, got %v", err)
+	}
+}
+
+func TestObserverDebounceAndThrottle(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewObserver("onInventoryChanged").
+		Entity("Product").
+		Event("UPDATE").
+		Debounce(500).
+		Action(Webhook("https://example.com/inventory")).
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewObserver("onPriceChanged").
+		Entity("Product").
+		Event("UPDATE").
+		Throttle(60000).
+		Action(Slack("#pricing", "Price of {id} changed")).
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	if !strings.Contains(string(data), `"debounce_ms":500`) {
+		t.Errorf("expected debounce_ms in export, got %s", data)
+	}
+	if !strings.Contains(string(data), `"throttle_ms":60000`) {
+		t.Errorf("expected throttle_ms in export, got %s", data)
+	}
+
+	obs := GetSchema().Observers
+	if obs[0].Name != "onInventoryChanged" || obs[0].ThrottleMs != 0 {
+		t.Errorf("debounced observer should not carry a throttle, got %+v", obs[0])
+	}
+}
+
+func TestObserverRateControlValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *ObserverBuilder
+		wantErr string
+	}{
+		{
+			name:    "both set",
+			builder: NewObserver("onOrder").Entity("Order").Event("UPDATE").Debounce(100).Throttle(1000),
+			wantErr: "mutually exclusive",
+		},
+		{
+			name:    "zero debounce",
+			builder: NewObserver("onOrder").Entity("Order").Event("UPDATE").Debounce(0),
+			wantErr: "debounce must be a positive",
+		},
+		{
+			name:    "negative throttle",
+			builder: NewObserver("onOrder").Entity("Order").Event("UPDATE").Throttle(-5),
+			wantErr: "throttle must be a positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := tt.builder.Register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if len(GetSchema().Observers) != 0 {
+				t.Error("invalid observer should not be registered")
+			}
+		})
+	}
+}
+
+func TestObserverPayloadFields(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterType("Order", []FieldInfo{
+		{Name: "id", Type: "ID"},
+		{Name: "total", Type: "Decimal"},
+		{Name: "customer_email", Type: "Email"},
+		{Name: "card_last4", Type: "String"},
+	}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := NewObserver("onOrderCreated").
+		Entity("Order").
+		Event("INSERT").
+		Fields("id", "total", "customer_email").
+		Action(Webhook("https://partner.example.com/orders")).
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	if !strings.Contains(string(data), `"payload_fields":["id","total","customer_email"]`) {
+		t.Errorf("expected payload_fields in export, got %s", data)
+	}
+}
+
+func TestObserverPayloadFieldsValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *ObserverBuilder
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			builder: NewObserver("onOrder").Entity("Order").Event("INSERT").Fields("id", "secret"),
+			wantErr: `payload field "secret" is not a field of type "Order"`,
+		},
+		{
+			name:    "duplicate field",
+			builder: NewObserver("onOrder").Entity("Order").Event("INSERT").Fields("id", "id"),
+			wantErr: `payload field "id" is listed more than once`,
+		},
+		{
+			name:    "unregistered entity",
+			builder: NewObserver("onInvoice").Entity("Invoice").Event("INSERT").Fields("id"),
+			wantErr: `requires entity type "Invoice" to be registered`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			if err := RegisterType("Order", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+				t.Fatalf("RegisterType: %v", err)
+			}
+			err := tt.builder.Register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if len(GetSchema().Observers) != 0 {
+				t.Error("invalid observer should not be registered")
+			}
+		})
+	}
+}
+
+func TestObserverPriority(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	for _, b := range []*ObserverBuilder{
+		NewObserver("notifyOrder").Entity("Order").Event("INSERT"),
+		NewObserver("validateOrder").Entity("Order").Event("INSERT").Priority(100),
+		NewObserver("auditOrder").Entity("Order").Event("INSERT").Priority(-10),
+		NewObserver("reserveStock").Entity("Order").Event("INSERT").Priority(100),
+	} {
+		if err := b.Register(); err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	for _, want := range []string{`"name":"validateOrder"`, `"priority":100`, `"priority":-10`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in export, got %s", want, data)
+		}
+	}
+	if strings.Count(string(data), `"priority"`) != 3 {
+		t.Errorf("the default priority should be omitted, got %s", data)
+	}
+
+	var order []string
+	for _, o := range SortObserversByPriority(GetSchema().Observers) {
+		order = append(order, o.Name)
+	}
+	want := "reserveStock validateOrder notifyOrder auditOrder"
+	if got := strings.Join(order, " "); got != want {
+		t.Errorf("SortObserversByPriority order = %s, want %s", got, want)
+	}
+}
+
+func TestObserverOnColumnsChanged(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterType("Order", []FieldInfo{
+		{Name: "id", Type: "ID"},
+		{Name: "status", Type: "String"},
+		{Name: "total", Type: "Decimal"},
+	}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := NewObserver("onOrderStatusChanged").
+		Entity("Order").
+		Event("UPDATE").
+		OnColumnsChanged("status", "total").
+		Action(Webhook("https://example.com/orders")).
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	if !strings.Contains(string(data), `"changed_columns":["status","total"]`) {
+		t.Errorf("expected changed_columns in export, got %s", data)
+	}
+}
+
+func TestObserverOnColumnsChangedValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *ObserverBuilder
+		wantErr string
+	}{
+		{
+			name:    "unknown column",
+			builder: NewObserver("onOrder").Entity("Order").Event("UPDATE").OnColumnsChanged("status", "colour"),
+			wantErr: `observer "onOrder": changed column "colour" is not a field of type "Order"`,
+		},
+		{
+			name:    "duplicate column",
+			builder: NewObserver("onOrder").Entity("Order").Event("UPDATE").OnColumnsChanged("status", "status"),
+			wantErr: `changed column "status" is listed more than once`,
+		},
+		{
+			name:    "computed column",
+			builder: NewObserver("onOrder").Entity("Order").Event("UPDATE").OnColumnsChanged("itemCount"),
+			wantErr: `changed column "itemCount" is computed`,
+		},
+		{
+			name:    "insert event",
+			builder: NewObserver("onOrder").Entity("Order").Event("INSERT").OnColumnsChanged("status"),
+			wantErr: `OnColumnsChanged only applies to UPDATE events, but the observer watches "INSERT"`,
+		},
+		{
+			name:    "unregistered entity",
+			builder: NewObserver("onInvoice").Entity("Invoice").Event("UPDATE").OnColumnsChanged("status"),
+			wantErr: `OnColumnsChanged requires entity type "Invoice" to be registered`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			if err := RegisterType("Order", []FieldInfo{
+				{Name: "id", Type: "ID"},
+				{Name: "status", Type: "String"},
+				{Name: "itemCount", Type: "Int", Computed: true},
+			}, ""); err != nil {
+				t.Fatalf("RegisterType: %v", err)
+			}
+			err := tt.builder.Register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
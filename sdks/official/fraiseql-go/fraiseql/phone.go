@@ -0,0 +1,325 @@
+package fraiseql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// callingCodes lists the ITU-T E.164 country calling code of each ISO 3166-1
+// region, one code per line followed by the regions it serves.
+const callingCodes = `1 US CA AG AI AS BB BM BS DM DO GD GU JM KN KY LC MP MS PR SX TC TT VC VG VI UM
+7 RU KZ
+20 EG
+27 ZA
+30 GR
+31 NL
+32 BE
+33 FR
+34 ES
+36 HU
+39 IT VA
+40 RO
+41 CH
+43 AT
+44 GB GG IM JE
+45 DK
+46 SE
+47 NO SJ BV
+48 PL
+49 DE
+51 PE
+52 MX
+53 CU
+54 AR
+55 BR
+56 CL
+57 CO
+58 VE
+60 MY
+61 AU CX CC
+62 ID
+63 PH
+64 NZ PN
+65 SG
+66 TH
+81 JP
+82 KR
+84 VN
+86 CN
+90 TR
+91 IN
+92 PK
+93 AF
+94 LK
+95 MM
+98 IR
+211 SS
+212 MA EH
+213 DZ
+216 TN
+218 LY
+220 GM
+221 SN
+222 MR
+223 ML
+224 GN
+225 CI
+226 BF
+227 NE
+228 TG
+229 BJ
+230 MU
+231 LR
+232 SL
+233 GH
+234 NG
+235 TD
+236 CF
+237 CM
+238 CV
+239 ST
+240 GQ
+241 GA
+242 CG
+243 CD
+244 AO
+245 GW
+246 IO
+248 SC
+249 SD
+250 RW
+251 ET
+252 SO
+253 DJ
+254 KE
+255 TZ
+256 UG
+257 BI
+258 MZ
+260 ZM
+261 MG
+262 RE YT TF
+263 ZW
+264 NA
+265 MW
+266 LS
+267 BW
+268 SZ
+269 KM
+290 SH
+291 ER
+297 AW
+298 FO
+299 GL
+350 GI
+351 PT
+352 LU
+353 IE
+354 IS
+355 AL
+356 MT
+357 CY
+358 FI AX
+359 BG
+370 LT
+371 LV
+372 EE
+373 MD
+374 AM
+375 BY
+376 AD
+377 MC
+378 SM
+380 UA
+381 RS
+382 ME
+385 HR
+386 SI
+387 BA
+389 MK
+420 CZ
+421 SK
+423 LI
+500 FK GS
+501 BZ
+502 GT
+503 SV
+504 HN
+505 NI
+506 CR
+507 PA
+508 PM
+509 HT
+590 GP BL MF
+591 BO
+592 GY
+593 EC
+594 GF
+595 PY
+596 MQ
+597 SR
+598 UY
+599 CW BQ
+670 TL
+672 NF AQ HM
+673 BN
+674 NR
+675 PG
+676 TO
+677 SB
+678 VU
+679 FJ
+680 PW
+681 WF
+682 CK
+683 NU
+685 WS
+686 KI
+687 NC
+688 TV
+689 PF
+690 TK
+691 FM
+692 MH
+850 KP
+852 HK
+853 MO
+855 KH
+856 LA
+880 BD
+886 TW
+960 MV
+961 LB
+962 JO
+963 SY
+964 IQ
+965 KW
+966 SA
+967 YE
+968 OM
+970 PS
+971 AE
+972 IL
+973 BH
+974 QA
+975 BT
+976 MN
+977 NP
+992 TJ
+993 TM
+994 AZ
+995 GE
+996 KG
+998 UZ`
+
+// regionCallingCodes maps a region to its calling code, e.g. "GB" to "44".
+var regionCallingCodes = func() map[string]string {
+	codes := make(map[string]string)
+	for _, line := range strings.Split(callingCodes, "\n") {
+		fields := strings.Fields(line)
+		for _, region := range fields[1:] {
+			codes[region] = fields[0]
+		}
+	}
+	return codes
+}()
+
+// trunkPrefixes are the national dialling prefixes dropped when a national
+// number is written in E.164. Regions not listed use "0"; the regions that
+// keep their leading zero internationally, such as Italy, use "".
+var trunkPrefixes = map[string]string{
+	"RU": "8", "KZ": "8", "BY": "8", "LT": "8",
+	"HU": "06",
+	"IT": "", "VA": "", "SM": "",
+}
+
+// e164Pattern matches an E.164 number: "+", then a country code and
+// subscriber number of at most 15 digits in all, with no leading zero.
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// IsValidE164 reports whether s is a phone number in E.164 format, e.g.
+// "+14155552671". Only the shape is checked, not whether the number is
+// allocated.
+func IsValidE164(s string) bool { return e164Pattern.MatchString(s) }
+
+// ParsePhoneNumber normalizes a phone number written for humans to E.164.
+// Spaces, dots, dashes, slashes and parentheses are dropped. A number
+// starting with "+" or the international prefix "00" is taken as
+// international; any other number is national to defaultRegion, an ISO
+// 3166-1 alpha-2 code such as "GB", whose calling code is prepended after
+// the trunk prefix (usually "0", or "1" in the North American plan) is
+// removed:
+//
+//	fraiseql.ParsePhoneNumber("(415) 555-2671", "US")   // "+14155552671"
+//	fraiseql.ParsePhoneNumber("020 7946 0958", "GB")    // "+442079460958"
+//	fraiseql.ParsePhoneNumber("0049 30 901820", "")     // "+4930901820"
+//
+// The checks stop at the E.164 shape; they do not know each region's
+// numbering plan. For full validation, parse with a libphonenumber port such
+// as github.com/nyaruka/phonenumbers and store its E.164 output.
+func ParsePhoneNumber(raw, defaultRegion string) (string, error) {
+	var digits strings.Builder
+	international := false
+	for i, r := range strings.TrimSpace(raw) {
+		switch {
+		case isDigit(r):
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			international = true
+		case r == ' ' || r == '.' || r == '-' || r == '/' || r == '(' || r == ')':
+		default:
+			return "", fmt.Errorf("phone number %q contains %q; only digits and separators are allowed", raw, r)
+		}
+	}
+	number := digits.String()
+	if number == "" {
+		return "", fmt.Errorf("phone number %q has no digits", raw)
+	}
+
+	if !international && strings.HasPrefix(number, "00") {
+		number, international = number[2:], true
+	}
+	if !international {
+		if defaultRegion == "" {
+			return "", fmt.Errorf("phone number %q is not international and no default region was given", raw)
+		}
+		code, ok := regionCallingCodes[strings.ToUpper(defaultRegion)]
+		if !ok {
+			return "", fmt.Errorf("unknown region %q; use an ISO 3166-1 alpha-2 code such as \"US\"", defaultRegion)
+		}
+		trunk, listed := trunkPrefixes[strings.ToUpper(defaultRegion)]
+		switch {
+		case code == "1":
+			trunk = "1"
+		case !listed:
+			trunk = "0"
+		}
+		if trunk != "" {
+			number = strings.TrimPrefix(number, trunk)
+		}
+		number = code + number
+	}
+
+	e164 := "+" + number
+	if !IsValidE164(e164) {
+		return "", fmt.Errorf("phone number %q is not a valid E.164 number (got %s)", raw, e164)
+	}
+	return e164, nil
+}
+
+// normalizePhoneDefault rewrites the default of a normalize=true PhoneNumber
+// field to E.164, the form the runtime stores, so the schema exports what
+// the database will hold.
+func normalizePhoneDefault(field FieldInfo) (FieldInfo, error) {
+	raw, ok := field.Default.(string)
+	if !ok || !field.Normalize || namedType(field.Type) != "PhoneNumber" {
+		return field, nil
+	}
+	e164, err := ParsePhoneNumber(raw, "")
+	if err != nil {
+		return field, fmt.Errorf("field %s: default: %w", field.Name, err)
+	}
+	field.Default = e164
+	return field, nil
+}
@@ -0,0 +1,112 @@
+package fraiseql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePhoneNumber(t *testing.T) {
+	tests := []struct {
+		raw    string
+		region string
+		want   string
+	}{
+		{"+1 (415) 555-2671", "", "+14155552671"},
+		{"(415) 555-2671", "US", "+14155552671"},
+		{"1-415-555-2671", "us", "+14155552671"},
+		{"020 7946 0958", "GB", "+442079460958"},
+		{"030 901820", "DE", "+4930901820"},
+		{"0049 30 901820", "FR", "+4930901820"},
+		{"01 23 45 67 89", "FR", "+33123456789"},
+		{"06 6982 0000", "IT", "+390669820000"},
+		{"8 (495) 123-45-67", "RU", "+74951234567"},
+		{"090-1234-5678", "JP", "+819012345678"},
+		{"+61.2.9876.5432", "", "+61298765432"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := ParsePhoneNumber(tt.raw, tt.region)
+			if err != nil {
+				t.Fatalf("ParsePhoneNumber(%q, %q): %v", tt.raw, tt.region, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePhoneNumber(%q, %q) = %q, want %q", tt.raw, tt.region, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePhoneNumberErrors(t *testing.T) {
+	tests := []struct {
+		raw     string
+		region  string
+		wantErr string
+	}{
+		{"415 555 2671", "", "not international and no default region"},
+		{"415 555 2671", "XX", `unknown region "XX"`},
+		{"+1 415 555 2671 ext. 12", "", `contains 'e'`},
+		{"1+415", "", `contains '+'`},
+		{"", "US", "has no digits"},
+		{"+1234", "", "not a valid E.164 number"},
+		{"+1234567890123456", "", "not a valid E.164 number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			_, err := ParsePhoneNumber(tt.raw, tt.region)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsValidE164(t *testing.T) {
+	for _, s := range []string{"+14155552671", "+442079460958", "+819012345678"} {
+		if !IsValidE164(s) {
+			t.Errorf("IsValidE164(%q) = false, want true", s)
+		}
+	}
+	for _, s := range []string{"14155552671", "+04155552671", "+1 415 555 2671", "+1234567890123456", "+"} {
+		if IsValidE164(s) {
+			t.Errorf("IsValidE164(%q) = true, want false", s)
+		}
+	}
+}
+
+func TestNormalizedPhoneDefault(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type SupportInput struct {
+		Phone string `fraiseql:"phone,type=PhoneNumber,normalize=true,default=+1 (800) 555-0199"`
+	}
+	fields, err := ExtractFields(reflect.TypeOf(SupportInput{}))
+	if err != nil {
+		t.Fatalf("ExtractFields: %v", err)
+	}
+	if got := fields["phone"].Default; got != "+18005550199" {
+		t.Errorf("tag default = %v, want +18005550199", got)
+	}
+
+	err = RegisterInputType(InputTypeDefinition{
+		Name: "ContactInput",
+		Fields: []FieldInfo{
+			{Name: "phone", Type: "PhoneNumber", Nullable: true, Normalize: true, Default: "0044 20 7946 0958"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RegisterInputType: %v", err)
+	}
+	if got := getInstance().inputTypes["ContactInput"].Fields[0].Default; got != "+442079460958" {
+		t.Errorf("input default = %v, want +442079460958", got)
+	}
+
+	err = RegisterInputType(InputTypeDefinition{
+		Name:   "BadInput",
+		Fields: []FieldInfo{{Name: "phone", Type: "PhoneNumber", Normalize: true, Default: "555-0199"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), `input type "BadInput": field phone: default: phone number "555-0199" is not international`) {
+		t.Errorf("error = %v, want a missing region error", err)
+	}
+}
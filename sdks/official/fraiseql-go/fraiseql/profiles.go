@@ -0,0 +1,128 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// profileNamePattern matches a valid build profile name. Profiles name the
+// files ExportSchemaSplit writes, so they are kept to safe file name characters.
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// validateProfileName rejects a profile name with characters outside
+// letters, digits, underscores and hyphens.
+func validateProfileName(profile string) error {
+	if !profileNamePattern.MatchString(profile) {
+		return fmt.Errorf("invalid profile %q: must contain only letters, digits, underscores and hyphens", profile)
+	}
+	return nil
+}
+
+// GetSchemaForProfile returns the slice of the schema that belongs to the named
+// build profile (e.g. "oss" or "enterprise").
+//
+// Queries, mutations and fields annotated with a profile (via the builders'
+// Profile method or a `profile=` struct tag) are included only when it matches;
+// elements without a profile are always included. Types, input types and enums
+// reachable only through excluded elements are dropped, while anything the
+// profile still references is pulled in automatically.
+// Returns an error if a schema transform fails.
+func GetSchemaForProfile(profile string) (Schema, error) {
+	full, err := buildSchema()
+	if err != nil {
+		return Schema{}, err
+	}
+	return schemaForProfile(full, profile), nil
+}
+
+func schemaForProfile(full Schema, profile string) Schema {
+	slice := full
+
+	slice.Queries = nil
+	for _, q := range full.Queries {
+		if inProfile(q.Profile, profile) {
+			slice.Queries = append(slice.Queries, q)
+		}
+	}
+	slice.Mutations = nil
+	for _, m := range full.Mutations {
+		if inProfile(m.Profile, profile) {
+			slice.Mutations = append(slice.Mutations, m)
+		}
+	}
+
+	slice.Types = make([]TypeDefinition, len(full.Types))
+	for i, t := range full.Types {
+		t.Fields = fieldsForProfile(t.Fields, profile)
+		slice.Types[i] = t
+	}
+	slice.InputTypes = nil
+	for _, in := range full.InputTypes {
+		in.Fields = fieldsForProfile(in.Fields, profile)
+		slice.InputTypes = append(slice.InputTypes, in)
+	}
+
+	// Drop the named types that only excluded elements lead to. Types that are
+	// unreachable in the full schema too (e.g. federation stubs) are kept.
+	before := reachableTypes(full)
+	after := reachableTypes(slice)
+	dropped := func(name string) bool {
+		return before[name] && !after[name]
+	}
+
+	types := slice.Types[:0]
+	for _, t := range slice.Types {
+		if !dropped(t.Name) {
+			types = append(types, t)
+		}
+	}
+	slice.Types = types
+
+	var inputs []InputTypeDefinition
+	for _, in := range slice.InputTypes {
+		if !dropped(in.Name) {
+			inputs = append(inputs, in)
+		}
+	}
+	slice.InputTypes = inputs
+
+	var enums []EnumDefinition
+	for _, e := range full.Enums {
+		if !dropped(e.Name) {
+			enums = append(enums, e)
+		}
+	}
+	slice.Enums = enums
+
+	return slice
+}
+
+// ExportSchemaForProfile exports the GetSchemaForProfile slice as indented
+// JSON, validating it first like ExportSchema does.
+func ExportSchemaForProfile(profile string) ([]byte, error) {
+	schema, err := GetSchemaForProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSchemaBeforeExport(schema); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// inProfile reports whether an element annotated with elementProfile belongs
+// to the requested profile. Unannotated elements belong to every profile.
+func inProfile(elementProfile, profile string) bool {
+	return elementProfile == "" || elementProfile == profile
+}
+
+func fieldsForProfile(fields []FieldInfo, profile string) []FieldInfo {
+	kept := make([]FieldInfo, 0, len(fields))
+	for _, f := range fields {
+		if inProfile(f.Profile, profile) {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
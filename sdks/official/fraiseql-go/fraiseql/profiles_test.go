@@ -0,0 +1,191 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// registerProfileSchema registers one registry holding both the OSS and the
+// enterprise slice of a schema.
+func registerProfileSchema(t *testing.T) {
+	t.Helper()
+
+	type User struct {
+		ID        ID     `fraiseql:"id"`
+		Email     string `fraiseql:"email"`
+		AuditLog  string `fraiseql:"auditLog,type=[AuditEntry!],profile=enterprise"`
+		SSOConfig string `fraiseql:"ssoConfig,type=SSOConfig,nullable=true,profile=enterprise"`
+	}
+	type AuditEntry struct {
+		ID     ID     `fraiseql:"id"`
+		Action string `fraiseql:"action"`
+	}
+	type SSOConfig struct {
+		Provider string `fraiseql:"provider,type=SSOProvider"`
+	}
+	type Tenant struct {
+		ID   ID     `fraiseql:"id"`
+		Name string `fraiseql:"name"`
+	}
+	type FederationStub struct {
+		ID ID `fraiseql:"id"`
+	}
+
+	if err := RegisterTypes(User{}, AuditEntry{}, SSOConfig{}, Tenant{}, FederationStub{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	Enum("SSOProvider", map[string]string{"OKTA": "okta", "AZURE": "azure"})
+
+	if err := NewQuery("users").ReturnType("User").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewQuery("tenants").ReturnType("Tenant").ReturnsArray(true).Profile("enterprise").Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewMutation("purgeAuditLog").ReturnType("Boolean").Profile("enterprise").Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewMutation("updateEmail").ReturnType("User").Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+}
+
+func typeNames(schema Schema) map[string]bool {
+	names := make(map[string]bool)
+	for _, t := range schema.Types {
+		names[t.Name] = true
+	}
+	for _, e := range schema.Enums {
+		names[e.Name] = true
+	}
+	return names
+}
+
+func TestGetSchemaForProfileOSS(t *testing.T) {
+	Reset()
+	defer Reset()
+	registerProfileSchema(t)
+
+	oss, err := GetSchemaForProfile("oss")
+	if err != nil {
+		t.Fatalf("GetSchemaForProfile: %v", err)
+	}
+
+	if len(oss.Queries) != 1 || oss.Queries[0].Name != "users" {
+		t.Errorf("oss queries: want [users], got %+v", oss.Queries)
+	}
+	if len(oss.Mutations) != 1 || oss.Mutations[0].Name != "updateEmail" {
+		t.Errorf("oss mutations: want [updateEmail], got %+v", oss.Mutations)
+	}
+
+	names := typeNames(oss)
+	for _, want := range []string{"User", "FederationStub"} {
+		if !names[want] {
+			t.Errorf("oss: expected type %s to be included", want)
+		}
+	}
+	for _, unwanted := range []string{"AuditEntry", "SSOConfig", "SSOProvider", "Tenant"} {
+		if names[unwanted] {
+			t.Errorf("oss: enterprise-only type %s should be dropped", unwanted)
+		}
+	}
+
+	for _, tp := range oss.Types {
+		if tp.Name != "User" {
+			continue
+		}
+		if len(tp.Fields) != 2 {
+			t.Errorf("oss: User should keep only id and email, got %+v", tp.Fields)
+		}
+		for _, f := range tp.Fields {
+			if f.Name == "auditLog" || f.Name == "ssoConfig" {
+				t.Errorf("oss: enterprise field %s should be excluded", f.Name)
+			}
+		}
+	}
+}
+
+func TestGetSchemaForProfileEnterprise(t *testing.T) {
+	Reset()
+	defer Reset()
+	registerProfileSchema(t)
+
+	ent, err := GetSchemaForProfile("enterprise")
+	if err != nil {
+		t.Fatalf("GetSchemaForProfile: %v", err)
+	}
+
+	if len(ent.Queries) != 2 || len(ent.Mutations) != 2 {
+		t.Errorf("enterprise: want 2 queries and 2 mutations, got %d and %d", len(ent.Queries), len(ent.Mutations))
+	}
+	names := typeNames(ent)
+	for _, want := range []string{"User", "AuditEntry", "SSOConfig", "SSOProvider", "Tenant", "FederationStub"} {
+		if !names[want] {
+			t.Errorf("enterprise: expected %s to be included", want)
+		}
+	}
+
+	// The full schema is left untouched by profile slicing.
+	var userFields int
+	for _, tp := range GetSchema().Types {
+		if tp.Name == "User" {
+			userFields = len(tp.Fields)
+		}
+	}
+	if userFields != 4 {
+		t.Errorf("registry User should keep all 4 fields, got %d", userFields)
+	}
+}
+
+func TestExportSchemaForProfile(t *testing.T) {
+	Reset()
+	defer Reset()
+	registerProfileSchema(t)
+
+	data, err := ExportSchemaForProfile("oss")
+	if err != nil {
+		t.Fatalf("ExportSchemaForProfile: %v", err)
+	}
+	var exported map[string]interface{}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got := len(exported["queries"].([]interface{})); got != 1 {
+		t.Errorf("expected 1 query in oss export, got %d", got)
+	}
+
+	// Profile annotations are an authoring concern and never exported.
+	full, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	if strings.Contains(string(full), `"profile"`) {
+		t.Error("profile annotations should not appear in the exported schema")
+	}
+}
+
+func TestProfileNameValidation(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	err := NewQuery("users").ReturnsScalar("Int").Profile("../x").Register()
+	if err == nil || !strings.Contains(err.Error(), `query "users": invalid profile "../x"`) {
+		t.Errorf("expected an invalid profile error from the query builder, got %v", err)
+	}
+	err = NewMutation("purge").ReturnsScalar("Int").Profile("a b").Register()
+	if err == nil || !strings.Contains(err.Error(), `mutation "purge": invalid profile "a b"`) {
+		t.Errorf("expected an invalid profile error from the mutation builder, got %v", err)
+	}
+
+	type Secret struct {
+		Value string `fraiseql:"value,profile=ent/../x"`
+	}
+	if err := RegisterTypes(Secret{}); err == nil || !strings.Contains(err.Error(), `invalid profile "ent/../x"`) {
+		t.Errorf("expected an invalid profile error from the tag, got %v", err)
+	}
+
+	if err := NewQuery("audit").ReturnsScalar("Int").Profile("enterprise-v2_beta").Register(); err != nil {
+		t.Errorf("expected a valid profile name to register, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package fraiseql
+
+import "fmt"
+
+// RateLimit caps how often a caller may run the query, at maxPerMinute
+// requests per minute. It is exported as the "rate_limit" config key, which
+// the runtime or gateway enforces per caller to prevent abuse; unlike
+// CacheTTLSeconds it does not change what a request returns. Register returns
+// an error if maxPerMinute is not positive.
+//
+// Example:
+//
+//	fraiseql.NewQuery("searchProducts").
+//		ReturnType(Product{}).
+//		ReturnsArray(true).
+//		RateLimit(60).
+//		Register()
+func (qb *QueryBuilder) RateLimit(maxPerMinute int) *QueryBuilder {
+	qb.rateLimit = &maxPerMinute
+	return qb
+}
+
+// RateLimit caps how often a caller may run the mutation, at maxPerMinute
+// requests per minute. See QueryBuilder.RateLimit.
+func (mb *MutationBuilder) RateLimit(maxPerMinute int) *MutationBuilder {
+	mb.rateLimit = &maxPerMinute
+	return mb
+}
+
+// validateRateLimit checks the RateLimit value, if one was set.
+func (b *operationBuilder) validateRateLimit(kind string) error {
+	if b.rateLimit != nil && *b.rateLimit <= 0 {
+		return fmt.Errorf(
+			"%s %q: rate limit must be a positive number of requests per minute, got %d",
+			kind, b.name, *b.rateLimit,
+		)
+	}
+	return nil
+}
+
+// rateLimitConfig returns the "rate_limit" config value.
+func (b *operationBuilder) rateLimitConfig() map[string]interface{} {
+	return map[string]interface{}{"max_per_minute": *b.rateLimit}
+}
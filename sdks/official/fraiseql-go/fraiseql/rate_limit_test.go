@@ -0,0 +1,66 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRateLimitExport(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewQuery("searchProducts").
+		ReturnType("Product").
+		ReturnsArray(true).
+		RateLimit(60).
+		Register(); err != nil {
+		t.Fatalf("query Register: %v", err)
+	}
+	if err := NewMutation("createOrder").
+		ReturnType("Order").
+		RateLimit(10).
+		Register(); err != nil {
+		t.Fatalf("mutation Register: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	for _, want := range []string{`"rate_limit":{"max_per_minute":60}`, `"rate_limit":{"max_per_minute":10}`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in export, got %s", want, data)
+		}
+	}
+}
+
+func TestRateLimitValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		register func() error
+		wantErr  string
+	}{
+		{
+			name:     "zero on query",
+			register: NewQuery("products").ReturnType("Product").RateLimit(0).Register,
+			wantErr:  `query "products": rate limit must be a positive number of requests per minute, got 0`,
+		},
+		{
+			name:     "negative on mutation",
+			register: NewMutation("createOrder").ReturnType("Order").RateLimit(-5).Register,
+			wantErr:  `mutation "createOrder": rate limit must be a positive number of requests per minute, got -5`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := tt.register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
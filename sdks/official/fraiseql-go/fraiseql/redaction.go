@@ -0,0 +1,17 @@
+package fraiseql
+
+import "reflect"
+
+// apiKeyType is the Go type of the APIKey scalar.
+var apiKeyType = reflect.TypeOf(APIKey(""))
+
+// redactedByDefault reports whether a field is redacted without a redact= tag:
+// APIKey fields are, whether the GraphQL type is APIKey or the Go field is an
+// APIKey. Other sensitive values, such as HashSHA256 digests, opt in with
+// redact=true.
+func redactedByDefault(graphQLType string, goType reflect.Type) bool {
+	for goType.Kind() == reflect.Pointer {
+		goType = goType.Elem()
+	}
+	return namedType(graphQLType) == "APIKey" || goType == apiKeyType
+}
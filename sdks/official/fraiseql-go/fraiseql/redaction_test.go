@@ -0,0 +1,71 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type redactedClient struct {
+	ID        ID         `fraiseql:"id"`
+	Key       APIKey     `fraiseql:"key,scope=read:client.key"`
+	TaggedKey string     `fraiseql:"taggedKey,type=APIKey"`
+	Untagged  APIKey     // untagged fields are redacted too
+	KeyHash   HashSHA256 `fraiseql:"keyHash,type=HashSHA256,redact=true"`
+	PublicKey APIKey     `fraiseql:"publicKey,redact=false"`
+	Name      string     `fraiseql:"name"`
+}
+
+func TestRedactedFields(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(redactedClient{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	want := map[string]bool{
+		"id":        false,
+		"key":       true,
+		"taggedKey": true,
+		"Untagged":  true,
+		"keyHash":   true,
+		"publicKey": false,
+		"name":      false,
+	}
+	fields := GetSchema().Types[0].Fields
+	for _, f := range fields {
+		if f.Redact != want[f.Name] {
+			t.Errorf("field %s: Redact = %v, want %v", f.Name, f.Redact, want[f.Name])
+		}
+	}
+	if fields[1].Scope != "read:client.key" {
+		t.Errorf("redacted field should keep its scope, got %q", fields[1].Scope)
+	}
+}
+
+func TestRedactExport(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(redactedClient{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	var schema struct {
+		Types []struct {
+			Fields []map[string]interface{} `json:"fields"`
+		} `json:"types"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	fields := schema.Types[0].Fields
+	if fields[1]["redact"] != true || fields[1]["scope"] != "read:client.key" {
+		t.Errorf("APIKey field should export redact and scope, got %v", fields[1])
+	}
+	if _, has := fields[6]["redact"]; has {
+		t.Errorf("unredacted field should not export redact, got %v", fields[6])
+	}
+}
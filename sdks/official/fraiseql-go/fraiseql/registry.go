@@ -1,37 +1,52 @@
 package fraiseql
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 )
 
-// ArgumentDefinition represents a GraphQL argument
+// ArgumentDefinition represents a GraphQL argument.
+//
+// Type carries the full GraphQL notation, including list wrappers and element
+// nullability (e.g. "[ID!]"); Nullable applies to the outermost type only. A
+// trailing "!" on Type also marks the argument non-null, so "[ID!]!" with
+// Nullable false describes a required list of non-null IDs.
 type ArgumentDefinition struct {
-	Name      string      `json:"name"`
-	Type      string      `json:"type"`
-	Nullable  bool        `json:"nullable"`
-	Default   interface{} `json:"default,omitempty"`
-	IsDefault bool        `json:"-"` // Track whether default was set
+	Name        string           `json:"name"`
+	Type        string           `json:"type"`
+	Nullable    bool             `json:"nullable"`
+	Default     interface{}      `json:"default,omitempty"`
+	IsDefault   bool             `json:"-"` // Track whether default was set
+	Description string           `json:"description,omitempty"`
+	Deprecated  *DeprecationInfo `json:"deprecated,omitempty"`
+	// Hidden marks an argument the runtime accepts, e.g. from internal
+	// callers, that is left out of introspection. It must be optional.
+	Hidden bool `json:"hidden,omitempty"`
 }
 
-// DeprecationInfo carries the deprecation reason for a query or mutation.
+// DeprecationInfo carries the deprecation reason for a query, mutation or argument.
 type DeprecationInfo struct {
 	Reason string `json:"reason"`
 }
 
 // TypeDefinition represents a GraphQL type
 type TypeDefinition struct {
-	Name         string      `json:"name"`
-	Fields       []FieldInfo `json:"fields"`
-	Description  string      `json:"description,omitempty"`
-	Relay        bool        `json:"relay,omitempty"`
-	SqlSource    string      `json:"sql_source,omitempty"`
-	JsonbColumn  string      `json:"jsonb_column,omitempty"`
-	IsError      bool        `json:"is_error,omitempty"`
-	RequiresRole string      `json:"requires_role,omitempty"`
-	Implements   []string    `json:"implements,omitempty"`
+	Name                string                    `json:"name"`
+	Fields              []FieldInfo               `json:"fields"`
+	Description         string                    `json:"description,omitempty"`
+	Relay               bool                      `json:"relay,omitempty"`
+	SqlSource           string                    `json:"sql_source,omitempty"`
+	JsonbColumn         string                    `json:"jsonb_column,omitempty"`
+	IsError             bool                      `json:"is_error,omitempty"`
+	RequiresRole        string                    `json:"requires_role,omitempty"`
+	Implements          []string                  `json:"implements,omitempty"`
+	Directives          []string                  `json:"directives,omitempty"`
+	RLS                 *RLSConfig                `json:"rls,omitempty"`
+	SecurityIdentifiers *SecurityIdentifierConfig `json:"security_identifiers,omitempty"`
 }
 
 // QueryDefinition represents a GraphQL query
@@ -59,6 +74,7 @@ type QueryDefinition struct {
 	Deprecation       *DeprecationInfo       `json:"deprecation,omitempty"`
 	Rest              *RestAnnotation        `json:"rest,omitempty"`
 	Config            map[string]interface{} `json:"config,omitempty"`
+	Profile           string                 `json:"-"` // see ExportSchemaForProfile
 }
 
 // MutationDefinition represents a GraphQL mutation
@@ -78,6 +94,7 @@ type MutationDefinition struct {
 	Deprecation          *DeprecationInfo       `json:"deprecation,omitempty"`
 	Rest                 *RestAnnotation        `json:"rest,omitempty"`
 	Config               map[string]interface{} `json:"config,omitempty"`
+	Profile              string                 `json:"-"` // see ExportSchemaForProfile
 }
 
 // FactTableDefinition represents a GraphQL fact table for analytics
@@ -96,6 +113,7 @@ type AggregateQueryDefinition struct {
 	AutoGroupBy    bool                   `json:"auto_group_by"`
 	AutoAggregates bool                   `json:"auto_aggregates"`
 	Description    string                 `json:"description,omitempty"`
+	Arguments      []ArgumentDefinition   `json:"arguments,omitempty"`
 	Config         map[string]interface{} `json:"config,omitempty"`
 }
 
@@ -115,7 +133,9 @@ type SubscriptionDefinition struct {
 
 // EnumValueDefinition represents a single value in a GraphQL enum.
 type EnumValueDefinition struct {
-	Name string `json:"name"`
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Deprecation *DeprecationInfo `json:"deprecation,omitempty"`
 }
 
 // EnumDefinition represents a GraphQL enum type.
@@ -126,8 +146,10 @@ type EnumDefinition struct {
 
 // Schema represents the complete GraphQL schema
 type Schema struct {
+	Info             *SchemaInfo                `json:"info,omitempty"`
 	Types            []TypeDefinition           `json:"types"`
 	Enums            []EnumDefinition           `json:"enums,omitempty"`
+	InputTypes       []InputTypeDefinition      `json:"input_types,omitempty"`
 	Queries          []QueryDefinition          `json:"queries"`
 	Mutations        []MutationDefinition       `json:"mutations"`
 	Subscriptions    []SubscriptionDefinition   `json:"subscriptions"`
@@ -135,6 +157,7 @@ type Schema struct {
 	AggregateQueries []AggregateQueryDefinition `json:"aggregate_queries,omitempty"`
 	Observers        []ObserverDefinition       `json:"observers,omitempty"`
 	CustomScalars    []map[string]interface{}   `json:"custom_scalars,omitempty"`
+	Directives       []DirectiveDefinition      `json:"directives,omitempty"`
 	InjectDefaults   *InjectDefaults            `json:"inject_defaults,omitempty"`
 }
 
@@ -152,32 +175,54 @@ type SchemaRegistry struct {
 	mu               sync.RWMutex
 	types            map[string]TypeDefinition
 	enums            map[string]EnumDefinition
+	inputTypes       map[string]InputTypeDefinition
 	queries          map[string]QueryDefinition
 	mutations        map[string]MutationDefinition
 	subscriptions    map[string]SubscriptionDefinition
 	factTables       map[string]FactTableDefinition
 	aggregateQueries map[string]AggregateQueryDefinition
 	observers        map[string]ObserverDefinition
+	actionTemplates  map[string]ObserverAction
+	directives       map[string]DirectiveDefinition
 	injectDefaults   *InjectDefaults
+	info             *SchemaInfo
+
+	// typeDeclarations records, per type, the changes made to it after
+	// registration (see declareType), so registering the same struct again
+	// is compared against the declared definition.
+	typeDeclarations map[string][]typeDeclaration
 }
 
+// typeDeclaration changes a registered type's definition, e.g. to mark it
+// tenant-scoped.
+type typeDeclaration func(def TypeDefinition) (TypeDefinition, error)
+
 // Global registry instance
 var registry *SchemaRegistry
 var once sync.Once
 
+// newSchemaRegistry returns an empty registry.
+func newSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{
+		types:            make(map[string]TypeDefinition),
+		enums:            make(map[string]EnumDefinition),
+		inputTypes:       make(map[string]InputTypeDefinition),
+		queries:          make(map[string]QueryDefinition),
+		mutations:        make(map[string]MutationDefinition),
+		subscriptions:    make(map[string]SubscriptionDefinition),
+		factTables:       make(map[string]FactTableDefinition),
+		aggregateQueries: make(map[string]AggregateQueryDefinition),
+		observers:        make(map[string]ObserverDefinition),
+		actionTemplates:  make(map[string]ObserverAction),
+		directives:       make(map[string]DirectiveDefinition),
+		typeDeclarations: make(map[string][]typeDeclaration),
+	}
+}
+
 // getInstance returns the singleton registry
 func getInstance() *SchemaRegistry {
 	once.Do(func() {
-		registry = &SchemaRegistry{
-			types:            make(map[string]TypeDefinition),
-			enums:            make(map[string]EnumDefinition),
-			queries:          make(map[string]QueryDefinition),
-			mutations:        make(map[string]MutationDefinition),
-			subscriptions:    make(map[string]SubscriptionDefinition),
-			factTables:       make(map[string]FactTableDefinition),
-			aggregateQueries: make(map[string]AggregateQueryDefinition),
-			observers:        make(map[string]ObserverDefinition),
-		}
+		registry = newSchemaRegistry()
 	})
 	return registry
 }
@@ -202,50 +247,205 @@ func toSnakeCase(s string) string {
 
 // RegisterType registers a type with the schema registry.
 // sql_source is automatically derived as "v_" + snake_case(name).
-// Returns an error if a type with the same name is already registered.
+// Re-registering an identical definition is a no-op, so init()-based
+// registration may safely run more than once. Returns an error if a different
+// type with the same name is already registered, or if a field's type is
+// empty, malformed or a miscased scalar such as "string". Type names that are
+// not registered yet are accepted; ValidateSchema reports any that never are.
 func RegisterType(name string, fields []FieldInfo, description string, relay ...bool) error {
-	reg := getInstance()
-	reg.mu.Lock()
-	defer reg.mu.Unlock()
-
-	if _, exists := reg.types[name]; exists {
-		return fmt.Errorf("type %q is already registered; each name must be unique within a schema", name)
-	}
 	isRelay := len(relay) > 0 && relay[0]
-	reg.types[name] = TypeDefinition{
+	return getInstance().addType(TypeDefinition{
 		Name:        name,
 		Fields:      fields,
 		Description: description,
 		Relay:       isRelay,
 		SqlSource:   "v_" + toSnakeCase(name),
-	}
-	return nil
+	})
 }
 
 // RegisterErrorType registers a GraphQL error type with the schema registry.
 // Error types are used to return structured error responses from mutations.
-// Returns an error if a type with the same name is already registered.
+// Like RegisterType, re-registering an identical definition is a no-op.
+// Returns an error if a different type with the same name is already registered.
 func RegisterErrorType(name string, fields []FieldInfo, description string) error {
-	reg := getInstance()
-	reg.mu.Lock()
-	defer reg.mu.Unlock()
-
-	if _, exists := reg.types[name]; exists {
-		return fmt.Errorf("type %q is already registered; each name must be unique within a schema", name)
-	}
-	reg.types[name] = TypeDefinition{
+	return getInstance().addType(TypeDefinition{
 		Name:        name,
 		Fields:      fields,
 		Description: description,
 		IsError:     true,
 		SqlSource:   "v_" + toSnakeCase(name),
+	})
+}
+
+// validatePrimaryKey checks that at most one field of def is the primary key.
+func validatePrimaryKey(def TypeDefinition) error {
+	pk := ""
+	for _, f := range def.Fields {
+		if !f.PrimaryKey {
+			continue
+		}
+		if pk != "" {
+			return fmt.Errorf(
+				"type %q has more than one primary key field (%q and %q); a type may have only one",
+				def.Name, pk, f.Name,
+			)
+		}
+		pk = f.Name
+	}
+	return nil
+}
+
+// validateCurrencyFields checks each amount field's currencyField pairing: the
+// amount must be a Decimal and the named field must exist and be a CurrencyCode.
+func validateCurrencyFields(def TypeDefinition) error {
+	for _, f := range def.Fields {
+		if f.CurrencyField == "" {
+			continue
+		}
+		if namedType(f.Type) != "Decimal" {
+			return fmt.Errorf(
+				"type %q: field %q has currencyField but type %s; only Decimal amount fields can pair with a currency",
+				def.Name, f.Name, f.Type,
+			)
+		}
+		var currency *FieldInfo
+		for i := range def.Fields {
+			if def.Fields[i].Name == f.CurrencyField {
+				currency = &def.Fields[i]
+				break
+			}
+		}
+		if currency == nil {
+			return fmt.Errorf(
+				"type %q: field %q names currency field %q, which does not exist",
+				def.Name, f.Name, f.CurrencyField,
+			)
+		}
+		if namedType(currency.Type) != "CurrencyCode" {
+			return fmt.Errorf(
+				"type %q: currency field %q of %q has type %s; it must be a CurrencyCode",
+				def.Name, currency.Name, f.Name, currency.Type,
+			)
+		}
+	}
+	return nil
+}
+
+// addType stores a type definition, treating an identical re-registration as
+// success and a differing one as a conflict.
+func (reg *SchemaRegistry) addType(def TypeDefinition) (err error) {
+	defer notifyIfRegistered(&err, "type", def.Name)
+	if def, err = prepareType(def); err != nil {
+		return err
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	return reg.storeTypeLocked(def)
+}
+
+// prepareType validates a type definition and fills in what registration
+// derives from it, such as descriptions, scalar constraints and source names.
+func prepareType(def TypeDefinition) (TypeDefinition, error) {
+	if err := validateFieldTypes(def.Name, def.Fields); err != nil {
+		return def, err
+	}
+	def = withDescriptions(def)
+	fields, err := withScalarConstraints(def.Name, def.Fields)
+	if err != nil {
+		return def, err
+	}
+	if fields, err = withSourceNames(def.Name, fields); err != nil {
+		return def, err
+	}
+	def.Fields = fields
+	if def, err = applyTenantKey(def); err != nil {
+		return def, err
+	}
+	if err := validatePrimaryKey(def); err != nil {
+		return def, err
+	}
+	if err := validateCurrencyFields(def); err != nil {
+		return def, err
+	}
+	if err := validateSlugFields(def); err != nil {
+		return def, err
+	}
+	for _, f := range def.Fields {
+		if f.Default != nil {
+			return def, fmt.Errorf(
+				"type %q: field %q has a default, but defaults only apply to input type fields",
+				def.Name, f.Name,
+			)
+		}
+		if f.RequiredIf != nil {
+			return def, fmt.Errorf(
+				"type %q: field %q has requiredIf, but conditional requirements only apply to input type fields",
+				def.Name, f.Name,
+			)
+		}
+		if f.Complexity != nil && *f.Complexity < 0 {
+			return def, fmt.Errorf("type %q: field %q: complexity must not be negative, got %d", def.Name, f.Name, *f.Complexity)
+		}
+		if err := validateFieldFormat(def.Name, f); err != nil {
+			return def, err
+		}
+	}
+	return def, nil
+}
+
+// storeTypeLocked stores a prepared type definition; an identical
+// re-registration is a no-op. The caller must hold reg.mu.
+func (reg *SchemaRegistry) storeTypeLocked(def TypeDefinition) error {
+	if existing, exists := reg.types[def.Name]; exists {
+		if declared, err := applyTypeDeclarations(def, reg.typeDeclarations[def.Name]); err == nil && reflect.DeepEqual(existing, declared) {
+			return nil
+		}
+		return fmt.Errorf(
+			"type %q is already registered with a different definition; each name must be unique within a schema",
+			def.Name,
+		)
+	}
+	reg.types[def.Name] = def
+	return nil
+}
+
+// declareType applies decl to the registered type typeName and records it,
+// so that registering the type's struct again, which rebuilds the definition
+// without decl, is still recognized as the same type. where names the
+// calling function for the error of an unregistered type.
+func (reg *SchemaRegistry) declareType(where, typeName string, decl typeDeclaration) error {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	def, exists := reg.types[typeName]
+	if !exists {
+		return fmt.Errorf("%s: type %q is not registered", where, typeName)
 	}
+	def, err := decl(def)
+	if err != nil {
+		return err
+	}
+	reg.types[typeName] = def
+	reg.typeDeclarations[typeName] = append(reg.typeDeclarations[typeName], decl)
 	return nil
 }
 
+// applyTypeDeclarations applies decls to def in order.
+func applyTypeDeclarations(def TypeDefinition, decls []typeDeclaration) (TypeDefinition, error) {
+	for _, decl := range decls {
+		var err error
+		if def, err = decl(def); err != nil {
+			return def, err
+		}
+	}
+	return def, nil
+}
+
 // RegisterQuery registers a query with the schema registry.
 // Returns an error if a query with the same name is already registered.
-func RegisterQuery(definition QueryDefinition) error {
+func RegisterQuery(definition QueryDefinition) (err error) {
+	defer notifyIfRegistered(&err, "query", definition.Name)
 	reg := getInstance()
 	reg.mu.Lock()
 	defer reg.mu.Unlock()
@@ -259,7 +459,8 @@ func RegisterQuery(definition QueryDefinition) error {
 
 // RegisterMutation registers a mutation with the schema registry.
 // Returns an error if a mutation with the same name is already registered.
-func RegisterMutation(definition MutationDefinition) error {
+func RegisterMutation(definition MutationDefinition) (err error) {
+	defer notifyIfRegistered(&err, "mutation", definition.Name)
 	reg := getInstance()
 	reg.mu.Lock()
 	defer reg.mu.Unlock()
@@ -273,7 +474,8 @@ func RegisterMutation(definition MutationDefinition) error {
 
 // RegisterFactTable registers a fact table with the schema registry.
 // Returns an error if a fact table with the same name is already registered.
-func RegisterFactTable(definition FactTableDefinition) error {
+func RegisterFactTable(definition FactTableDefinition) (err error) {
+	defer notifyIfRegistered(&err, "fact table", definition.Name)
 	reg := getInstance()
 	reg.mu.Lock()
 	defer reg.mu.Unlock()
@@ -287,7 +489,8 @@ func RegisterFactTable(definition FactTableDefinition) error {
 
 // RegisterAggregateQuery registers an aggregate query with the schema registry.
 // Returns an error if an aggregate query with the same name is already registered.
-func RegisterAggregateQuery(definition AggregateQueryDefinition) error {
+func RegisterAggregateQuery(definition AggregateQueryDefinition) (err error) {
+	defer notifyIfRegistered(&err, "aggregate query", definition.Name)
 	reg := getInstance()
 	reg.mu.Lock()
 	defer reg.mu.Unlock()
@@ -303,7 +506,8 @@ func RegisterAggregateQuery(definition AggregateQueryDefinition) error {
 // Subscriptions in FraiseQL are compiled projections of database events.
 // They are sourced from LISTEN/NOTIFY or CDC, not resolver-based.
 // Returns an error if a subscription with the same name is already registered.
-func RegisterSubscription(definition SubscriptionDefinition) error {
+func RegisterSubscription(definition SubscriptionDefinition) (err error) {
+	defer notifyIfRegistered(&err, "subscription", definition.Name)
 	reg := getInstance()
 	reg.mu.Lock()
 	defer reg.mu.Unlock()
@@ -339,8 +543,27 @@ func GetRegistry() *SchemaRegistry {
 	return getInstance()
 }
 
-// GetSchema returns the complete schema as a Schema struct
+// GetSchema returns the complete schema as a Schema struct, with the
+// registered schema transforms applied. GetSchema cannot report a failing
+// transform; it then returns the schema without transforms. Use GetSchemaE
+// when transforms are registered.
 func GetSchema() Schema {
+	schema, err := buildSchema()
+	if err != nil {
+		return collectSchema()
+	}
+	return schema
+}
+
+// GetSchemaE is GetSchema but returns the error of a failing schema transform
+// instead of falling back to the schema without transforms.
+func GetSchemaE() (Schema, error) {
+	return buildSchema()
+}
+
+// collectSchema assembles the registered definitions into a sorted Schema,
+// before any schema transforms run.
+func collectSchema() Schema {
 	reg := getInstance()
 	reg.mu.RLock()
 	defer reg.mu.RUnlock()
@@ -356,6 +579,10 @@ func GetSchema() Schema {
 		schema.Enums = append(schema.Enums, enumDef)
 	}
 
+	for _, inputDef := range reg.inputTypes {
+		schema.InputTypes = append(schema.InputTypes, inputDef)
+	}
+
 	for _, queryDef := range reg.queries {
 		schema.Queries = append(schema.Queries, queryDef)
 	}
@@ -380,6 +607,14 @@ func GetSchema() Schema {
 		schema.Observers = append(schema.Observers, observer)
 	}
 
+	for _, directive := range reg.directives {
+		schema.Directives = append(schema.Directives, directive)
+	}
+
+	if reg.info != nil {
+		info := *reg.info
+		schema.Info = &info
+	}
 	if reg.injectDefaults != nil {
 		schema.InjectDefaults = reg.injectDefaults
 	}
@@ -387,17 +622,60 @@ func GetSchema() Schema {
 	// Include custom scalars
 	customScalars := GetAllCustomScalars()
 	for name := range customScalars {
-		schema.CustomScalars = append(schema.CustomScalars, map[string]interface{}{
+		scalar := map[string]interface{}{
 			"name": name,
-		})
+		}
+		if url := ScalarSpecifiedByURL(name); url != "" {
+			scalar["specified_by"] = url
+		}
+		schema.CustomScalars = append(schema.CustomScalars, scalar)
 	}
 
+	// FraiseQL scalars with a seeded `@specifiedBy` URL are listed too, when
+	// the schema references them.
+	for name, kind := range introspectionKinds(schema) {
+		if _, custom := customScalars[name]; custom || kind != "SCALAR" {
+			continue
+		}
+		if url := scalarSpecURLs[name]; url != "" {
+			schema.CustomScalars = append(schema.CustomScalars, map[string]interface{}{
+				"name":         name,
+				"specified_by": url,
+			})
+		}
+	}
+
+	sortSchema(&schema)
+
 	return schema
 }
 
+// sortSchema orders every section of the schema by name so that the exported
+// JSON is deterministic regardless of map iteration or registration order.
+func sortSchema(schema *Schema) {
+	sort.Slice(schema.Types, func(i, j int) bool { return schema.Types[i].Name < schema.Types[j].Name })
+	sort.Slice(schema.Enums, func(i, j int) bool { return schema.Enums[i].Name < schema.Enums[j].Name })
+	sort.Slice(schema.InputTypes, func(i, j int) bool { return schema.InputTypes[i].Name < schema.InputTypes[j].Name })
+	sort.Slice(schema.Queries, func(i, j int) bool { return schema.Queries[i].Name < schema.Queries[j].Name })
+	sort.Slice(schema.Mutations, func(i, j int) bool { return schema.Mutations[i].Name < schema.Mutations[j].Name })
+	sort.Slice(schema.Subscriptions, func(i, j int) bool { return schema.Subscriptions[i].Name < schema.Subscriptions[j].Name })
+	sort.Slice(schema.FactTables, func(i, j int) bool { return schema.FactTables[i].Name < schema.FactTables[j].Name })
+	sort.Slice(schema.AggregateQueries, func(i, j int) bool {
+		return schema.AggregateQueries[i].Name < schema.AggregateQueries[j].Name
+	})
+	sort.Slice(schema.Observers, func(i, j int) bool { return schema.Observers[i].Name < schema.Observers[j].Name })
+	sort.Slice(schema.Directives, func(i, j int) bool { return schema.Directives[i].Name < schema.Directives[j].Name })
+	sort.Slice(schema.CustomScalars, func(i, j int) bool {
+		return schema.CustomScalars[i]["name"].(string) < schema.CustomScalars[j]["name"].(string)
+	})
+}
+
 // GetSchemaJSON returns the schema as JSON bytes
 func GetSchemaJSON(pretty bool) ([]byte, error) {
-	schema := GetSchema()
+	schema, err := buildSchema()
+	if err != nil {
+		return nil, err
+	}
 
 	if pretty {
 		return json.MarshalIndent(schema, "", "  ")
@@ -407,22 +685,60 @@ func GetSchemaJSON(pretty bool) ([]byte, error) {
 
 // Reset clears the registry (useful for testing)
 func Reset() {
+	ResetSchema()
+
+	// Also clear custom scalars, type mappers, schema transforms, descriptions
+	// OnRegister callbacks and RegisterValidator validators, and restore the
+	// default scope validator, field nullability, float slice mapping,
+	// empty-schema check and export validation
+	ClearCustomScalars()
+	ClearTypeMappers()
+	ClearSchemaTransforms()
+	SetDescriptions(nil)
+	SetScopeValidator(nil)
+	SetDefaultNullable(false)
+	AllowEmptySchema(false)
+	SetFloatSlicesAsVector(false)
+	SetValidateOnExport(false)
+	clearRegisterCallbacks()
+	clearValidators()
+}
+
+// ResetSchema clears the schema elements: types, enums, input types,
+// operations, fact tables, aggregate queries, observers, action templates,
+// directives, inject defaults and schema info. Unlike Reset, it keeps the
+// configuration set up once for the process, such as custom scalars, type
+// mappers, validators, schema transforms, descriptions, OnRegister callbacks
+// and the Set* options, so tests can share a configured environment:
+//
+//	func TestMain(m *testing.M) {
+//		fraiseql.RegisterCustomScalar(Email{})
+//		os.Exit(m.Run())
+//	}
+//
+//	func TestUsers(t *testing.T) {
+//		defer fraiseql.ResetSchema()
+//		...
+//	}
+func ResetSchema() {
 	reg := getInstance()
 	reg.mu.Lock()
 	defer reg.mu.Unlock()
 
 	reg.types = make(map[string]TypeDefinition)
 	reg.enums = make(map[string]EnumDefinition)
+	reg.inputTypes = make(map[string]InputTypeDefinition)
 	reg.queries = make(map[string]QueryDefinition)
 	reg.mutations = make(map[string]MutationDefinition)
 	reg.subscriptions = make(map[string]SubscriptionDefinition)
 	reg.factTables = make(map[string]FactTableDefinition)
 	reg.aggregateQueries = make(map[string]AggregateQueryDefinition)
 	reg.observers = make(map[string]ObserverDefinition)
+	reg.actionTemplates = make(map[string]ObserverAction)
+	reg.directives = make(map[string]DirectiveDefinition)
 	reg.injectDefaults = nil
-
-	// Also clear custom scalars
-	ClearCustomScalars()
+	reg.info = nil
+	reg.typeDeclarations = make(map[string][]typeDeclaration)
 }
 
 // ClearRegistry clears the registry (alias for Reset, used in tests)
@@ -432,15 +748,24 @@ func ClearRegistry() {
 
 // Enum registers a GraphQL enum type with the schema registry.
 // The values map keys are the enum member names (e.g., "DAY", "WEEK").
+// A member's description comes from SetDescriptions, keyed "Enum.MEMBER".
 func Enum(name string, values map[string]string) {
+	defer notifyRegistered("enum", name)
+	enumValues := make([]EnumValueDefinition, 0, len(values))
+	descriptions.mu.RLock()
+	for memberName := range values {
+		enumValues = append(enumValues, EnumValueDefinition{
+			Name:        memberName,
+			Description: descriptions.descriptions[name+"."+memberName],
+		})
+	}
+	descriptions.mu.RUnlock()
+
 	reg := getInstance()
 	reg.mu.Lock()
 	defer reg.mu.Unlock()
 
-	enumValues := make([]EnumValueDefinition, 0, len(values))
-	for memberName := range values {
-		enumValues = append(enumValues, EnumValueDefinition{Name: memberName})
-	}
+	sort.Slice(enumValues, func(i, j int) bool { return enumValues[i].Name < enumValues[j].Name })
 
 	reg.enums[name] = EnumDefinition{
 		Name:   name,
@@ -448,31 +773,112 @@ func Enum(name string, values map[string]string) {
 	}
 }
 
-// RegisterTypes extracts fields from Go struct types and registers them
+// DeprecateEnumValue marks a member of a registered enum as deprecated with
+// the given reason. Call it after Enum, which replaces the enum's members.
+// Returns an error if the enum or the member is not registered.
+func DeprecateEnumValue(enumName, value, reason string) error {
+	reg := getInstance()
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	def, exists := reg.enums[enumName]
+	if !exists {
+		return fmt.Errorf("DeprecateEnumValue: enum %q is not registered", enumName)
+	}
+	values := append([]EnumValueDefinition(nil), def.Values...)
+	for i := range values {
+		if values[i].Name == value {
+			values[i].Deprecation = &DeprecationInfo{Reason: reason}
+			def.Values = values
+			reg.enums[enumName] = def
+			return nil
+		}
+	}
+	return fmt.Errorf("DeprecateEnumValue: enum %q has no value %q", enumName, value)
+}
+
+// RegisterTypes extracts fields from Go struct types and registers them. It
+// stops at the first error, including a type already registered under the
+// same name with a different definition; the types before it stay
+// registered. Registering an identical type again is a no-op.
 func RegisterTypes(types ...interface{}) error {
 	for _, t := range types {
-		structType := reflect.TypeOf(t)
-		if structType.Kind() == reflect.Pointer {
-			structType = structType.Elem()
+		if err := registerStructType(reflect.TypeOf(t)); err != nil {
+			return err
 		}
+	}
 
-		if structType.Kind() != reflect.Struct {
-			return fmt.Errorf("expected struct type, got %v", structType.Kind())
-		}
+	return nil
+}
 
-		fields, err := ExtractFields(structType)
-		if err != nil {
-			return fmt.Errorf("failed to extract fields from %s: %w", structType.Name(), err)
+// RegisterReflectTypes is like RegisterTypes but takes the struct types
+// themselves, for generic or generated code that holds reflect.Type values
+// rather than instances. Pointer types register their element type.
+func RegisterReflectTypes(types ...reflect.Type) error {
+	for _, t := range types {
+		if t == nil {
+			return fmt.Errorf("expected struct type, got nil reflect.Type")
 		}
-
-		// Convert map to slice of FieldInfo
-		var fieldSlice []FieldInfo
-		for _, field := range fields {
-			fieldSlice = append(fieldSlice, field)
+		if err := registerStructType(t); err != nil {
+			return err
 		}
+	}
 
-		RegisterType(structType.Name(), fieldSlice, "")
+	return nil
+}
+
+// RegisterTypesCtx is like RegisterTypes but checks ctx between types, so bulk
+// registration of large generated schemas can be cancelled or bounded by a
+// deadline. On cancellation it returns an error wrapping ctx.Err(); types
+// registered before the cancellation remain registered.
+func RegisterTypesCtx(ctx context.Context, types ...interface{}) error {
+	for i, t := range types {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("type registration stopped after %d of %d types: %w", i, len(types), err)
+		}
+		if err := registerStructType(reflect.TypeOf(t)); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// registerStructType extracts the fields of a struct type and registers it
+// under the struct's name.
+func registerStructType(structType reflect.Type) error {
+	def, err := structTypeDefinition(structType)
+	if err != nil {
+		return err
+	}
+	return getInstance().addType(def)
+}
+
+// structTypeDefinition builds the type definition of a struct type, or of
+// the struct a pointer type points to, without registering it.
+func structTypeDefinition(structType reflect.Type) (TypeDefinition, error) {
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	if structType.Kind() != reflect.Struct {
+		return TypeDefinition{}, fmt.Errorf("expected struct type, got %v", structType.Kind())
+	}
+
+	fields, err := extractFieldList(structType)
+	if err != nil {
+		return TypeDefinition{}, fmt.Errorf("failed to extract fields from %s: %w", structType.Name(), err)
+	}
+	directives, err := structTypeDirectives(structType)
+	if err != nil {
+		return TypeDefinition{}, fmt.Errorf("failed to extract directives from %s: %w", structType.Name(), err)
+	}
+
+	name := structType.Name()
+	return TypeDefinition{
+		Name:       name,
+		Fields:     fields,
+		SqlSource:  "v_" + toSnakeCase(name),
+		Directives: directives,
+	}, nil
+}
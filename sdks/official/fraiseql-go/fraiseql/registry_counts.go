@@ -0,0 +1,55 @@
+package fraiseql
+
+// registryLen reads a registry length under the read lock, without assembling
+// the schema as GetSchema does.
+func registryLen(length func(reg *SchemaRegistry) int) int {
+	reg := getInstance()
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	return length(reg)
+}
+
+// CountTypes returns the number of registered types, including error types.
+func CountTypes() int {
+	return registryLen(func(reg *SchemaRegistry) int { return len(reg.types) })
+}
+
+// CountEnums returns the number of registered enums.
+func CountEnums() int {
+	return registryLen(func(reg *SchemaRegistry) int { return len(reg.enums) })
+}
+
+// CountInputTypes returns the number of registered input types.
+func CountInputTypes() int {
+	return registryLen(func(reg *SchemaRegistry) int { return len(reg.inputTypes) })
+}
+
+// CountQueries returns the number of registered queries.
+func CountQueries() int {
+	return registryLen(func(reg *SchemaRegistry) int { return len(reg.queries) })
+}
+
+// CountMutations returns the number of registered mutations.
+func CountMutations() int {
+	return registryLen(func(reg *SchemaRegistry) int { return len(reg.mutations) })
+}
+
+// CountSubscriptions returns the number of registered subscriptions.
+func CountSubscriptions() int {
+	return registryLen(func(reg *SchemaRegistry) int { return len(reg.subscriptions) })
+}
+
+// CountFactTables returns the number of registered fact tables.
+func CountFactTables() int {
+	return registryLen(func(reg *SchemaRegistry) int { return len(reg.factTables) })
+}
+
+// CountAggregateQueries returns the number of registered aggregate queries.
+func CountAggregateQueries() int {
+	return registryLen(func(reg *SchemaRegistry) int { return len(reg.aggregateQueries) })
+}
+
+// CountObservers returns the number of registered observers.
+func CountObservers() int {
+	return registryLen(func(reg *SchemaRegistry) int { return len(reg.observers) })
+}
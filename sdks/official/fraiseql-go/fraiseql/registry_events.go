@@ -0,0 +1,60 @@
+package fraiseql
+
+import "sync"
+
+// RegisterCallback is called with the kind and name of each registered
+// element. kind is one of "type", "input type", "enum", "query", "mutation",
+// "subscription", "fact table", "aggregate query", "observer",
+// "action template", "directive" or "scalar".
+type RegisterCallback func(kind string, name string)
+
+// registerCallbacks holds the callbacks added with OnRegister.
+type registerCallbacks struct {
+	mu        sync.RWMutex
+	callbacks []RegisterCallback
+}
+
+// Global instance
+var onRegister = &registerCallbacks{}
+
+// OnRegister adds a callback invoked synchronously after each successful
+// registration, for progress reporting or custom validation during large
+// registrations. Callbacks run in the order they were added.
+//
+// Callbacks run with no registry lock held, so they may read the schema or
+// register further elements without deadlocking. Re-registering an identical
+// type counts as a successful registration. Reset() removes all callbacks.
+func OnRegister(callback RegisterCallback) {
+	onRegister.mu.Lock()
+	defer onRegister.mu.Unlock()
+	onRegister.callbacks = append(onRegister.callbacks, callback)
+}
+
+// clearRegisterCallbacks removes all callbacks added with OnRegister.
+func clearRegisterCallbacks() {
+	onRegister.mu.Lock()
+	defer onRegister.mu.Unlock()
+	onRegister.callbacks = nil
+}
+
+// notifyRegistered invokes the OnRegister callbacks. It must be called
+// without reg.mu held.
+func notifyRegistered(kind, name string) {
+	onRegister.mu.RLock()
+	callbacks := make([]RegisterCallback, len(onRegister.callbacks))
+	copy(callbacks, onRegister.callbacks)
+	onRegister.mu.RUnlock()
+
+	for _, callback := range callbacks {
+		callback(kind, name)
+	}
+}
+
+// notifyIfRegistered invokes the OnRegister callbacks when *err is nil.
+// Register functions defer it before locking reg.mu, so it runs once the
+// lock has been released.
+func notifyIfRegistered(err *error, kind, name string) {
+	if *err == nil {
+		notifyRegistered(kind, name)
+	}
+}
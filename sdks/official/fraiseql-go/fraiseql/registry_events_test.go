@@ -0,0 +1,65 @@
+package fraiseql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOnRegisterEvents(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Author struct {
+		ID   ID     `fraiseql:"id"`
+		Name string `fraiseql:"name"`
+	}
+
+	var events []string
+	OnRegister(func(kind, name string) {
+		events = append(events, kind+":"+name)
+		// Callbacks run without the registry lock held, so reading the
+		// schema here must not deadlock.
+		_ = GetSchema()
+	})
+
+	if err := RegisterTypes(Author{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	Enum("Role", map[string]string{"ADMIN": "admin"})
+	if err := NewInputType("AuthorInput").Field("name", "String").Register(); err != nil {
+		t.Fatalf("Register input: %v", err)
+	}
+	if err := NewQuery("authors").ReturnType(Author{}).ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("Register query: %v", err)
+	}
+	if err := NewMutation("createAuthor").ReturnType(Author{}).Register(); err != nil {
+		t.Fatalf("Register mutation: %v", err)
+	}
+	if err := NewQuery("authors").ReturnType(Author{}).Register(); err == nil {
+		t.Fatal("expected duplicate query error")
+	}
+
+	want := []string{
+		"type:Author",
+		"enum:Role",
+		"input type:AuthorInput",
+		"query:authors",
+		"mutation:createAuthor",
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events: want %v, got %v", want, events)
+	}
+}
+
+func TestResetClearsRegisterCallbacks(t *testing.T) {
+	Reset()
+	calls := 0
+	OnRegister(func(kind, name string) { calls++ })
+	Reset()
+	defer Reset()
+
+	Enum("Role", map[string]string{"ADMIN": "admin"})
+	if calls != 0 {
+		t.Errorf("expected callbacks to be cleared by Reset, got %d calls", calls)
+	}
+}
@@ -0,0 +1,180 @@
+package fraiseql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// SnapshotRegistry returns a copy of the definitions registered so far. A
+// module that owns part of a large schema can register into a freshly Reset
+// registry and snapshot the result, so the parts can be combined with
+// MergeRegistries.
+//
+// Example:
+//
+//	fraiseql.Reset()
+//	billing.Register()
+//	billingReg := fraiseql.SnapshotRegistry()
+//
+//	fraiseql.Reset()
+//	catalog.Register()
+//	merged, err := fraiseql.MergeRegistries(billingReg, fraiseql.SnapshotRegistry())
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fraiseql.UseRegistry(merged)
+//	fraiseql.ExportSchema("schema.json")
+func SnapshotRegistry() *SchemaRegistry {
+	snapshot := newSchemaRegistry()
+	if err := snapshot.merge(getInstance(), 0, map[string]int{}); err != nil {
+		// An empty registry cannot conflict with anything.
+		panic(err)
+	}
+	return snapshot
+}
+
+// MergeRegistries combines the definitions of regs into a new registry. As
+// with repeated registration, a name defined identically in more than one
+// registry is merged into one definition; a name defined differently is an
+// error naming the kind, the name and the two registries, by 1-based
+// position. The inputs are not modified.
+func MergeRegistries(regs ...*SchemaRegistry) (*SchemaRegistry, error) {
+	merged := newSchemaRegistry()
+	owners := make(map[string]int)
+	for i, reg := range regs {
+		if reg == nil {
+			return nil, fmt.Errorf("MergeRegistries: registry %d is nil", i+1)
+		}
+		if err := merged.merge(reg, i+1, owners); err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+// UseRegistry replaces the definitions in the global registry with a copy of
+// reg's, such as the result of MergeRegistries, so that ExportSchema and the
+// other exports write them. Custom scalars, type mappers, schema transforms
+// and other settings are left as they are.
+func UseRegistry(reg *SchemaRegistry) {
+	global := getInstance()
+	if reg == global {
+		return
+	}
+	copied := newSchemaRegistry()
+	if err := copied.merge(reg, 0, map[string]int{}); err != nil {
+		panic(err)
+	}
+
+	global.mu.Lock()
+	defer global.mu.Unlock()
+	global.types = copied.types
+	global.enums = copied.enums
+	global.inputTypes = copied.inputTypes
+	global.queries = copied.queries
+	global.mutations = copied.mutations
+	global.subscriptions = copied.subscriptions
+	global.factTables = copied.factTables
+	global.aggregateQueries = copied.aggregateQueries
+	global.observers = copied.observers
+	global.actionTemplates = copied.actionTemplates
+	global.directives = copied.directives
+	global.injectDefaults = copied.injectDefaults
+	global.info = copied.info
+	global.typeDeclarations = copied.typeDeclarations
+}
+
+// merge adds src's definitions to reg. owners records, per kind and name,
+// which registry (by position) first defined it, for conflict errors.
+func (reg *SchemaRegistry) merge(src *SchemaRegistry, position int, owners map[string]int) error {
+	src.mu.RLock()
+	defer src.mu.RUnlock()
+
+	for _, err := range []error{
+		mergeDefinitions("type", reg.types, src.types, position, owners),
+		mergeDefinitions("enum", reg.enums, src.enums, position, owners),
+		mergeDefinitions("input type", reg.inputTypes, src.inputTypes, position, owners),
+		mergeDefinitions("query", reg.queries, src.queries, position, owners),
+		mergeDefinitions("mutation", reg.mutations, src.mutations, position, owners),
+		mergeDefinitions("subscription", reg.subscriptions, src.subscriptions, position, owners),
+		mergeDefinitions("fact table", reg.factTables, src.factTables, position, owners),
+		mergeDefinitions("aggregate query", reg.aggregateQueries, src.aggregateQueries, position, owners),
+		mergeDefinitions("observer", reg.observers, src.observers, position, owners),
+		mergeDefinitions("action template", reg.actionTemplates, src.actionTemplates, position, owners),
+		mergeDefinitions("directive", reg.directives, src.directives, position, owners),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	// The declarations of a type defined identically in both registries
+	// lead to the same definition, so the first registry's are kept.
+	for name, decls := range src.typeDeclarations {
+		if _, declared := reg.typeDeclarations[name]; !declared {
+			reg.typeDeclarations[name] = append([]typeDeclaration(nil), decls...)
+		}
+	}
+
+	if src.injectDefaults != nil {
+		if reg.injectDefaults != nil && !reflect.DeepEqual(reg.injectDefaults, src.injectDefaults) {
+			return fmt.Errorf(
+				"inject defaults are set differently in registries %d and %d",
+				owners["inject defaults"], position,
+			)
+		}
+		if reg.injectDefaults == nil {
+			owners["inject defaults"] = position
+		}
+		defaults := *src.injectDefaults
+		reg.injectDefaults = &defaults
+	}
+
+	if src.info != nil {
+		if reg.info != nil && !reflect.DeepEqual(reg.info, src.info) {
+			return fmt.Errorf(
+				"schema info is set differently in registries %d and %d",
+				owners["schema info"], position,
+			)
+		}
+		if reg.info == nil {
+			owners["schema info"] = position
+		}
+		info := *src.info
+		reg.info = &info
+	}
+	return nil
+}
+
+// mergeDefinitions copies src into dst, rejecting names dst already holds
+// with a different definition.
+func mergeDefinitions[T any](kind string, dst, src map[string]T, position int, owners map[string]int) error {
+	for _, name := range sortedKeys(src) {
+		def := src[name]
+		key := kind + " " + name
+		if existing, exists := dst[name]; exists {
+			if reflect.DeepEqual(existing, def) {
+				continue
+			}
+			return fmt.Errorf(
+				"%s %q is defined differently in registries %d and %d; each name must be unique within a schema",
+				kind, name, owners[key], position,
+			)
+		}
+		dst[name] = def
+		owners[key] = position
+	}
+	return nil
+}
+
+// sortedKeys returns m's keys in order, so merge conflicts are reported
+// deterministically.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for name := range m {
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,81 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+// buildRegistry resets the global registry, runs register and snapshots the result.
+func buildRegistry(t *testing.T, register func() error) *SchemaRegistry {
+	t.Helper()
+	Reset()
+	if err := register(); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	return SnapshotRegistry()
+}
+
+func TestMergeRegistries(t *testing.T) {
+	defer Reset()
+
+	shared := []FieldInfo{{Name: "id", Type: "ID"}}
+	billing := buildRegistry(t, func() error {
+		if err := RegisterType("Account", shared, ""); err != nil {
+			return err
+		}
+		if err := RegisterType("Invoice", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+			return err
+		}
+		return NewQuery("invoices").ReturnType("Invoice").ReturnsArray(true).Register()
+	})
+	catalog := buildRegistry(t, func() error {
+		if err := RegisterType("Account", shared, ""); err != nil {
+			return err
+		}
+		if err := RegisterType("Product", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+			return err
+		}
+		return NewQuery("products").ReturnType("Product").ReturnsArray(true).Register()
+	})
+
+	merged, err := MergeRegistries(billing, catalog)
+	if err != nil {
+		t.Fatalf("MergeRegistries: %v", err)
+	}
+
+	Reset()
+	UseRegistry(merged)
+	if got := CountTypes(); got != 3 {
+		t.Errorf("CountTypes() = %d, want 3 (Account is defined identically in both)", got)
+	}
+	if got := CountQueries(); got != 2 {
+		t.Errorf("CountQueries() = %d, want 2", got)
+	}
+
+	// The inputs are unaffected by the merge.
+	UseRegistry(billing)
+	if got := CountTypes(); got != 2 {
+		t.Errorf("billing registry CountTypes() = %d, want 2", got)
+	}
+}
+
+func TestMergeRegistriesConflicts(t *testing.T) {
+	defer Reset()
+
+	first := buildRegistry(t, func() error {
+		return RegisterType("Account", []FieldInfo{{Name: "id", Type: "ID"}}, "")
+	})
+	second := buildRegistry(t, func() error {
+		return RegisterType("Account", []FieldInfo{{Name: "id", Type: "UUID"}}, "")
+	})
+
+	_, err := MergeRegistries(first, second)
+	want := `type "Account" is defined differently in registries 1 and 2`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("error = %v, want it to contain %q", err, want)
+	}
+
+	if _, err := MergeRegistries(first, nil); err == nil || !strings.Contains(err.Error(), "registry 2 is nil") {
+		t.Fatalf("expected an error for a nil registry, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package fraiseql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RecursiveOptions configures RegisterTypesRecursiveWithOptions.
+type RecursiveOptions struct {
+	// MaxDepth bounds how many levels of nested struct types are followed
+	// below each root type; 0 means no limit. A root's direct field types
+	// are at depth 1.
+	MaxDepth int
+}
+
+// RegisterTypesRecursive registers the given struct types and every struct
+// type reachable through their fields (directly, through pointers, or as
+// slice and array elements), so a root type's object graph need not be listed
+// by hand. Each type is registered once, so cyclic graphs terminate.
+//
+// Fields whose Go type maps to something other than an object type, such as
+// time.Time, text-marshaling types, types handled by a type mapper, or fields
+// with a `type=` tag naming another type, are not followed.
+func RegisterTypesRecursive(types ...interface{}) error {
+	return RegisterTypesRecursiveWithOptions(RecursiveOptions{}, types...)
+}
+
+// RegisterTypesRecursiveWithOptions is like RegisterTypesRecursive with a
+// bound on the nesting depth, a safety valve for generated schemas. The whole
+// graph is walked before anything is registered, so exceeding MaxDepth
+// registers nothing and returns an error naming the path that exceeded it.
+func RegisterTypesRecursiveWithOptions(opts RecursiveOptions, types ...interface{}) error {
+	if opts.MaxDepth < 0 {
+		return fmt.Errorf("RecursiveOptions.MaxDepth must not be negative, got %d", opts.MaxDepth)
+	}
+
+	w := &typeGraphWalker{opts: opts, seen: make(map[reflect.Type]bool)}
+	for _, t := range types {
+		structType := reflect.TypeOf(t)
+		if structType != nil && structType.Kind() == reflect.Pointer {
+			structType = structType.Elem()
+		}
+		if structType == nil || structType.Kind() != reflect.Struct {
+			return fmt.Errorf("expected struct type, got %v", structType)
+		}
+		if err := w.walk(structType); err != nil {
+			return err
+		}
+	}
+
+	for _, structType := range w.order {
+		if err := registerStructType(structType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// typeGraphWalker collects the struct types reachable from the roots of a
+// recursive registration, breadth first, so each type is reached by its
+// shortest path and MaxDepth is not tripped by a longer route to a type that
+// is also close to a root.
+type typeGraphWalker struct {
+	opts  RecursiveOptions
+	seen  map[reflect.Type]bool
+	order []reflect.Type
+}
+
+// typeGraphStep is a struct type queued by typeGraphWalker together with the
+// fields ("Type.Field" steps) it was reached through.
+type typeGraphStep struct {
+	structType reflect.Type
+	path       []string
+}
+
+// walk visits root and the struct types reachable from it.
+func (w *typeGraphWalker) walk(root reflect.Type) error {
+	if w.seen[root] {
+		return nil
+	}
+	w.seen[root] = true
+	queue := []typeGraphStep{{structType: root}}
+
+	for len(queue) > 0 {
+		step := queue[0]
+		queue = queue[1:]
+		w.order = append(w.order, step.structType)
+
+		for i := 0; i < step.structType.NumField(); i++ {
+			field := step.structType.Field(i)
+			if !field.IsExported() || field.Anonymous {
+				continue
+			}
+			nested, ok := nestedObjectType(field)
+			if !ok || w.seen[nested] {
+				continue
+			}
+			path := append(step.path[:len(step.path):len(step.path)], step.structType.Name()+"."+field.Name)
+			if w.opts.MaxDepth > 0 && len(path) > w.opts.MaxDepth {
+				return fmt.Errorf(
+					"type nesting exceeds MaxDepth %d at %s -> %s",
+					w.opts.MaxDepth, strings.Join(path, " -> "), nested.Name(),
+				)
+			}
+			w.seen[nested] = true
+			queue = append(queue, typeGraphStep{structType: nested, path: path})
+		}
+	}
+	return nil
+}
+
+// nestedObjectType returns the struct type a field refers to as a GraphQL
+// object type, looking through pointers, slices and arrays.
+func nestedObjectType(field reflect.StructField) (reflect.Type, bool) {
+	elem := field.Type
+	for elem.Kind() == reflect.Pointer || elem.Kind() == reflect.Slice || elem.Kind() == reflect.Array {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct || elem.Name() == "" {
+		return nil, false
+	}
+	if graphQLType, _, err := goToGraphQLType(elem); err != nil || graphQLType != elem.Name() {
+		return nil, false
+	}
+	if tag, ok := field.Tag.Lookup("fraiseql"); ok {
+		if typ, hasType := tagOption(tag, "type"); hasType && namedType(typ) != elem.Name() {
+			return nil, false
+		}
+	}
+	return elem, true
+}
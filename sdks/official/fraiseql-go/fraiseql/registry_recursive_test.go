@@ -0,0 +1,125 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type recLevel1 struct {
+	ID   int        `fraiseql:"type=ID"`
+	Next *recLevel2 `fraiseql:"nullable=true"`
+}
+
+type recLevel2 struct {
+	ID   int `fraiseql:"type=ID"`
+	Next []recLevel3
+}
+
+type recLevel3 struct {
+	ID   int `fraiseql:"type=ID"`
+	Next recLevel4
+}
+
+type recLevel4 struct {
+	ID        int `fraiseql:"type=ID"`
+	CreatedAt time.Time
+	Extra     recLevel5 `fraiseql:"type=Json"`
+}
+
+type recLevel5 struct {
+	Key string
+}
+
+type recAuthor struct {
+	ID    int `fraiseql:"type=ID"`
+	Posts []recPost
+}
+
+type recPost struct {
+	ID     int        `fraiseql:"type=ID"`
+	Author *recAuthor `fraiseql:"nullable=true"`
+}
+
+func TestRegisterTypesRecursive(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypesRecursive(recLevel1{}); err != nil {
+		t.Fatalf("RegisterTypesRecursive: %v", err)
+	}
+	if got := CountTypes(); got != 4 {
+		t.Errorf("CountTypes() = %d, want 4 (time.Time and type=Json fields are not followed)", got)
+	}
+	schema := GetSchema()
+	for _, name := range []string{"recLevel1", "recLevel2", "recLevel3", "recLevel4"} {
+		found := false
+		for _, typ := range schema.Types {
+			if typ.Name == name {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("type %q was not registered", name)
+		}
+	}
+}
+
+func TestRegisterTypesRecursiveCycle(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypesRecursive(recAuthor{}); err != nil {
+		t.Fatalf("RegisterTypesRecursive: %v", err)
+	}
+	if got := CountTypes(); got != 2 {
+		t.Errorf("CountTypes() = %d, want 2", got)
+	}
+}
+
+func TestRegisterTypesRecursiveMaxDepth(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxDepth int
+		wantErr  string
+	}{
+		{name: "unlimited", maxDepth: 0},
+		{name: "exact depth", maxDepth: 3},
+		{
+			name:     "too shallow",
+			maxDepth: 2,
+			wantErr:  "type nesting exceeds MaxDepth 2 at recLevel1.Next -> recLevel2.Next -> recLevel3.Next -> recLevel4",
+		},
+		{name: "negative", maxDepth: -1, wantErr: "must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := RegisterTypesRecursiveWithOptions(RecursiveOptions{MaxDepth: tt.maxDepth}, recLevel1{})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+			if got := CountTypes(); got != 0 {
+				t.Errorf("CountTypes() = %d after a rejected registration, want 0", got)
+			}
+		})
+	}
+}
+
+func TestRegisterTypesRecursiveRejectsNonStruct(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypesRecursive("not a struct"); err == nil {
+		t.Fatal("expected an error for a non-struct type")
+	}
+}
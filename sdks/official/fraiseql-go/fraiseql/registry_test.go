@@ -1,7 +1,10 @@
 package fraiseql
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -360,6 +363,21 @@ func TestDuplicateRegistrationErrors(t *testing.T) {
 		}
 	})
 
+	t.Run("RegisterTypes returns error for a conflicting type", func(t *testing.T) {
+		Reset()
+		if err := RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+			t.Fatalf("RegisterType: %v", err)
+		}
+
+		type User struct {
+			Email string `fraiseql:"email"`
+		}
+		err := RegisterTypes(User{})
+		if err == nil || !strings.Contains(err.Error(), "already registered") {
+			t.Errorf("expected an 'already registered' error, got: %v", err)
+		}
+	})
+
 	t.Run("RegisterQuery returns error for duplicate", func(t *testing.T) {
 		Reset()
 		def := QueryDefinition{Name: "getUser", ReturnType: "User", ReturnsList: false, Nullable: true}
@@ -408,3 +426,302 @@ func TestDuplicateRegistrationErrors(t *testing.T) {
 		}
 	})
 }
+
+func TestIdenticalReRegistrationIsNoOp(t *testing.T) {
+	type Account struct {
+		ID    ID     `fraiseql:"id"`
+		Email string `fraiseql:"email"`
+	}
+
+	t.Run("RegisterType accepts an identical definition", func(t *testing.T) {
+		Reset()
+		defer Reset()
+		fields := []FieldInfo{{Name: "id", Type: "ID"}, {Name: "name", Type: "String"}}
+
+		if err := RegisterType("User", fields, "A user"); err != nil {
+			t.Fatalf("first registration should succeed, got: %v", err)
+		}
+		if err := RegisterType("User", fields, "A user"); err != nil {
+			t.Fatalf("identical re-registration should succeed, got: %v", err)
+		}
+		if n := len(GetSchema().Types); n != 1 {
+			t.Errorf("expected 1 type after re-registration, got %d", n)
+		}
+	})
+
+	t.Run("RegisterType rejects differing fields", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		if err := RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+			t.Fatalf("first registration should succeed, got: %v", err)
+		}
+		err := RegisterType("User", []FieldInfo{{Name: "id", Type: "ID", Nullable: true}}, "")
+		if err == nil || !strings.Contains(err.Error(), "different definition") {
+			t.Errorf("expected conflict error, got: %v", err)
+		}
+	})
+
+	t.Run("RegisterTypes on the same struct twice", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		if err := RegisterTypes(Account{}); err != nil {
+			t.Fatalf("first RegisterTypes should succeed, got: %v", err)
+		}
+		if err := RegisterTypes(Account{}); err != nil {
+			t.Errorf("repeated RegisterTypes should succeed, got: %v", err)
+		}
+	})
+
+	t.Run("error and regular types with the same name conflict", func(t *testing.T) {
+		Reset()
+		defer Reset()
+		fields := []FieldInfo{{Name: "message", Type: "String"}}
+
+		if err := RegisterType("Failure", fields, ""); err != nil {
+			t.Fatalf("first registration should succeed, got: %v", err)
+		}
+		if err := RegisterErrorType("Failure", fields, ""); err == nil {
+			t.Error("expected conflict when an error type reuses a regular type's name")
+		}
+	})
+}
+
+// cancelAfterCtx reports cancellation once Err has been called n times.
+type cancelAfterCtx struct {
+	context.Context
+	calls int
+	n     int
+}
+
+func (c *cancelAfterCtx) Err() error {
+	c.calls++
+	if c.calls > c.n {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestRegisterTypesCtx(t *testing.T) {
+	type A struct {
+		ID ID `fraiseql:"id"`
+	}
+	type B struct {
+		ID ID `fraiseql:"id"`
+	}
+	type C struct {
+		ID ID `fraiseql:"id"`
+	}
+
+	t.Run("registers all types", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		if err := RegisterTypesCtx(context.Background(), A{}, B{}, C{}); err != nil {
+			t.Fatalf("RegisterTypesCtx: %v", err)
+		}
+		if got := len(GetSchema().Types); got != 3 {
+			t.Errorf("expected 3 types, got %d", got)
+		}
+	})
+
+	t.Run("stops when cancelled partway", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		ctx := &cancelAfterCtx{Context: context.Background(), n: 2}
+		err := RegisterTypesCtx(ctx, A{}, B{}, C{})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+		if got := len(GetSchema().Types); got != 2 {
+			t.Errorf("expected 2 types registered before cancellation, got %d", got)
+		}
+	})
+
+	t.Run("already cancelled context registers nothing", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := RegisterTypesCtx(ctx, A{}); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+		if got := len(GetSchema().Types); got != 0 {
+			t.Errorf("expected no types, got %d", got)
+		}
+	})
+}
+
+func TestRegisterReflectTypes(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Author struct {
+		ID   ID     `fraiseql:"id"`
+		Name string `fraiseql:"name"`
+	}
+	type Book struct {
+		ID    ID     `fraiseql:"id"`
+		Title string `fraiseql:"title"`
+	}
+
+	if err := RegisterReflectTypes(reflect.TypeOf(Author{}), reflect.TypeOf((*Book)(nil))); err != nil {
+		t.Fatalf("RegisterReflectTypes: %v", err)
+	}
+	schema := GetSchema()
+	if len(schema.Types) != 2 || schema.Types[0].Name != "Author" || schema.Types[1].Name != "Book" {
+		t.Fatalf("expected Author and Book, got %+v", schema.Types)
+	}
+	if schema.Types[1].SqlSource != "v_book" || schema.Types[1].Fields[1].Name != "title" {
+		t.Errorf("expected the same extraction as RegisterTypes, got %+v", schema.Types[1])
+	}
+
+	// The same type registered through RegisterTypes is an identical re-registration.
+	if err := RegisterTypes(Author{}); err != nil {
+		t.Errorf("RegisterTypes after RegisterReflectTypes: %v", err)
+	}
+	if err := RegisterReflectTypes(reflect.TypeOf(0)); err == nil {
+		t.Error("expected error for a non-struct type")
+	}
+	if err := RegisterReflectTypes(nil); err == nil {
+		t.Error("expected error for a nil type")
+	}
+}
+
+func TestRegistryCounts(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	counts := func() []int {
+		return []int{
+			CountTypes(), CountEnums(), CountInputTypes(), CountQueries(), CountMutations(),
+			CountSubscriptions(), CountFactTables(), CountAggregateQueries(), CountObservers(),
+		}
+	}
+	for i, n := range counts() {
+		if n != 0 {
+			t.Errorf("count %d: expected 0 after Reset, got %d", i, n)
+		}
+	}
+
+	type User struct {
+		ID ID `fraiseql:"id"`
+	}
+	if err := RegisterTypes(User{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := RegisterErrorType("NotFound", []FieldInfo{{Name: "message", Type: "String"}}, ""); err != nil {
+		t.Fatalf("RegisterErrorType: %v", err)
+	}
+	Enum("Role", map[string]string{"ADMIN": "admin"})
+	if err := NewInputType("UserInput").Field("name", "String").Register(); err != nil {
+		t.Fatalf("Register input: %v", err)
+	}
+	if err := NewQuery("users").ReturnType(User{}).ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("Register query: %v", err)
+	}
+	if err := NewQuery("user").ReturnType(User{}).Register(); err != nil {
+		t.Fatalf("Register query: %v", err)
+	}
+	if err := NewMutation("createUser").ReturnType(User{}).Register(); err != nil {
+		t.Fatalf("Register mutation: %v", err)
+	}
+
+	schema := GetSchema()
+	want := []int{
+		len(schema.Types), len(schema.Enums), len(schema.InputTypes), len(schema.Queries), len(schema.Mutations),
+		len(schema.Subscriptions), len(schema.FactTables), len(schema.AggregateQueries), len(schema.Observers),
+	}
+	if got := counts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("counts: want %v (from GetSchema), got %v", want, got)
+	}
+	if CountTypes() != 2 || CountQueries() != 2 {
+		t.Errorf("expected 2 types and 2 queries, got %d and %d", CountTypes(), CountQueries())
+	}
+
+	Reset()
+	if CountTypes() != 0 || CountQueries() != 0 || CountMutations() != 0 {
+		t.Error("expected counts to return to 0 after Reset")
+	}
+}
+
+func TestResetSchemaKeepsConfiguration(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Account struct {
+		ID    ID     `fraiseql:"id"`
+		Email string `fraiseql:"email"`
+	}
+	setup := func() {
+		RegisterCustomScalar(&EmailScalar{})
+		RegisterTypeMapper(func(goType reflect.Type) (string, bool, bool) { return "", false, false })
+		RegisterValidator("naming", SeverityWarning, func(Schema) []error {
+			return []error{errors.New("always warns")}
+		})
+		SetDefaultNullable(true)
+		if err := RegisterTypes(Account{}); err != nil {
+			t.Fatalf("RegisterTypes: %v", err)
+		}
+		if err := NewQuery("accounts").ReturnType("Account").ReturnsArray(true).Register(); err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+		if err := SetSchemaInfo(SchemaInfo{Title: "Accounts"}); err != nil {
+			t.Fatalf("SetSchemaInfo: %v", err)
+		}
+	}
+
+	t.Run("ResetSchema", func(t *testing.T) {
+		setup()
+		ResetSchema()
+
+		schema := GetSchema()
+		if len(schema.Types) != 0 || len(schema.Queries) != 0 || schema.Info != nil {
+			t.Errorf("schema elements should be cleared, got %d types, %d queries, info %v",
+				len(schema.Types), len(schema.Queries), schema.Info)
+		}
+		if !HasCustomScalar((&EmailScalar{}).Name()) {
+			t.Error("custom scalar should be kept")
+		}
+		typeMappers.mu.RLock()
+		mappers := len(typeMappers.mappers)
+		typeMappers.mu.RUnlock()
+		if mappers != 1 {
+			t.Errorf("type mappers = %d, want 1", mappers)
+		}
+		if report := Validate(); len(report.Warnings) == 0 {
+			t.Error("registered validator should still run")
+		}
+		if !isDefaultNullable() {
+			t.Error("SetDefaultNullable should be kept")
+		}
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		Reset()
+		setup()
+		Reset()
+
+		if len(GetSchema().Types) != 0 {
+			t.Error("types should be cleared")
+		}
+		if HasCustomScalar((&EmailScalar{}).Name()) {
+			t.Error("custom scalar should be cleared")
+		}
+		typeMappers.mu.RLock()
+		mappers := len(typeMappers.mappers)
+		typeMappers.mu.RUnlock()
+		if mappers != 0 {
+			t.Errorf("type mappers = %d, want 0", mappers)
+		}
+		if report := Validate(); len(report.Warnings) != 0 {
+			t.Errorf("registered validator should be removed, got %v", report.Warnings)
+		}
+		if isDefaultNullable() {
+			t.Error("SetDefaultNullable should be restored")
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package fraiseql
+
+import "fmt"
+
+// RLSConfig is the row-level security configuration of a tenant-scoped type.
+// The compiler adds a filter on TenantField against the request's tenant to
+// every query that reads the type.
+type RLSConfig struct {
+	TenantField string `json:"tenant_field"`
+}
+
+// TenantScoped marks a registered type as tenant-scoped on field, as a
+// `tenantKey=true` tag on the field would. The type is exported with an "rls"
+// config naming the field, so the compiler injects tenant filtering instead of
+// each query spelling out the WHERE clause.
+//
+// Returns an error if the type is not registered, has no such field, or
+// already has a different tenant key field.
+//
+// Example:
+//
+//	fraiseql.RegisterTypes(Invoice{})
+//	fraiseql.TenantScoped("Invoice", "tenantId")
+func TenantScoped(typeName, field string) error {
+	return getInstance().declareType("TenantScoped", typeName, func(def TypeDefinition) (TypeDefinition, error) {
+		def.RLS = &RLSConfig{TenantField: field}
+		return applyTenantKey(def)
+	})
+}
+
+// applyTenantKey checks that def has at most one tenant key field and derives
+// its RLS config from it. When def.RLS already names a tenant field, that
+// field becomes the tenant key. The caller's Fields slice is copied, not
+// modified.
+func applyTenantKey(def TypeDefinition) (TypeDefinition, error) {
+	var keys []string
+	for _, f := range def.Fields {
+		if f.TenantKey {
+			keys = append(keys, f.Name)
+		}
+	}
+	if def.RLS != nil {
+		if len(keys) == 0 || keys[0] != def.RLS.TenantField {
+			keys = append(keys, def.RLS.TenantField)
+		}
+	}
+	switch {
+	case len(keys) == 0:
+		return def, nil
+	case len(keys) > 1:
+		return def, fmt.Errorf(
+			"type %q has more than one tenant key field (%q and %q); a tenant-scoped type needs exactly one",
+			def.Name, keys[0], keys[1],
+		)
+	}
+
+	fields := make([]FieldInfo, len(def.Fields))
+	copy(fields, def.Fields)
+	found := false
+	for i := range fields {
+		if fields[i].Name == keys[0] {
+			fields[i].TenantKey = true
+			found = true
+		}
+	}
+	if !found {
+		return def, fmt.Errorf("type %q is tenant-scoped on field %q, but has no such field", def.Name, keys[0])
+	}
+	def.Fields = fields
+	def.RLS = &RLSConfig{TenantField: keys[0]}
+	return def, nil
+}
@@ -0,0 +1,163 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTenantKeyTag(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Invoice struct {
+		ID       ID     `fraiseql:"id"`
+		TenantID ID     `fraiseql:"tenantId,tenantKey=true"`
+		Number   string `fraiseql:"number"`
+	}
+	if err := RegisterTypes(Invoice{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	var exported struct {
+		Types []struct {
+			RLS    map[string]string `json:"rls"`
+			Fields []FieldInfo       `json:"fields"`
+		} `json:"types"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	invoice := exported.Types[0]
+	if invoice.RLS["tenant_field"] != "tenantId" {
+		t.Errorf("rls: want tenant_field tenantId, got %v", invoice.RLS)
+	}
+	if !invoice.Fields[1].TenantKey || invoice.Fields[0].TenantKey {
+		t.Errorf("expected only tenantId to be the tenant key, got %+v", invoice.Fields)
+	}
+}
+
+func TestTenantScoped(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterType("Order", []FieldInfo{
+		{Name: "id", Type: "ID"},
+		{Name: "orgId", Type: "ID"},
+	}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := TenantScoped("Order", "orgId"); err != nil {
+		t.Fatalf("TenantScoped: %v", err)
+	}
+
+	order := GetSchema().Types[0]
+	if order.RLS == nil || order.RLS.TenantField != "orgId" {
+		t.Errorf("rls: want tenant_field orgId, got %+v", order.RLS)
+	}
+	if !order.Fields[1].TenantKey {
+		t.Error("TenantScoped should mark the field as the tenant key")
+	}
+	if err := TenantScoped("Order", "orgId"); err != nil {
+		t.Errorf("repeating TenantScoped with the same field should succeed, got %v", err)
+	}
+}
+
+func TestTenantScopedThenReRegister(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Account struct {
+		ID    ID `fraiseql:"id"`
+		OrgID ID `fraiseql:"orgId"`
+	}
+	if err := RegisterTypes(Account{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := TenantScoped("Account", "orgId"); err != nil {
+		t.Fatalf("TenantScoped: %v", err)
+	}
+	if err := RegisterTypes(Account{}); err != nil {
+		t.Fatalf("registering the same struct again should be a no-op, got %v", err)
+	}
+	if rls := GetSchema().Types[0].RLS; rls == nil || rls.TenantField != "orgId" {
+		t.Errorf("re-registering should keep the tenant scope, got %+v", rls)
+	}
+
+	// The declaration travels with a snapshot.
+	snapshot := SnapshotRegistry()
+	Reset()
+	UseRegistry(snapshot)
+	if err := RegisterTypes(Account{}); err != nil {
+		t.Errorf("re-registering into a restored snapshot should be a no-op, got %v", err)
+	}
+
+	if err := RegisterType("Account", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err == nil {
+		t.Error("a different definition should still be rejected")
+	}
+}
+
+func TestTenantScopedValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func() error
+		wantErr string
+	}{
+		{
+			name: "two tenant key fields",
+			setup: func() error {
+				type Shared struct {
+					OrgID    ID `fraiseql:"orgId,tenantKey=true"`
+					TenantID ID `fraiseql:"tenantId,tenantKey=true"`
+				}
+				return RegisterTypes(Shared{})
+			},
+			wantErr: `more than one tenant key field ("orgId" and "tenantId")`,
+		},
+		{
+			name: "TenantScoped on a different field than the tag",
+			setup: func() error {
+				type Shared struct {
+					OrgID    ID `fraiseql:"orgId,tenantKey=true"`
+					TenantID ID `fraiseql:"tenantId"`
+				}
+				if err := RegisterTypes(Shared{}); err != nil {
+					return err
+				}
+				return TenantScoped("Shared", "tenantId")
+			},
+			wantErr: "more than one tenant key field",
+		},
+		{
+			name: "missing field",
+			setup: func() error {
+				if err := RegisterType("Order", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+					return err
+				}
+				return TenantScoped("Order", "orgId")
+			},
+			wantErr: `tenant-scoped on field "orgId", but has no such field`,
+		},
+		{
+			name:    "unregistered type",
+			setup:   func() error { return TenantScoped("Order", "orgId") },
+			wantErr: `type "Order" is not registered`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := tt.setup()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -7,18 +7,22 @@ import (
 
 // customScalarRegistry is the global registry for custom scalars.
 type customScalarRegistry struct {
-	mu      sync.RWMutex
-	scalars map[string]CustomScalar
+	mu          sync.RWMutex
+	scalars     map[string]CustomScalar
+	specifiedBy map[string]string
 }
 
 // Global instance
 var scalarRegistry = &customScalarRegistry{
-	scalars: make(map[string]CustomScalar),
+	scalars:     make(map[string]CustomScalar),
+	specifiedBy: make(map[string]string),
 }
 
 // RegisterCustomScalar registers a custom scalar with the global registry.
 //
-// The scalar must have a non-empty name returned by Name().
+// The scalar must have a non-empty name returned by Name(). An optional
+// specifiedByURL sets the scalar's `@specifiedBy` URL, exported as
+// "specified_by"; it takes precedence over a SpecifiedByURL method.
 //
 // Example:
 //
@@ -29,23 +33,30 @@ var scalarRegistry = &customScalarRegistry{
 //
 //	func init() {
 //	    RegisterCustomScalar(&Email{})
+//	    RegisterCustomScalar(&IBAN{}, "https://www.iso.org/standard/81090.html")
 //	}
 //
 // Panics if a scalar with the same name is already registered.
-func RegisterCustomScalar(scalar CustomScalar) {
+func RegisterCustomScalar(scalar CustomScalar, specifiedByURL ...string) {
 	name := scalar.Name()
 	if name == "" {
 		panic("CustomScalar must have a non-empty name")
 	}
 
 	scalarRegistry.mu.Lock()
-	defer scalarRegistry.mu.Unlock()
+	_, exists := scalarRegistry.scalars[name]
+	if !exists {
+		scalarRegistry.scalars[name] = scalar
+		if len(specifiedByURL) > 0 && specifiedByURL[0] != "" {
+			scalarRegistry.specifiedBy[name] = specifiedByURL[0]
+		}
+	}
+	scalarRegistry.mu.Unlock()
 
-	if _, exists := scalarRegistry.scalars[name]; exists {
+	if exists {
 		panic(fmt.Sprintf("Scalar \"%s\" is already registered", name))
 	}
-
-	scalarRegistry.scalars[name] = scalar
+	notifyRegistered("scalar", name)
 }
 
 // GetCustomScalar retrieves a registered custom scalar by name.
@@ -85,6 +96,7 @@ func UnregisterCustomScalar(name string) {
 	scalarRegistry.mu.Lock()
 	defer scalarRegistry.mu.Unlock()
 	delete(scalarRegistry.scalars, name)
+	delete(scalarRegistry.specifiedBy, name)
 }
 
 // ClearCustomScalars clears all registered custom scalars (useful for testing).
@@ -92,4 +104,5 @@ func ClearCustomScalars() {
 	scalarRegistry.mu.Lock()
 	defer scalarRegistry.mu.Unlock()
 	scalarRegistry.scalars = make(map[string]CustomScalar)
+	scalarRegistry.specifiedBy = make(map[string]string)
 }
@@ -333,7 +333,88 @@ var ScalarNames = map[string]bool{
 	"LTree": true,
 }
 
-// IsScalarType checks if a type name is a known scalar type.
+// scalarSpecURLs seeds `@specifiedBy` URLs for FraiseQL scalars that follow a
+// published specification.
+var scalarSpecURLs = map[string]string{
+	"DateTime":        "https://scalars.graphql.org/andimarek/date-time",
+	"Date":            "https://scalars.graphql.org/andimarek/local-date",
+	"Time":            "https://scalars.graphql.org/andimarek/local-time",
+	"UUID":            "https://tools.ietf.org/html/rfc4122",
+	"Json":            "https://www.ecma-international.org/publications/files/ECMA-ST/ECMA-404.pdf",
+	"Email":           "https://tools.ietf.org/html/rfc5322",
+	"URL":             "https://tools.ietf.org/html/rfc3986",
+	"DomainName":      "https://tools.ietf.org/html/rfc1035",
+	"Hostname":        "https://tools.ietf.org/html/rfc1123",
+	"PhoneNumber":     "https://www.itu.int/rec/T-REC-E.164",
+	"LocaleCode":      "https://tools.ietf.org/html/rfc5646",
+	"Timezone":        "https://www.iana.org/time-zones",
+	"CountryCode":     "https://www.iso.org/iso-3166-country-codes.html",
+	"CurrencyCode":    "https://www.iso.org/iso-4217-currency-codes.html",
+	"SemanticVersion": "https://semver.org/spec/v2.0.0.html",
+	"HashSHA256":      "https://tools.ietf.org/html/rfc6234",
+	"IPv4":            "https://tools.ietf.org/html/rfc791",
+	"IPv6":            "https://tools.ietf.org/html/rfc4291",
+	"CIDR":            "https://tools.ietf.org/html/rfc4632",
+	"MimeType":        "https://tools.ietf.org/html/rfc6838",
+	"Markdown":        "https://spec.commonmark.org/",
+	"LTree":           "https://www.postgresql.org/docs/current/ltree.html",
+}
+
+// ScalarSpecifiedByURL returns the `@specifiedBy` URL for a scalar, or "" if
+// it has none. The URL passed to RegisterCustomScalar comes first, then a
+// registered custom scalar implementing SpecifiedByScalar, then the URLs
+// seeded for FraiseQL's own scalars.
+func ScalarSpecifiedByURL(name string) string {
+	scalarRegistry.mu.RLock()
+	url := scalarRegistry.specifiedBy[name]
+	scalarRegistry.mu.RUnlock()
+	if url != "" {
+		return url
+	}
+	if scalar, ok := GetCustomScalar(name).(SpecifiedByScalar); ok {
+		if url := scalar.SpecifiedByURL(); url != "" {
+			return url
+		}
+	}
+	return scalarSpecURLs[name]
+}
+
+// scalarBaseTypes records the base kind of the scalars that are not
+// string-based, matching their Go marker types (type Port int, type Latitude
+// float64, ...); every other FraiseQL scalar is a string on the wire.
+var scalarBaseTypes = map[string]string{
+	"Int":        "int",
+	"Float":      "float",
+	"Boolean":    "boolean",
+	"Port":       "int",
+	"Latitude":   "float",
+	"Longitude":  "float",
+	"Percentage": "float",
+	"Vector":     "list",
+	"Json":       "json",
+}
+
+// ScalarBaseType returns the base kind of a built-in GraphQL or FraiseQL
+// scalar: "int", "float", "boolean", "string", "list" (Vector, a list of
+// floats) or "json" (arbitrary JSON). Port is int-based and Latitude
+// float-based, for example, so tooling can infer numeric constraints or pick
+// the generated type. It returns "" for names that are not known scalars,
+// including custom scalars, whose representation the SDK does not know.
+func ScalarBaseType(name string) string {
+	if base, ok := scalarBaseTypes[name]; ok {
+		return base
+	}
+	if IsScalarType(name) {
+		return "string"
+	}
+	return ""
+}
+
+// IsScalarType checks if a type name is a built-in GraphQL scalar
+// (String, Int, Float, Boolean, ID) or a known FraiseQL scalar type.
 func IsScalarType(typeName string) bool {
+	if _, ok := builtinScalars[typeName]; ok {
+		return true
+	}
 	return ScalarNames[typeName]
 }
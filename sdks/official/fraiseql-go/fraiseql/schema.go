@@ -1,71 +1,125 @@
 package fraiseql
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 )
 
 var builtinScalars = map[string]struct{}{
 	"String": {}, "Int": {}, "Float": {}, "Boolean": {}, "ID": {},
 }
 
-// validateSchemaBeforeExport checks that all query and mutation return types
-// refer to registered types, returning a descriptive error if not.
+// validateSchemaBeforeExport runs the ValidateSchema checks against the
+// assembled schema, returning a single descriptive error listing every problem.
 func validateSchemaBeforeExport(schema Schema) error {
-	registeredNames := make(map[string]struct{})
-	for _, t := range schema.Types {
-		registeredNames[t.Name] = struct{}{}
+	errs := validateSchema(schema)
+	if len(errs) == 0 {
+		return nil
 	}
-	for _, e := range schema.Enums {
-		registeredNames[e.Name] = struct{}{}
-	}
-	for k, v := range builtinScalars {
-		registeredNames[k] = v
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
 	}
+	return fmt.Errorf(
+		"schema validation failed before export. Fix the following errors:\n  - %s",
+		strings.Join(msgs, "\n  - "),
+	)
+}
 
-	var errs []string
+// emptySchemaPolicy holds the setting made with AllowEmptySchema.
+var emptySchemaPolicy struct {
+	mu    sync.RWMutex
+	allow bool
+}
 
-	for _, q := range schema.Queries {
-		if _, ok := registeredNames[q.ReturnType]; !ok && q.ReturnType != "" {
-			errs = append(errs, fmt.Sprintf(
-				"query %q has return type %q which is not a registered type", q.Name, q.ReturnType,
-			))
-		}
-	}
-	for _, m := range schema.Mutations {
-		if _, ok := registeredNames[m.ReturnType]; !ok && m.ReturnType != "" {
-			errs = append(errs, fmt.Sprintf(
-				"mutation %q has return type %q which is not a registered type", m.Name, m.ReturnType,
-			))
-		}
-	}
+// AllowEmptySchema sets whether ExportSchema may write a schema with no types
+// and no operations. By default (false) it returns an error instead, since an
+// empty registry usually means the package that registers the schema was
+// never imported. Reset() restores the default.
+func AllowEmptySchema(allow bool) {
+	emptySchemaPolicy.mu.Lock()
+	defer emptySchemaPolicy.mu.Unlock()
+	emptySchemaPolicy.allow = allow
+}
+
+// checkNotEmpty returns an error for a schema with no types and no operations,
+// unless AllowEmptySchema(true) is set.
+func checkNotEmpty(schema Schema) error {
+	emptySchemaPolicy.mu.RLock()
+	allow := emptySchemaPolicy.allow
+	emptySchemaPolicy.mu.RUnlock()
 
-	if len(errs) > 0 {
+	empty := len(schema.Types) == 0 && len(schema.Queries) == 0 && len(schema.Mutations) == 0 &&
+		len(schema.Subscriptions) == 0 && len(schema.AggregateQueries) == 0
+	if empty && !allow {
 		return fmt.Errorf(
-			"schema validation failed before export. Fix the following errors:\n  - %s",
-			strings.Join(errs, "\n  - "),
+			"refusing to export an empty schema: no types or operations are registered; " +
+				"check that the package registering them is imported, or call AllowEmptySchema(true)",
 		)
 	}
 	return nil
 }
 
+// writeFileAtomic writes data to a temporary file in the directory of path and
+// renames it into place, so readers never see a partially written file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
 // ExportSchema exports the schema registry to a JSON file
-// Returns error if file cannot be written
+// Returns error if file cannot be written, or if the schema is empty (see
+// AllowEmptySchema). The file is written atomically: it is either the complete
+// new schema or left as it was.
 func ExportSchema(outputPath string) error {
-	schema := GetSchema()
+	schema, err := buildSchema()
+	if err != nil {
+		return err
+	}
+	if err := checkNotEmpty(schema); err != nil {
+		return err
+	}
 	if err := validateSchemaBeforeExport(schema); err != nil {
 		return err
 	}
+	if err := validateReportBeforeExport(schema); err != nil {
+		return err
+	}
 
-	schemaJSON, err := GetSchemaJSON(true)
+	schemaJSON, err := json.MarshalIndent(schema, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal schema to JSON: %w", err)
 	}
 
 	// Write to file
-	err = os.WriteFile(outputPath, schemaJSON, 0o644)
+	err = writeFileAtomic(outputPath, schemaJSON, 0o644)
 	if err != nil {
 		return fmt.Errorf("failed to write schema file: %w", err)
 	}
@@ -91,6 +145,22 @@ func ExportSchemaRaw(pretty bool) ([]byte, error) {
 	return GetSchemaJSON(pretty)
 }
 
+// SchemaHash returns a stable fingerprint of the registered schema: the
+// hex-encoded SHA-256 of its canonical JSON. GetSchema orders every section by
+// name, so the hash changes only when the schema itself changes — not when
+// registration order or map iteration order differs between runs.
+// Useful for cache invalidation and deployment gating.
+// SchemaHash panics if a schema transform fails, since there is no schema to
+// fingerprint; call GetSchemaJSON first to handle the error.
+func SchemaHash() string {
+	schemaJSON, err := GetSchemaJSON(false)
+	if err != nil {
+		panic(err)
+	}
+	sum := sha256.Sum256(schemaJSON)
+	return hex.EncodeToString(sum[:])
+}
+
 // MarshalJSON implements json.Marshaler for the Schema type
 // This ensures proper JSON formatting
 func (s Schema) MarshalJSON() ([]byte, error) {
@@ -107,17 +177,63 @@ func (s Schema) MarshalJSON() ([]byte, error) {
 // and configuration (queries, mutations, etc.) comes from fraiseql.toml
 // The pretty parameter controls JSON formatting
 func ExportTypes(pretty bool) ([]byte, error) {
-	schema := GetSchema()
+	schema, err := buildSchema()
+	if err != nil {
+		return nil, err
+	}
+	return exportSections(schema, []string{"types"}, pretty)
+}
 
-	// Build minimal schema with only types
-	minimalSchema := map[string]interface{}{
-		"types": schema.Types,
+// schemaSections maps each exportable section name to its part of the schema.
+var schemaSections = map[string]func(Schema) interface{}{
+	"types":             func(s Schema) interface{} { return s.Types },
+	"queries":           func(s Schema) interface{} { return s.Queries },
+	"mutations":         func(s Schema) interface{} { return s.Mutations },
+	"observers":         func(s Schema) interface{} { return s.Observers },
+	"subscriptions":     func(s Schema) interface{} { return s.Subscriptions },
+	"fact_tables":       func(s Schema) interface{} { return s.FactTables },
+	"aggregate_queries": func(s Schema) interface{} { return s.AggregateQueries },
+	"enums":             func(s Schema) interface{} { return s.Enums },
+}
+
+// ExportSection exports only the named schema sections as indented JSON, for
+// partial compiles. Valid names are types, queries, mutations, observers,
+// subscriptions, fact_tables, aggregate_queries and enums; the output holds
+// exactly the requested keys. ExportSection("types") matches ExportTypes(true).
+// Returns an error if no section or an unknown section is requested.
+func ExportSection(sections ...string) ([]byte, error) {
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("ExportSection: at least one section name is required")
+	}
+	schema, err := buildSchema()
+	if err != nil {
+		return nil, err
+	}
+	return exportSections(schema, sections, true)
+}
+
+func exportSections(schema Schema, sections []string, pretty bool) ([]byte, error) {
+	partial := make(map[string]interface{}, len(sections))
+	for _, name := range sections {
+		section, ok := schemaSections[name]
+		if !ok {
+			valid := make([]string, 0, len(schemaSections))
+			for n := range schemaSections {
+				valid = append(valid, n)
+			}
+			sort.Strings(valid)
+			return nil, fmt.Errorf(
+				"unknown schema section %q; must be one of %s",
+				name, strings.Join(valid, ", "),
+			)
+		}
+		partial[name] = section(schema)
 	}
 
 	if pretty {
-		return json.MarshalIndent(minimalSchema, "", "  ")
+		return json.MarshalIndent(partial, "", "  ")
 	}
-	return json.Marshal(minimalSchema)
+	return json.Marshal(partial)
 }
 
 // ExportTypesFile exports types to a file using ExportTypes()
@@ -128,7 +244,7 @@ func ExportTypesFile(outputPath string) error {
 	}
 
 	// Write to file
-	err = os.WriteFile(outputPath, typesJSON, 0o644)
+	err = writeFileAtomic(outputPath, typesJSON, 0o644)
 	if err != nil {
 		return fmt.Errorf("failed to write types file: %w", err)
 	}
@@ -0,0 +1,330 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SchemaChange is one difference between two schemas.
+type SchemaChange struct {
+	// Change is "added", "removed" or "changed".
+	Change string `json:"change"`
+	// Element names what changed, e.g. "type User", "field User.email" or
+	// "argument users.limit".
+	Element string `json:"element"`
+	// Description explains the change, e.g. "type changed from Int to String".
+	Description string `json:"description"`
+	// Breaking reports whether clients written against the old schema may
+	// fail against the new one.
+	Breaking bool `json:"breaking"`
+}
+
+// DiffSchemas compares two schemas and returns their differences, sorted by
+// element. A change is breaking when an existing client could fail: removing
+// a type, field, operation, argument or enum value; changing a type; making an
+// output field or operation result nullable; or requiring a new or existing
+// argument or input field. Additions and relaxed inputs are not breaking.
+func DiffSchemas(old, new Schema) []SchemaChange {
+	d := &schemaDiff{}
+
+	definitions(d, "type", namedTypes(old.Types), namedTypes(new.Types), func(name string, o, n TypeDefinition) {
+		d.fields("field", name, o.Fields, n.Fields, false)
+	})
+	definitions(d, "input type", namedInputTypes(old.InputTypes), namedInputTypes(new.InputTypes), func(name string, o, n InputTypeDefinition) {
+		d.fields("input field", name, o.Fields, n.Fields, true)
+	})
+	definitions(d, "enum", namedEnums(old.Enums), namedEnums(new.Enums), func(name string, o, n EnumDefinition) {
+		oldValues := make(map[string]bool, len(o.Values))
+		for _, v := range o.Values {
+			oldValues[v.Name] = true
+		}
+		newValues := make(map[string]bool, len(n.Values))
+		for _, v := range n.Values {
+			newValues[v.Name] = true
+		}
+		for _, v := range sortedKeys(oldValues) {
+			if !newValues[v] {
+				d.add("removed", "enum value "+name+"."+v, "", true)
+			}
+		}
+		for _, v := range sortedKeys(newValues) {
+			if !oldValues[v] {
+				d.add("added", "enum value "+name+"."+v, "", false)
+			}
+		}
+	})
+	definitions(d, "query", namedQueries(old.Queries), namedQueries(new.Queries), func(name string, o, n QueryDefinition) {
+		d.result("query", name, o.ReturnType, o.ReturnsList, o.Nullable, n.ReturnType, n.ReturnsList, n.Nullable)
+		d.arguments(name, o.Arguments, n.Arguments)
+	})
+	definitions(d, "mutation", namedMutations(old.Mutations), namedMutations(new.Mutations), func(name string, o, n MutationDefinition) {
+		d.result("mutation", name, o.ReturnType, o.ReturnsList, o.Nullable, n.ReturnType, n.ReturnsList, n.Nullable)
+		d.arguments(name, o.Arguments, n.Arguments)
+	})
+	definitions(d, "subscription", namedSubscriptions(old.Subscriptions), namedSubscriptions(new.Subscriptions), func(name string, o, n SubscriptionDefinition) {
+		d.result("subscription", name, o.EntityType, false, o.Nullable, n.EntityType, false, n.Nullable)
+		d.arguments(name, o.Arguments, n.Arguments)
+	})
+
+	sort.SliceStable(d.changes, func(i, j int) bool { return d.changes[i].Element < d.changes[j].Element })
+	return d.changes
+}
+
+// schemaDiff accumulates the changes found by DiffSchemas.
+type schemaDiff struct {
+	changes []SchemaChange
+}
+
+func (d *schemaDiff) add(change, element, description string, breaking bool) {
+	d.changes = append(d.changes, SchemaChange{Change: change, Element: element, Description: description, Breaking: breaking})
+}
+
+// definitions reports the names only in old as removed and only in new as
+// added, and calls compare for the names in both.
+func definitions[T any](d *schemaDiff, kind string, old, new map[string]T, compare func(name string, o, n T)) {
+	for _, name := range sortedKeys(old) {
+		n, exists := new[name]
+		if !exists {
+			d.add("removed", kind+" "+name, "", true)
+			continue
+		}
+		compare(name, old[name], n)
+	}
+	for _, name := range sortedKeys(new) {
+		if _, exists := old[name]; !exists {
+			d.add("added", kind+" "+name, "", false)
+		}
+	}
+}
+
+// fields compares the fields of an object or input type. For input types,
+// adding a required field or requiring an existing one is breaking, since
+// clients don't send it; for object types, making a field nullable is, since
+// clients may not expect null.
+func (d *schemaDiff) fields(kind, owner string, old, new []FieldInfo, input bool) {
+	newFields := make(map[string]FieldInfo, len(new))
+	for _, f := range new {
+		newFields[f.Name] = f
+	}
+	oldFields := make(map[string]bool, len(old))
+	for _, o := range old {
+		oldFields[o.Name] = true
+		element := kind + " " + owner + "." + o.Name
+		n, exists := newFields[o.Name]
+		if !exists {
+			d.add("removed", element, "", true)
+			continue
+		}
+		d.typeChange(element, fieldTypeString(o.Type, o.Nullable), fieldTypeString(n.Type, n.Nullable), input, n.Default != nil)
+	}
+	for _, n := range new {
+		if oldFields[n.Name] {
+			continue
+		}
+		required := input && isNonNull(n.Type, n.Nullable) && n.Default == nil
+		description := ""
+		if required {
+			description = "new required field"
+		}
+		d.add("added", kind+" "+owner+"."+n.Name, description, required)
+	}
+}
+
+// arguments compares the arguments of an operation.
+func (d *schemaDiff) arguments(operation string, old, new []ArgumentDefinition) {
+	newArgs := make(map[string]ArgumentDefinition, len(new))
+	for _, a := range new {
+		newArgs[a.Name] = a
+	}
+	oldArgs := make(map[string]bool, len(old))
+	for _, o := range old {
+		oldArgs[o.Name] = true
+		element := "argument " + operation + "." + o.Name
+		n, exists := newArgs[o.Name]
+		if !exists {
+			d.add("removed", element, "", true)
+			continue
+		}
+		d.typeChange(element, fieldTypeString(o.Type, o.Nullable), fieldTypeString(n.Type, n.Nullable), true, n.IsDefault)
+	}
+	for _, n := range new {
+		if oldArgs[n.Name] {
+			continue
+		}
+		required := isNonNull(n.Type, n.Nullable) && !n.IsDefault
+		description := ""
+		if required {
+			description = "new required argument"
+		}
+		d.add("added", "argument "+operation+"."+n.Name, description, required)
+	}
+}
+
+// result compares an operation's result type.
+func (d *schemaDiff) result(kind, name, oldType string, oldList, oldNullable bool, newType string, newList, newNullable bool) {
+	d.typeChange(kind+" "+name, resultTypeString(oldType, oldList, oldNullable), resultTypeString(newType, newList, newNullable), false, false)
+}
+
+// typeChange reports a change between the type strings o and n. When they
+// differ only in nullability, the direction decides whether it is breaking:
+// requiring a value breaks an input unless it has a default, and allowing
+// null breaks an output.
+func (d *schemaDiff) typeChange(element, o, n string, input, hasDefault bool) {
+	if o == n {
+		return
+	}
+	description := fmt.Sprintf("type changed from %s to %s", o, n)
+	if strings.TrimSuffix(o, "!") != strings.TrimSuffix(n, "!") {
+		d.add("changed", element, description, true)
+		return
+	}
+	madeRequired := strings.HasSuffix(n, "!")
+	breaking := !madeRequired
+	if input {
+		breaking = madeRequired && !hasDefault
+	}
+	d.add("changed", element, description, breaking)
+}
+
+func isNonNull(typeStr string, nullable bool) bool {
+	return !nullable || strings.HasSuffix(typeStr, "!")
+}
+
+// fieldTypeString renders a field or argument type in GraphQL notation.
+func fieldTypeString(typeStr string, nullable bool) string {
+	if isNonNull(typeStr, nullable) {
+		return strings.TrimSuffix(typeStr, "!") + "!"
+	}
+	return typeStr
+}
+
+// resultTypeString renders an operation's result type in GraphQL notation.
+func resultTypeString(returnType string, list, nullable bool) string {
+	if list {
+		return fieldTypeString("["+fieldTypeString(returnType, false)+"]", nullable)
+	}
+	return fieldTypeString(returnType, nullable)
+}
+
+func namedTypes(defs []TypeDefinition) map[string]TypeDefinition {
+	m := make(map[string]TypeDefinition, len(defs))
+	for _, def := range defs {
+		m[def.Name] = def
+	}
+	return m
+}
+
+func namedInputTypes(defs []InputTypeDefinition) map[string]InputTypeDefinition {
+	m := make(map[string]InputTypeDefinition, len(defs))
+	for _, def := range defs {
+		m[def.Name] = def
+	}
+	return m
+}
+
+func namedEnums(defs []EnumDefinition) map[string]EnumDefinition {
+	m := make(map[string]EnumDefinition, len(defs))
+	for _, def := range defs {
+		m[def.Name] = def
+	}
+	return m
+}
+
+func namedQueries(defs []QueryDefinition) map[string]QueryDefinition {
+	m := make(map[string]QueryDefinition, len(defs))
+	for _, def := range defs {
+		m[def.Name] = def
+	}
+	return m
+}
+
+func namedMutations(defs []MutationDefinition) map[string]MutationDefinition {
+	m := make(map[string]MutationDefinition, len(defs))
+	for _, def := range defs {
+		m[def.Name] = def
+	}
+	return m
+}
+
+func namedSubscriptions(defs []SubscriptionDefinition) map[string]SubscriptionDefinition {
+	m := make(map[string]SubscriptionDefinition, len(defs))
+	for _, def := range defs {
+		m[def.Name] = def
+	}
+	return m
+}
+
+// Changelog is the JSON form of ExportChangelog's output.
+type Changelog struct {
+	// Breaking reports whether any change is breaking.
+	Breaking bool           `json:"breaking"`
+	Changes  []SchemaChange `json:"changes"`
+}
+
+// ExportChangelog compares old, typically the schema of the previous release
+// loaded from its schema.json, with the registered schema, and writes the
+// changes from DiffSchemas to path for release tooling. A path ending in .md
+// gets a markdown changelog listing the breaking changes first; any other
+// path gets the JSON form of Changelog.
+func ExportChangelog(old Schema, path string) error {
+	current, err := buildSchema()
+	if err != nil {
+		return err
+	}
+	changes := DiffSchemas(old, current)
+
+	var data []byte
+	if strings.EqualFold(filepath.Ext(path), ".md") {
+		data = []byte(changelogMarkdown(changes))
+	} else {
+		changelog := Changelog{Changes: changes}
+		if changelog.Changes == nil {
+			changelog.Changes = []SchemaChange{}
+		}
+		for _, c := range changes {
+			changelog.Breaking = changelog.Breaking || c.Breaking
+		}
+		if data, err = json.MarshalIndent(changelog, "", "  "); err != nil {
+			return fmt.Errorf("failed to marshal changelog to JSON: %w", err)
+		}
+	}
+
+	if err := writeFileAtomic(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write changelog file: %w", err)
+	}
+	return nil
+}
+
+// changelogMarkdown renders changes as a markdown changelog.
+func changelogMarkdown(changes []SchemaChange) string {
+	var b strings.Builder
+	b.WriteString("# Schema changelog\n")
+	if len(changes) == 0 {
+		b.WriteString("\nNo changes.\n")
+		return b.String()
+	}
+	for _, section := range []struct {
+		title    string
+		breaking bool
+	}{{"Breaking changes", true}, {"Changes", false}} {
+		wrote := false
+		for _, c := range changes {
+			if c.Breaking != section.breaking {
+				continue
+			}
+			if !wrote {
+				fmt.Fprintf(&b, "\n## %s\n\n", section.title)
+				wrote = true
+			}
+			fmt.Fprintf(&b, "- %s `%s`", strings.ToUpper(c.Change[:1])+c.Change[1:], c.Element)
+			if c.Description != "" {
+				fmt.Fprintf(&b, ": %s", c.Description)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
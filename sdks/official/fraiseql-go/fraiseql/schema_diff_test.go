@@ -0,0 +1,180 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// registerChangelogV1 registers the "previous release" schema of the
+// changelog tests.
+func registerChangelogV1(t *testing.T) {
+	t.Helper()
+	if err := RegisterType("User", []FieldInfo{
+		{Name: "id", Type: "ID"},
+		{Name: "email", Type: "Email"},
+		{Name: "age", Type: "Int"},
+		{Name: "nickname", Type: "String", Nullable: true},
+	}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	Enum("Role", map[string]string{"ADMIN": "admin", "GUEST": "guest"})
+	if err := NewQuery("users").ReturnType("User").ReturnsArray(true).Arg("limit", "Int", 10).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewMutation("deleteUser").ReturnType("User").Arg("id", "ID", nil).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+}
+
+// registerChangelogV2 registers the current schema of the changelog tests.
+func registerChangelogV2(t *testing.T) {
+	t.Helper()
+	if err := RegisterType("User", []FieldInfo{
+		{Name: "id", Type: "ID"},
+		{Name: "age", Type: "String"},
+		{Name: "nickname", Type: "String"},
+		{Name: "createdAt", Type: "DateTime"},
+	}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := RegisterType("Post", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	Enum("Role", map[string]string{"ADMIN": "admin", "MEMBER": "member"})
+	if err := NewQuery("users").ReturnType("User").ReturnsArray(true).
+		Arg("limit", "Int", 10).
+		Arg("tenant", "ID", nil).
+		Arg("search", "String", nil, true).
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+}
+
+func TestDiffSchemas(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	registerChangelogV1(t)
+	old := GetSchema()
+	Reset()
+	registerChangelogV2(t)
+
+	want := []SchemaChange{
+		{Change: "added", Element: "argument users.search"},
+		{Change: "added", Element: "argument users.tenant", Description: "new required argument", Breaking: true},
+		{Change: "removed", Element: "enum value Role.GUEST", Breaking: true},
+		{Change: "added", Element: "enum value Role.MEMBER"},
+		{Change: "changed", Element: "field User.age", Description: "type changed from Int! to String!", Breaking: true},
+		{Change: "added", Element: "field User.createdAt"},
+		{Change: "removed", Element: "field User.email", Breaking: true},
+		{Change: "changed", Element: "field User.nickname", Description: "type changed from String to String!"},
+		{Change: "removed", Element: "mutation deleteUser", Breaking: true},
+		{Change: "added", Element: "type Post"},
+	}
+	if got := DiffSchemas(old, GetSchema()); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffSchemas =\n%+v\nwant\n%+v", got, want)
+	}
+	if got := DiffSchemas(old, old); got != nil {
+		t.Errorf("DiffSchemas of identical schemas = %+v, want none", got)
+	}
+}
+
+func TestDiffSchemasNullability(t *testing.T) {
+	tests := []struct {
+		name         string
+		old, new     []FieldInfo
+		input        bool
+		wantBreaking bool
+	}{
+		{"output made nullable", []FieldInfo{{Name: "f", Type: "Int"}}, []FieldInfo{{Name: "f", Type: "Int", Nullable: true}}, false, true},
+		{"output made non-null", []FieldInfo{{Name: "f", Type: "Int", Nullable: true}}, []FieldInfo{{Name: "f", Type: "Int"}}, false, false},
+		{"input made required", []FieldInfo{{Name: "f", Type: "Int", Nullable: true}}, []FieldInfo{{Name: "f", Type: "Int"}}, true, true},
+		{"input made required with default", []FieldInfo{{Name: "f", Type: "Int", Nullable: true}}, []FieldInfo{{Name: "f", Type: "Int", Default: 1}}, true, false},
+		{"input made optional", []FieldInfo{{Name: "f", Type: "Int"}}, []FieldInfo{{Name: "f", Type: "Int", Nullable: true}}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &schemaDiff{}
+			d.fields("field", "T", tt.old, tt.new, tt.input)
+			if len(d.changes) != 1 || d.changes[0].Breaking != tt.wantBreaking {
+				t.Errorf("changes = %+v, want one with Breaking %v", d.changes, tt.wantBreaking)
+			}
+		})
+	}
+}
+
+func TestExportChangelog(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	registerChangelogV1(t)
+	old := GetSchema()
+	Reset()
+	registerChangelogV2(t)
+	dir := t.TempDir()
+
+	mdPath := filepath.Join(dir, "CHANGELOG.md")
+	if err := ExportChangelog(old, mdPath); err != nil {
+		t.Fatalf("ExportChangelog(md): %v", err)
+	}
+	md, err := os.ReadFile(mdPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantMD := "# Schema changelog\n" +
+		"\n## Breaking changes\n\n" +
+		"- Added `argument users.tenant`: new required argument\n" +
+		"- Removed `enum value Role.GUEST`\n" +
+		"- Changed `field User.age`: type changed from Int! to String!\n" +
+		"- Removed `field User.email`\n" +
+		"- Removed `mutation deleteUser`\n" +
+		"\n## Changes\n\n" +
+		"- Added `argument users.search`\n" +
+		"- Added `enum value Role.MEMBER`\n" +
+		"- Added `field User.createdAt`\n" +
+		"- Changed `field User.nickname`: type changed from String to String!\n" +
+		"- Added `type Post`\n"
+	if string(md) != wantMD {
+		t.Errorf("markdown changelog =\n%s\nwant\n%s", md, wantMD)
+	}
+
+	jsonPath := filepath.Join(dir, "changelog.json")
+	if err := ExportChangelog(old, jsonPath); err != nil {
+		t.Fatalf("ExportChangelog(json): %v", err)
+	}
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var changelog Changelog
+	if err := json.Unmarshal(data, &changelog); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !changelog.Breaking || len(changelog.Changes) != 10 {
+		t.Errorf("changelog = %+v, want 10 changes including breaking ones", changelog)
+	}
+	if c := changelog.Changes[1]; c.Element != "argument users.tenant" || !c.Breaking {
+		t.Errorf("changes[1] = %+v, want the breaking users.tenant argument", c)
+	}
+}
+
+func TestExportChangelogNoChanges(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	registerChangelogV1(t)
+	path := filepath.Join(t.TempDir(), "changelog.json")
+	if err := ExportChangelog(GetSchema(), path); err != nil {
+		t.Fatalf("ExportChangelog: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\n  \"breaking\": false,\n  \"changes\": []\n}"; string(data) != want {
+		t.Errorf("changelog = %s, want %s", data, want)
+	}
+}
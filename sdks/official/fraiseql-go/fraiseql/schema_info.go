@@ -0,0 +1,52 @@
+package fraiseql
+
+import (
+	"errors"
+	"strings"
+)
+
+// SchemaInfo describes the API as a whole, like the info object of an
+// OpenAPI document. It is exported as the top-level "info" object.
+type SchemaInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Contact is who to reach about the API.
+	Contact *SchemaContact `json:"contact,omitempty"`
+}
+
+// SchemaContact is the contact of a SchemaInfo.
+type SchemaContact struct {
+	Name  string `json:"name,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// SetSchemaInfo sets the schema's title, version, description and contact,
+// replacing any set before, for export as the top-level "info" object. Reset
+// clears it.
+//
+// Example:
+//
+//	fraiseql.SetSchemaInfo(fraiseql.SchemaInfo{
+//	    Title:   "Storefront API",
+//	    Version: "2.3.0",
+//	    Contact: &fraiseql.SchemaContact{Name: "API team", Email: "api@example.com"},
+//	})
+//
+// Returns an error if the title is empty.
+func SetSchemaInfo(info SchemaInfo) error {
+	if strings.TrimSpace(info.Title) == "" {
+		return errors.New("schema info needs a title")
+	}
+	if info.Contact != nil {
+		contact := *info.Contact
+		info.Contact = &contact
+	}
+
+	reg := getInstance()
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	reg.info = &info
+	return nil
+}
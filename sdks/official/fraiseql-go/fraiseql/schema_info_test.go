@@ -0,0 +1,75 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSchemaInfoExport(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := SetSchemaInfo(SchemaInfo{
+		Title:       "Storefront API",
+		Version:     "2.3.0",
+		Description: "Products, carts and orders.",
+		Contact:     &SchemaContact{Name: "API team", Email: "api@example.com"},
+	}); err != nil {
+		t.Fatalf("SetSchemaInfo: %v", err)
+	}
+	if err := NewQuery("products").ReturnType("Product").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	want := `{"info":{"title":"Storefront API","version":"2.3.0","description":"Products, carts and orders.","contact":{"name":"API team","email":"api@example.com"}},`
+	if !strings.HasPrefix(string(data), want) {
+		t.Errorf("expected export to start with %s, got %s", want, data)
+	}
+
+	Reset()
+	if err := NewQuery("products").ReturnType("Product").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if data, err = GetSchemaJSON(false); err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	if strings.Contains(string(data), `"info"`) {
+		t.Errorf("Reset should clear the schema info, got %s", data)
+	}
+}
+
+func TestSetSchemaInfoRequiresTitle(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	err := SetSchemaInfo(SchemaInfo{Version: "1.0.0"})
+	if err == nil || !strings.Contains(err.Error(), "schema info needs a title") {
+		t.Errorf("error = %v, want a missing title error", err)
+	}
+}
+
+func TestMergeRegistriesSchemaInfo(t *testing.T) {
+	defer Reset()
+
+	first := buildRegistry(t, func() error { return SetSchemaInfo(SchemaInfo{Title: "Catalog API"}) })
+	second := buildRegistry(t, func() error { return SetSchemaInfo(SchemaInfo{Title: "Orders API"}) })
+	third := buildRegistry(t, func() error { return nil })
+
+	merged, err := MergeRegistries(first, third)
+	if err != nil {
+		t.Fatalf("MergeRegistries: %v", err)
+	}
+	if merged.info == nil || merged.info.Title != "Catalog API" {
+		t.Errorf("merged info = %+v, want the first registry's", merged.info)
+	}
+
+	_, err = MergeRegistries(first, second)
+	want := "schema info is set differently in registries 1 and 2"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("error = %v, want it to contain %q", err, want)
+	}
+}
@@ -0,0 +1,375 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// splitIndexFile and splitSharedFile are the file names ExportSchemaSplit
+// reserves for the index and for the definitions no group owns.
+const (
+	splitIndexFile  = "index.json"
+	splitSharedFile = "shared.json"
+)
+
+// SchemaSplitIndex is the index file ExportSchemaSplit writes next to the
+// split schema files.
+type SchemaSplitIndex struct {
+	// By is the grouping the schema was split by.
+	By   string      `json:"by"`
+	Info *SchemaInfo `json:"info,omitempty"`
+	// Files lists the schema files written, in name order.
+	Files []string `json:"files"`
+	// Definitions maps each definition, named like "type User" or
+	// "query users", to the file holding it, so a reference to a type in
+	// another file can be resolved.
+	Definitions map[string]string `json:"definitions"`
+}
+
+// ExportSchemaSplit writes the schema to dir as several files, for modular
+// compilation and review of large schemas, plus an index.json listing them
+// (see SchemaSplitIndex). by chooses the grouping:
+//
+//   - "kind": one file per section, e.g. types.json, queries.json and
+//     mutations.json.
+//   - "entity": one file per object type, named after it in snake case
+//     (order_item.json), holding the type with the queries and mutations
+//     returning it and the subscriptions and observers on it; enums, input
+//     types, fact tables, aggregate queries and operations returning a scalar
+//     go to shared.json.
+//   - "tag": one file per build profile (see GetSchemaForProfile), named after
+//     it in snake case (enterprise.json), holding the queries and mutations
+//     annotated with the profile and the types, input types and enums only
+//     those operations reach. Definitions that no profile or several profiles
+//     reach, unannotated operations, subscriptions, fact tables, aggregate
+//     queries and observers go to shared.json. Profile-annotated fields stay
+//     in their type's file.
+//
+// Each file is a schema document in the same format as ExportSchema's
+// output. Every definition is written to exactly one file; a file may refer
+// to types defined in another, which the index's Definitions locates. The
+// custom scalars, directives and inject defaults apply to the whole schema
+// and are written to shared.json.
+//
+// The schema is checked as ExportSchema checks it. Returns an error if by is
+// not a known grouping, if a type's or profile's file name is one of the
+// reserved index.json and shared.json, or if two types or two profiles map to
+// the same file name (e.g. User and user).
+func ExportSchemaSplit(dir string, by string) error {
+	schema, err := buildSchema()
+	if err != nil {
+		return err
+	}
+	if err := checkNotEmpty(schema); err != nil {
+		return err
+	}
+	if err := validateSchemaBeforeExport(schema); err != nil {
+		return err
+	}
+	if err := validateReportBeforeExport(schema); err != nil {
+		return err
+	}
+
+	var files map[string]*Schema
+	switch by {
+	case "kind":
+		files = splitByKind(schema)
+	case "entity":
+		if files, err = splitByEntity(schema); err != nil {
+			return err
+		}
+	case "tag":
+		if files, err = splitByTag(schema); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown schema split %q; must be one of entity, kind, tag", by)
+	}
+	if shared := sharedSettings(schema); shared != nil {
+		files[splitSharedFile] = mergeSplitFile(files[splitSharedFile], shared)
+	}
+
+	index := SchemaSplitIndex{By: by, Info: schema.Info, Files: sortedKeys(files), Definitions: make(map[string]string)}
+	for name, file := range files {
+		for _, element := range splitElements(*file) {
+			index.Definitions[element] = name
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create schema directory: %w", err)
+	}
+	for _, name := range index.Files {
+		if err := writeSplitFile(filepath.Join(dir, name), withEmptySections(*files[name])); err != nil {
+			return err
+		}
+	}
+	return writeSplitFile(filepath.Join(dir, splitIndexFile), index)
+}
+
+// splitByKind groups the schema's definitions by section.
+func splitByKind(schema Schema) map[string]*Schema {
+	files := make(map[string]*Schema)
+	add := func(name string, empty bool, set func(*Schema)) {
+		if empty {
+			return
+		}
+		file := &Schema{}
+		set(file)
+		files[name+".json"] = file
+	}
+	add("types", len(schema.Types) == 0, func(f *Schema) { f.Types = schema.Types })
+	add("enums", len(schema.Enums) == 0, func(f *Schema) { f.Enums = schema.Enums })
+	add("input_types", len(schema.InputTypes) == 0, func(f *Schema) { f.InputTypes = schema.InputTypes })
+	add("queries", len(schema.Queries) == 0, func(f *Schema) { f.Queries = schema.Queries })
+	add("mutations", len(schema.Mutations) == 0, func(f *Schema) { f.Mutations = schema.Mutations })
+	add("subscriptions", len(schema.Subscriptions) == 0, func(f *Schema) { f.Subscriptions = schema.Subscriptions })
+	add("fact_tables", len(schema.FactTables) == 0, func(f *Schema) { f.FactTables = schema.FactTables })
+	add("aggregate_queries", len(schema.AggregateQueries) == 0, func(f *Schema) { f.AggregateQueries = schema.AggregateQueries })
+	add("observers", len(schema.Observers) == 0, func(f *Schema) { f.Observers = schema.Observers })
+	return files
+}
+
+// splitByEntity groups the schema's definitions by the object type they
+// return or watch.
+func splitByEntity(schema Schema) (map[string]*Schema, error) {
+	files := make(map[string]*Schema)
+	owners := make(map[string]string, len(schema.Types))
+	fileOwners := make(map[string]string, len(schema.Types))
+	for _, t := range schema.Types {
+		name := toSnakeCase(t.Name) + ".json"
+		if name == splitIndexFile || name == splitSharedFile {
+			return nil, fmt.Errorf("type %q would be written to %s, which ExportSchemaSplit reserves", t.Name, name)
+		}
+		if other, taken := fileOwners[name]; taken {
+			return nil, fmt.Errorf("types %q and %q would both be written to %s", other, t.Name, name)
+		}
+		fileOwners[name] = t.Name
+		owners[t.Name] = name
+		files[name] = &Schema{Types: []TypeDefinition{t}}
+	}
+	shared := &Schema{
+		Enums:            schema.Enums,
+		InputTypes:       schema.InputTypes,
+		FactTables:       schema.FactTables,
+		AggregateQueries: schema.AggregateQueries,
+	}
+	fileFor := func(typeName string) *Schema {
+		if name, ok := owners[namedType(typeName)]; ok {
+			return files[name]
+		}
+		return shared
+	}
+	for _, q := range schema.Queries {
+		f := fileFor(q.ReturnType)
+		f.Queries = append(f.Queries, q)
+	}
+	for _, m := range schema.Mutations {
+		f := fileFor(m.ReturnType)
+		f.Mutations = append(f.Mutations, m)
+	}
+	for _, s := range schema.Subscriptions {
+		f := fileFor(s.EntityType)
+		f.Subscriptions = append(f.Subscriptions, s)
+	}
+	for _, o := range schema.Observers {
+		f := fileFor(o.Entity)
+		f.Observers = append(f.Observers, o)
+	}
+	if len(splitElements(*shared)) > 0 {
+		files[splitSharedFile] = shared
+	}
+	return files, nil
+}
+
+// splitByTag groups the schema's definitions by build profile.
+func splitByTag(schema Schema) (map[string]*Schema, error) {
+	files := make(map[string]*Schema)
+	fileNames := make(map[string]string)
+	fileOwners := make(map[string]string)
+	fileFor := func(profile string) (*Schema, error) {
+		name, ok := fileNames[profile]
+		if !ok {
+			name = toSnakeCase(profile) + ".json"
+			if name == splitIndexFile || name == splitSharedFile {
+				return nil, fmt.Errorf("profile %q would be written to %s, which ExportSchemaSplit reserves", profile, name)
+			}
+			if other, taken := fileOwners[name]; taken {
+				return nil, fmt.Errorf("profiles %q and %q would both be written to %s", other, profile, name)
+			}
+			fileOwners[name] = profile
+			fileNames[profile] = name
+			files[name] = &Schema{}
+		}
+		return files[name], nil
+	}
+
+	shared := &Schema{
+		Subscriptions:    schema.Subscriptions,
+		FactTables:       schema.FactTables,
+		AggregateQueries: schema.AggregateQueries,
+		Observers:        schema.Observers,
+	}
+	// roots holds the operations of each profile, and of none under "", to
+	// work out which profiles reach each named type.
+	roots := map[string]*Schema{"": {
+		Types:            schema.Types,
+		InputTypes:       schema.InputTypes,
+		Subscriptions:    schema.Subscriptions,
+		AggregateQueries: schema.AggregateQueries,
+	}}
+	rootsFor := func(profile string) *Schema {
+		if roots[profile] == nil {
+			roots[profile] = &Schema{Types: schema.Types, InputTypes: schema.InputTypes}
+		}
+		return roots[profile]
+	}
+	for _, q := range schema.Queries {
+		r := rootsFor(q.Profile)
+		r.Queries = append(r.Queries, q)
+		f := shared
+		if q.Profile != "" {
+			var err error
+			if f, err = fileFor(q.Profile); err != nil {
+				return nil, err
+			}
+		}
+		f.Queries = append(f.Queries, q)
+	}
+	for _, m := range schema.Mutations {
+		r := rootsFor(m.Profile)
+		r.Mutations = append(r.Mutations, m)
+		f := shared
+		if m.Profile != "" {
+			var err error
+			if f, err = fileFor(m.Profile); err != nil {
+				return nil, err
+			}
+		}
+		f.Mutations = append(f.Mutations, m)
+	}
+
+	// A named type belongs to a profile's file when that profile's
+	// operations are the only ones reaching it.
+	reachedBy := make(map[string][]string)
+	for profile, r := range roots {
+		for name := range reachableTypes(*r) {
+			reachedBy[name] = append(reachedBy[name], profile)
+		}
+	}
+	owner := func(name string) *Schema {
+		if profiles := reachedBy[name]; len(profiles) == 1 && profiles[0] != "" {
+			return files[fileNames[profiles[0]]]
+		}
+		return shared
+	}
+	for _, t := range schema.Types {
+		f := owner(t.Name)
+		f.Types = append(f.Types, t)
+	}
+	for _, in := range schema.InputTypes {
+		f := owner(in.Name)
+		f.InputTypes = append(f.InputTypes, in)
+	}
+	for _, e := range schema.Enums {
+		f := owner(e.Name)
+		f.Enums = append(f.Enums, e)
+	}
+
+	if len(splitElements(*shared)) > 0 {
+		files[splitSharedFile] = shared
+	}
+	return files, nil
+}
+
+// sharedSettings returns the schema-wide settings, or nil if there are none.
+func sharedSettings(schema Schema) *Schema {
+	if len(schema.CustomScalars) == 0 && len(schema.Directives) == 0 && schema.InjectDefaults == nil {
+		return nil
+	}
+	return &Schema{
+		CustomScalars:  schema.CustomScalars,
+		Directives:     schema.Directives,
+		InjectDefaults: schema.InjectDefaults,
+	}
+}
+
+// mergeSplitFile adds the schema-wide settings to file, which may be nil.
+func mergeSplitFile(file, settings *Schema) *Schema {
+	if file == nil {
+		return settings
+	}
+	file.CustomScalars = settings.CustomScalars
+	file.Directives = settings.Directives
+	file.InjectDefaults = settings.InjectDefaults
+	return file
+}
+
+// splitElements names the definitions in file for the index.
+func splitElements(file Schema) []string {
+	var elements []string
+	for _, t := range file.Types {
+		elements = append(elements, "type "+t.Name)
+	}
+	for _, e := range file.Enums {
+		elements = append(elements, "enum "+e.Name)
+	}
+	for _, in := range file.InputTypes {
+		elements = append(elements, "input type "+in.Name)
+	}
+	for _, q := range file.Queries {
+		elements = append(elements, "query "+q.Name)
+	}
+	for _, m := range file.Mutations {
+		elements = append(elements, "mutation "+m.Name)
+	}
+	for _, s := range file.Subscriptions {
+		elements = append(elements, "subscription "+s.Name)
+	}
+	for _, f := range file.FactTables {
+		elements = append(elements, "fact table "+f.Name)
+	}
+	for _, a := range file.AggregateQueries {
+		elements = append(elements, "aggregate query "+a.Name)
+	}
+	for _, o := range file.Observers {
+		elements = append(elements, "observer "+o.Name)
+	}
+	for _, d := range file.Directives {
+		elements = append(elements, "directive "+d.Name)
+	}
+	sort.Strings(elements)
+	return elements
+}
+
+// withEmptySections fills the sections a schema document always has, so
+// each split file reads like ExportSchema's output.
+func withEmptySections(file Schema) Schema {
+	if file.Types == nil {
+		file.Types = []TypeDefinition{}
+	}
+	if file.Queries == nil {
+		file.Queries = []QueryDefinition{}
+	}
+	if file.Mutations == nil {
+		file.Mutations = []MutationDefinition{}
+	}
+	if file.Subscriptions == nil {
+		file.Subscriptions = []SubscriptionDefinition{}
+	}
+	return file
+}
+
+func writeSplitFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s to JSON: %w", filepath.Base(path), err)
+	}
+	if err := writeFileAtomic(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write schema file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func registerSplitSchema(t *testing.T) {
+	t.Helper()
+	if err := RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := RegisterType("OrderItem", []FieldInfo{{Name: "id", Type: "ID"}, {Name: "buyer", Type: "User"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	Enum("Status", map[string]string{"OPEN": "open"})
+	if err := NewInputType("UserFilter").Field("name", "String", true).Register(); err != nil {
+		t.Fatalf("input Register: %v", err)
+	}
+	if err := NewQuery("users").ReturnType("User").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("query Register: %v", err)
+	}
+	if err := NewQuery("orderItems").ReturnType("OrderItem").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("query Register: %v", err)
+	}
+	if err := NewQuery("userCount").ReturnsScalar("Int").Register(); err != nil {
+		t.Fatalf("query Register: %v", err)
+	}
+	if err := NewMutation("createUser").ReturnType("User").Register(); err != nil {
+		t.Fatalf("mutation Register: %v", err)
+	}
+}
+
+func readSplitDir(t *testing.T, dir string) (SchemaSplitIndex, map[string]Schema) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	var index SchemaSplitIndex
+	files := make(map[string]Schema)
+	for _, e := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		if e.Name() == "index.json" {
+			err = json.Unmarshal(data, &index)
+		} else {
+			var s Schema
+			err = json.Unmarshal(data, &s)
+			files[e.Name()] = s
+		}
+		if err != nil {
+			t.Fatalf("unmarshal %s: %v", e.Name(), err)
+		}
+	}
+	return index, files
+}
+
+func TestExportSchemaSplitByEntity(t *testing.T) {
+	Reset()
+	defer Reset()
+	registerSplitSchema(t)
+
+	dir := filepath.Join(t.TempDir(), "schema")
+	if err := ExportSchemaSplit(dir, "entity"); err != nil {
+		t.Fatalf("ExportSchemaSplit: %v", err)
+	}
+	index, files := readSplitDir(t, dir)
+
+	wantFiles := []string{"order_item.json", "shared.json", "user.json"}
+	if !reflect.DeepEqual(index.Files, wantFiles) || len(files) != len(wantFiles) {
+		t.Fatalf("files = %v (index %v), want %v", sortedKeys(files), index.Files, wantFiles)
+	}
+	if index.By != "entity" {
+		t.Errorf("index By = %q, want entity", index.By)
+	}
+
+	user := files["user.json"]
+	if len(user.Types) != 1 || user.Types[0].Name != "User" || len(user.Queries) != 1 || user.Queries[0].Name != "users" ||
+		len(user.Mutations) != 1 || user.Mutations[0].Name != "createUser" {
+		t.Errorf("user.json = %+v, want User with users and createUser", user)
+	}
+	shared := files["shared.json"]
+	if len(shared.Types) != 0 || len(shared.Enums) != 1 || len(shared.InputTypes) != 1 ||
+		len(shared.Queries) != 1 || shared.Queries[0].Name != "userCount" {
+		t.Errorf("shared.json = %+v, want Status, UserFilter and userCount", shared)
+	}
+
+	wantDefinitions := map[string]string{
+		"type User":             "user.json",
+		"type OrderItem":        "order_item.json",
+		"enum Status":           "shared.json",
+		"input type UserFilter": "shared.json",
+		"query users":           "user.json",
+		"query orderItems":      "order_item.json",
+		"query userCount":       "shared.json",
+		"mutation createUser":   "user.json",
+	}
+	if !reflect.DeepEqual(index.Definitions, wantDefinitions) {
+		t.Errorf("index definitions = %v, want %v", index.Definitions, wantDefinitions)
+	}
+}
+
+func TestExportSchemaSplitByKind(t *testing.T) {
+	Reset()
+	defer Reset()
+	registerSplitSchema(t)
+	if err := RegisterDirective("audited", []string{"FIELD_DEFINITION"}, nil); err != nil {
+		t.Fatalf("RegisterDirective: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := ExportSchemaSplit(dir, "kind"); err != nil {
+		t.Fatalf("ExportSchemaSplit: %v", err)
+	}
+	index, files := readSplitDir(t, dir)
+
+	wantFiles := []string{"enums.json", "input_types.json", "mutations.json", "queries.json", "shared.json", "types.json"}
+	if !reflect.DeepEqual(index.Files, wantFiles) || len(files) != len(wantFiles) {
+		t.Fatalf("files = %v (index %v), want %v", sortedKeys(files), index.Files, wantFiles)
+	}
+	if got := files["queries.json"].Queries; len(got) != 3 {
+		t.Errorf("queries.json holds %d queries, want 3", len(got))
+	}
+	if got := files["shared.json"].Directives; len(got) != 1 || got[0].Name != "audited" {
+		t.Errorf("shared.json directives = %+v, want audited", got)
+	}
+	if index.Definitions["type OrderItem"] != "types.json" || index.Definitions["directive audited"] != "shared.json" {
+		t.Errorf("index definitions = %v", index.Definitions)
+	}
+}
+
+func TestExportSchemaSplitByTag(t *testing.T) {
+	Reset()
+	defer Reset()
+	registerSplitSchema(t)
+	if err := RegisterType("Invoice", []FieldInfo{{Name: "id", Type: "ID"}, {Name: "customer", Type: "User"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := NewQuery("invoices").ReturnType("Invoice").ReturnsArray(true).Profile("enterprise").Register(); err != nil {
+		t.Fatalf("query Register: %v", err)
+	}
+	if err := NewMutation("voidInvoice").ReturnType("Invoice").Profile("enterprise").Register(); err != nil {
+		t.Fatalf("mutation Register: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := ExportSchemaSplit(dir, "tag"); err != nil {
+		t.Fatalf("ExportSchemaSplit: %v", err)
+	}
+	index, files := readSplitDir(t, dir)
+
+	wantFiles := []string{"enterprise.json", "shared.json"}
+	if !reflect.DeepEqual(index.Files, wantFiles) || len(files) != len(wantFiles) {
+		t.Fatalf("files = %v (index %v), want %v", sortedKeys(files), index.Files, wantFiles)
+	}
+	if index.By != "tag" {
+		t.Errorf("index By = %q, want tag", index.By)
+	}
+
+	// Invoice is reached only by enterprise operations; User is also reached
+	// by unannotated ones, so it stays shared.
+	wantDefinitions := map[string]string{
+		"type Invoice":          "enterprise.json",
+		"query invoices":        "enterprise.json",
+		"mutation voidInvoice":  "enterprise.json",
+		"type User":             "shared.json",
+		"type OrderItem":        "shared.json",
+		"enum Status":           "shared.json",
+		"input type UserFilter": "shared.json",
+		"query users":           "shared.json",
+		"query orderItems":      "shared.json",
+		"query userCount":       "shared.json",
+		"mutation createUser":   "shared.json",
+	}
+	if !reflect.DeepEqual(index.Definitions, wantDefinitions) {
+		t.Errorf("index definitions = %v, want %v", index.Definitions, wantDefinitions)
+	}
+}
+
+func TestExportSchemaSplitErrors(t *testing.T) {
+	Reset()
+	defer Reset()
+	registerSplitSchema(t)
+
+	err := ExportSchemaSplit(t.TempDir(), "module")
+	if err == nil || !strings.Contains(err.Error(), `unknown schema split "module"; must be one of entity, kind, tag`) {
+		t.Errorf("error = %v, want an unknown grouping error", err)
+	}
+
+	if err := NewQuery("indexStats").ReturnsScalar("Int").Profile("index").Register(); err != nil {
+		t.Fatalf("query Register: %v", err)
+	}
+	err = ExportSchemaSplit(t.TempDir(), "tag")
+	if err == nil || !strings.Contains(err.Error(), `profile "index" would be written to index.json`) {
+		t.Errorf("error = %v, want a reserved file name error", err)
+	}
+
+	if err := NewQuery("enterpriseUsers").ReturnsScalar("Int").Profile("Enterprise").Register(); err != nil {
+		t.Fatalf("query Register: %v", err)
+	}
+	if err := NewQuery("enterpriseOrders").ReturnsScalar("Int").Profile("enterprise").Register(); err != nil {
+		t.Fatalf("query Register: %v", err)
+	}
+	err = ExportSchemaSplit(t.TempDir(), "tag")
+	if err == nil || !strings.Contains(err.Error(), `profiles "enterprise" and "Enterprise" would both be written to enterprise.json`) {
+		t.Errorf("error = %v, want a profile file name collision error", err)
+	}
+
+	if err := RegisterType("user", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	err = ExportSchemaSplit(t.TempDir(), "entity")
+	if err == nil || !strings.Contains(err.Error(), `types "User" and "user" would both be written to user.json`) {
+		t.Errorf("error = %v, want a type file name collision error", err)
+	}
+	reg := getInstance()
+	reg.mu.Lock()
+	delete(reg.types, "user")
+	reg.mu.Unlock()
+
+	if err := RegisterType("Shared", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	err = ExportSchemaSplit(t.TempDir(), "entity")
+	if err == nil || !strings.Contains(err.Error(), `type "Shared" would be written to shared.json`) {
+		t.Errorf("error = %v, want a reserved file name error", err)
+	}
+}
@@ -0,0 +1,151 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func registerHashFixtureTypes(t *testing.T, reversed bool) {
+	t.Helper()
+
+	type Post struct {
+		ID    ID     `fraiseql:"id"`
+		Title string `fraiseql:"title"`
+	}
+	type User struct {
+		ID    ID     `fraiseql:"id"`
+		Email string `fraiseql:"email"`
+	}
+
+	steps := []func() error{
+		func() error { return RegisterTypes(User{}) },
+		func() error { return RegisterTypes(Post{}) },
+		func() error { return NewQuery("users").ReturnType(User{}).ReturnsArray(true).Register() },
+		func() error { return NewQuery("posts").ReturnType(Post{}).ReturnsArray(true).Register() },
+		func() error { return NewMutation("createPost").ReturnType(Post{}).Register() },
+		func() error {
+			Enum("Status", map[string]string{"DRAFT": "draft", "PUBLISHED": "published", "ARCHIVED": "archived"})
+			return nil
+		},
+	}
+	if reversed {
+		for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+			steps[i], steps[j] = steps[j], steps[i]
+		}
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("registration failed: %v", err)
+		}
+	}
+}
+
+func TestSchemaHashStableAcrossRegistrationOrder(t *testing.T) {
+	defer Reset()
+
+	Reset()
+	registerHashFixtureTypes(t, false)
+	first := SchemaHash()
+
+	Reset()
+	registerHashFixtureTypes(t, true)
+	second := SchemaHash()
+
+	if first != second {
+		t.Errorf("hash differs across registration order:\n  %s\n  %s", first, second)
+	}
+	if len(first) != 64 {
+		t.Errorf("expected 64 hex characters, got %d", len(first))
+	}
+
+	// Repeated calls on the same registry are stable too.
+	for i := 0; i < 10; i++ {
+		if got := SchemaHash(); got != second {
+			t.Fatalf("hash changed between calls: %s vs %s", got, second)
+		}
+	}
+}
+
+func TestSchemaHashChangesWithSchema(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	registerHashFixtureTypes(t, false)
+	before := SchemaHash()
+
+	if err := NewQuery("post").ReturnType("Post").Nullable(true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if after := SchemaHash(); after == before {
+		t.Error("expected hash to change after registering a new query")
+	}
+}
+
+func TestExportSchemaWritesAtomically(t *testing.T) {
+	Reset()
+	defer Reset()
+	registerHashFixtureTypes(t, false)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(path, []byte(`{"truncated`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ExportSchema(path); err != nil {
+		t.Fatalf("ExportSchema: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("exported file is not valid JSON: %v", err)
+	}
+	if len(schema.Types) != 2 || len(schema.Queries) != 2 || len(schema.Mutations) != 1 {
+		t.Errorf("exported schema is incomplete: %d types, %d queries, %d mutations", len(schema.Types), len(schema.Queries), len(schema.Mutations))
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o644 {
+		t.Errorf("mode: want 0644, got %o", mode)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected no temp files left behind, got %d entries", len(entries))
+	}
+}
+
+func TestExportSchemaRejectsEmptySchema(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	path := filepath.Join(t.TempDir(), "schema.json")
+	err := ExportSchema(path)
+	if err == nil || !strings.Contains(err.Error(), "refusing to export an empty schema") {
+		t.Fatalf("expected empty schema error, got %v", err)
+	}
+	if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+		t.Error("no file should be written for an empty schema")
+	}
+
+	AllowEmptySchema(true)
+	if err := ExportSchema(path); err != nil {
+		t.Fatalf("ExportSchema with AllowEmptySchema(true): %v", err)
+	}
+
+	Reset()
+	if err := ExportSchema(path); err == nil {
+		t.Error("expected Reset to restore the empty schema check")
+	}
+}
@@ -0,0 +1,132 @@
+package fraiseql
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// SchemaTransform rewrites the assembled schema before it is returned or
+// exported. Returning an error aborts the export.
+type SchemaTransform func(schema *Schema) error
+
+// schemaTransformRegistry holds the transforms applied by GetSchema and the export functions.
+type schemaTransformRegistry struct {
+	mu         sync.RWMutex
+	transforms []SchemaTransform
+}
+
+// Global instance
+var schemaTransforms = &schemaTransformRegistry{}
+
+// RegisterSchemaTransform registers a transformation over the assembled
+// schema, for cross-cutting rewrites such as adding a `node(id: ID!)` query or
+// stripping internal fields.
+//
+// Transforms run in registration order after the registered definitions are
+// assembled and before the schema is marshaled, by GetSchema, GetSchemaJSON,
+// ExportSchema and the other export functions. The schema is sorted again
+// afterwards, so transforms may append definitions in any order. If a
+// transform returns an error, the export returns it and nothing is written,
+// and GetSchema returns the schema without transforms (GetSchemaE returns
+// the error). Transforms receive a
+// deep copy of the assembled schema, so they may modify nested slices and maps
+// (e.g. a type's Fields) in place without touching the registry.
+//
+// Example:
+//
+//	fraiseql.RegisterSchemaTransform(func(s *fraiseql.Schema) error {
+//	    s.Queries = append(s.Queries, fraiseql.QueryDefinition{
+//	        Name:       "node",
+//	        ReturnType: "Node",
+//	        Nullable:   true,
+//	        Arguments:  []fraiseql.ArgumentDefinition{{Name: "id", Type: "ID"}},
+//	    })
+//	    return nil
+//	})
+func RegisterSchemaTransform(transform SchemaTransform) {
+	schemaTransforms.mu.Lock()
+	defer schemaTransforms.mu.Unlock()
+	schemaTransforms.transforms = append(schemaTransforms.transforms, transform)
+}
+
+// ClearSchemaTransforms removes all registered schema transforms (useful for testing).
+func ClearSchemaTransforms() {
+	schemaTransforms.mu.Lock()
+	defer schemaTransforms.mu.Unlock()
+	schemaTransforms.transforms = nil
+}
+
+// buildSchema assembles the registered schema and applies the schema
+// transforms. The transforms run without the registry lock held, so they may
+// read the registry themselves.
+func buildSchema() (Schema, error) {
+	schema := collectSchema()
+
+	schemaTransforms.mu.RLock()
+	transforms := append([]SchemaTransform(nil), schemaTransforms.transforms...)
+	schemaTransforms.mu.RUnlock()
+
+	if len(transforms) == 0 {
+		return schema, nil
+	}
+	schema = deepCopy(reflect.ValueOf(schema)).Interface().(Schema)
+	for i, transform := range transforms {
+		if err := transform(&schema); err != nil {
+			return Schema{}, fmt.Errorf("schema transform %d failed: %w", i+1, err)
+		}
+	}
+	sortSchema(&schema)
+	return schema, nil
+}
+
+// deepCopy returns a copy of v that shares no slices, maps or pointers with
+// it. Unexported struct fields and funcs are copied as they are.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return c
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return c
+	default:
+		return v
+	}
+}
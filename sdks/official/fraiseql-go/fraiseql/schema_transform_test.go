@@ -0,0 +1,174 @@
+package fraiseql
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRegisterSchemaTransformInjectsQuery(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := NewQuery("users").ReturnType("User").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	RegisterSchemaTransform(func(s *Schema) error {
+		s.Queries = append(s.Queries, QueryDefinition{
+			Name:       "node",
+			ReturnType: "User",
+			Nullable:   true,
+			Arguments:  []ArgumentDefinition{{Name: "id", Type: "ID"}},
+		})
+		return nil
+	})
+
+	schema := GetSchema()
+	if len(schema.Queries) != 2 || schema.Queries[0].Name != "node" {
+		t.Fatalf("expected injected node query sorted first, got %+v", schema.Queries)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	if !strings.Contains(string(data), `"name":"node"`) {
+		t.Errorf("injected query missing from exported JSON: %s", data)
+	}
+
+	// The registry itself is untouched.
+	if _, exists := getInstance().queries["node"]; exists {
+		t.Error("transforms must not write back to the registry")
+	}
+}
+
+func TestSchemaTransformsRunInRegistrationOrder(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	var calls []string
+	RegisterSchemaTransform(func(s *Schema) error {
+		calls = append(calls, "first")
+		s.Types = append(s.Types, TypeDefinition{Name: "Node"})
+		return nil
+	})
+	RegisterSchemaTransform(func(s *Schema) error {
+		calls = append(calls, "second")
+		if len(s.Types) != 1 {
+			return errors.New("expected the type added by the first transform")
+		}
+		return nil
+	})
+
+	if _, err := GetSchemaJSON(false); err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	if strings.Join(calls, ",") != "first,second" {
+		t.Errorf("unexpected transform order: %v", calls)
+	}
+}
+
+func TestSchemaTransformErrorAbortsExport(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	RegisterSchemaTransform(func(s *Schema) error {
+		s.Types = nil
+		return errors.New("internal field policy violated")
+	})
+
+	path := filepath.Join(t.TempDir(), "schema.json")
+	err := ExportSchema(path)
+	if err == nil || !strings.Contains(err.Error(), "internal field policy violated") {
+		t.Fatalf("expected transform error from ExportSchema, got %v", err)
+	}
+	if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+		t.Error("no schema file should be written when a transform fails")
+	}
+	if _, err := GetSchemaJSON(false); err == nil {
+		t.Error("expected GetSchemaJSON to return the transform error")
+	}
+
+	if errs := ValidateSchema(); len(errs) != 1 || !strings.Contains(errs[0].Error(), "internal field policy violated") {
+		t.Errorf("expected ValidateSchema to report the transform error, got %v", errs)
+	}
+
+	if errs := ValidateConventions(); len(errs) != 1 || !strings.Contains(errs[0].Error(), "internal field policy violated") {
+		t.Errorf("expected ValidateConventions to report the transform error, got %v", errs)
+	}
+	if _, err := FindOrphanTypes(); err == nil {
+		t.Error("expected FindOrphanTypes to return the transform error")
+	}
+	if _, err := AuthSummary(); err == nil {
+		t.Error("expected AuthSummary to return the transform error")
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected SchemaHash to panic on the transform error")
+			}
+		}()
+		SchemaHash()
+	}()
+	if report := Validate(); len(report.Errors) != 1 || report.Errors[0].Category != "schema" {
+		t.Errorf("expected Validate to report the transform error, got %+v", report)
+	}
+
+	if _, err := GetSchemaE(); err == nil {
+		t.Error("expected GetSchemaE to return the transform error")
+	}
+	if _, err := GetSchemaForProfile("oss"); err == nil {
+		t.Error("expected GetSchemaForProfile to return the transform error")
+	}
+	// GetSchema cannot report the error and falls back to the untransformed schema.
+	if len(GetSchema().Types) != 1 {
+		t.Error("GetSchema should return the schema without transforms on failure")
+	}
+}
+
+func TestSchemaTransformModifiesCopy(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}, {Name: "ssn", Type: "String"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := NewQuery("users").ReturnType("User").Config(map[string]interface{}{"tags": []string{"public"}}).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	RegisterSchemaTransform(func(s *Schema) error {
+		s.Types[0].Fields[1].Type = "Int"
+		s.Queries[0].Config["tags"].([]string)[0] = "internal"
+		return nil
+	})
+	GetSchema()
+
+	reg := getInstance()
+	if got := reg.types["User"].Fields[1].Type; got != "String" {
+		t.Errorf("registered field type = %q, transforms must not modify the registry", got)
+	}
+	if got := reg.queries["users"].Config["tags"].([]string)[0]; got != "public" {
+		t.Errorf("registered config tag = %q, transforms must not modify the registry", got)
+	}
+}
+
+func TestResetClearsSchemaTransforms(t *testing.T) {
+	Reset()
+	RegisterSchemaTransform(func(s *Schema) error {
+		return errors.New("should not run")
+	})
+	Reset()
+
+	if _, err := GetSchemaJSON(false); err != nil {
+		t.Errorf("expected transforms to be cleared by Reset, got %v", err)
+	}
+}
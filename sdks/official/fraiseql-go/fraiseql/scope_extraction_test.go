@@ -2,7 +2,10 @@ package fraiseql
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -509,6 +512,171 @@ func TestConflictingBothScopeAndScopes(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// CUSTOM SCOPE VALIDATOR
+// ============================================================================
+
+// threeSegmentScope accepts resource:action:qualifier scopes such as orders:read:own.
+func threeSegmentScope(scope string) error {
+	parts := strings.Split(scope, ":")
+	if len(parts) != 3 {
+		return fmt.Errorf("expected resource:action:qualifier, got %d segments", len(parts))
+	}
+	for _, p := range parts {
+		if p == "" {
+			return errors.New("empty segment")
+		}
+	}
+	return nil
+}
+
+func TestCustomScopeValidator(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Order struct {
+		ID    int     `fraiseql:"id,type=Int"`
+		Total float64 `fraiseql:"total,type=Float,scope=orders:read:own"`
+		Notes string  `fraiseql:"notes,type=String,scopes=urn:acme:read;support"`
+	}
+
+	// The built-in action:resource grammar rejects colons in the resource.
+	if _, err := ExtractFields(reflect.TypeOf(Order{})); err == nil {
+		t.Fatal("default validator should reject three-segment scopes")
+	}
+
+	SetScopeValidator(threeSegmentScope)
+
+	fields, err := ExtractFields(reflect.TypeOf(Order{}))
+	if err != nil {
+		t.Fatalf("custom validator should accept three-segment scopes: %v", err)
+	}
+	if fields["total"].Scope != "orders:read:own" {
+		t.Errorf("expected scope orders:read:own, got %q", fields["total"].Scope)
+	}
+	if !contains(fields["notes"].Scopes, "urn:acme:read") || !contains(fields["notes"].Scopes, "support") {
+		t.Errorf("unexpected scopes: %v", fields["notes"].Scopes)
+	}
+
+	type TwoSegment struct {
+		Field string `fraiseql:"field,type=String,scope=read:user"`
+	}
+	_, err = ExtractFields(reflect.TypeOf(TwoSegment{}))
+	if err == nil || !strings.Contains(err.Error(), "expected resource:action:qualifier") {
+		t.Errorf("custom validator error should be surfaced, got %v", err)
+	}
+}
+
+func TestCustomScopeValidatorSkipsRoleNames(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	var seen []string
+	SetScopeValidator(func(scope string) error {
+		seen = append(seen, scope)
+		return nil
+	})
+
+	type Report struct {
+		Body string `fraiseql:"body,type=String,scopes=admin;read:user"`
+	}
+	if _, err := ExtractFields(reflect.TypeOf(Report{})); err != nil {
+		t.Fatalf("ExtractFields: %v", err)
+	}
+	if strings.Join(seen, ",") != "read:user" {
+		t.Errorf("validator saw %v, want only the read:user scope", seen)
+	}
+
+	type BadRole struct {
+		Body string `fraiseql:"body,type=String,scopes=admin-team"`
+	}
+	if _, err := ExtractFields(reflect.TypeOf(BadRole{})); err == nil || !strings.Contains(err.Error(), "invalid role name") {
+		t.Errorf("role names should follow the role grammar, got %v", err)
+	}
+}
+
+func TestResetRestoresDefaultScopeValidator(t *testing.T) {
+	Reset()
+	SetScopeValidator(func(string) error { return errors.New("always rejects") })
+	Reset()
+	defer Reset()
+
+	type UserWithScope struct {
+		Email string `fraiseql:"email,type=String,scope=read:user.email"`
+	}
+	if _, err := ExtractFields(reflect.TypeOf(UserWithScope{})); err != nil {
+		t.Errorf("Reset should restore the default validator: %v", err)
+	}
+}
+
+func TestValidateScope(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	tests := []struct {
+		scope   string
+		wantErr string
+	}{
+		{scope: "read:user.email"},
+		{scope: "admin:*"},
+		{scope: "*"},
+		{scope: "", wantErr: "empty scope"},
+		{scope: "read", wantErr: "missing colon"},
+		{scope: "read-all:user", wantErr: "invalid action"},
+		{scope: "read:user-email", wantErr: "invalid resource"},
+	}
+	for _, tt := range tests {
+		err := ValidateScope(tt.scope)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("ValidateScope(%q) = %v, want nil", tt.scope, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ValidateScope(%q) = %v, want error containing %q", tt.scope, err, tt.wantErr)
+		}
+	}
+
+	// A dynamically built scope follows the custom grammar once installed.
+	tenant := "acme"
+	scope := fmt.Sprintf("orders:read:%s", tenant)
+	if err := ValidateScope(scope); err == nil {
+		t.Errorf("built-in grammar should reject %q", scope)
+	}
+	SetScopeValidator(threeSegmentScope)
+	if err := ValidateScope(scope); err != nil {
+		t.Errorf("custom validator should accept %q: %v", scope, err)
+	}
+}
+
+func TestListScopes(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Employee struct {
+		ID     int     `fraiseql:"id,type=Int"`
+		Email  string  `fraiseql:"email,type=String,scope=read:user.email"`
+		Salary float64 `fraiseql:"salary,type=Float,scopes=read:User.salary;admin"`
+	}
+	type Payroll struct {
+		Total float64 `fraiseql:"total,type=Float,scope=read:User.salary"`
+		Notes string  `fraiseql:"notes,type=String,scope=*"`
+	}
+	type RaiseInput struct {
+		Amount float64 `fraiseql:"amount,type=Float,scope=write:User.salary"`
+	}
+	if err := RegisterTypes(Employee{}, Payroll{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := RegisterInputTypes(RaiseInput{}); err != nil {
+		t.Fatalf("RegisterInputTypes: %v", err)
+	}
+
+	want := []string{"*", "read:User.salary", "read:user.email", "write:User.salary"}
+	if got := ListScopes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ListScopes() = %v, want %v", got, want)
+	}
+}
+
 // ============================================================================
 // TEST HELPERS
 // ============================================================================
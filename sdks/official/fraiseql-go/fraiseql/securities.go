@@ -0,0 +1,155 @@
+package fraiseql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SecurityIdentifierConfig names the ISIN and CUSIP fields of a type that
+// identify the same security. The runtime cross-checks them: for US and
+// Canadian securities the ISIN embeds the CUSIP as its national code.
+type SecurityIdentifierConfig struct {
+	ISINField  string `json:"isin_field"`
+	CUSIPField string `json:"cusip_field"`
+}
+
+// SecurityIdentifiers declares that the ISIN field isinField and the CUSIP
+// field cusipField of a registered type identify the same security, so the
+// type is exported with a "security_identifiers" config the runtime uses to
+// reject inconsistent pairs.
+//
+// Returns an error if the type is not registered, or if either field is
+// missing or not of the matching scalar type.
+//
+// Example:
+//
+//	fraiseql.RegisterTypes(Security{})
+//	fraiseql.SecurityIdentifiers("Security", "isin", "cusip")
+func SecurityIdentifiers(typeName, isinField, cusipField string) error {
+	return getInstance().declareType("SecurityIdentifiers", typeName, func(def TypeDefinition) (TypeDefinition, error) {
+		if isinField == cusipField {
+			return def, fmt.Errorf("type %q: ISIN and CUSIP fields must differ, both are %q", typeName, isinField)
+		}
+		for _, want := range []struct{ field, scalar string }{{isinField, "ISIN"}, {cusipField, "CUSIP"}} {
+			if err := requireFieldScalar(def, want.field, want.scalar); err != nil {
+				return def, err
+			}
+		}
+		def.SecurityIdentifiers = &SecurityIdentifierConfig{ISINField: isinField, CUSIPField: cusipField}
+		return def, nil
+	})
+}
+
+// requireFieldScalar checks that def has a field with the given name typed as
+// scalar.
+func requireFieldScalar(def TypeDefinition, field, scalar string) error {
+	for _, f := range def.Fields {
+		if f.Name != field {
+			continue
+		}
+		if strings.TrimSuffix(f.Type, "!") != scalar {
+			return fmt.Errorf("type %q: field %q must be of type %s, got %s", def.Name, field, scalar, f.Type)
+		}
+		return nil
+	}
+	return fmt.Errorf("type %q has no field %q", def.Name, field)
+}
+
+// ValidateISIN reports whether s is a well-formed ISIN: a two-letter country
+// code, a nine-character alphanumeric national code and a Luhn check digit
+// computed over the letters expanded to numbers (A=10 ... Z=35).
+func ValidateISIN(s string) bool {
+	if len(s) != 12 || !isUpperLetter(s[0]) || !isUpperLetter(s[1]) || !isDigit(rune(s[11])) {
+		return false
+	}
+	var digits []byte
+	for i := 0; i < len(s); i++ {
+		v, ok := alnumValue(s[i])
+		if !ok {
+			return false
+		}
+		digits = strconv.AppendInt(digits, int64(v), 10)
+	}
+
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-1-i)%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// ValidateCUSIP reports whether s is a well-formed CUSIP: eight characters
+// (digits, letters, '*', '@' or '#') followed by a check digit computed with
+// the "double add double" algorithm.
+func ValidateCUSIP(s string) bool {
+	if len(s) != 9 || !isDigit(rune(s[8])) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 8; i++ {
+		var v int
+		switch c := s[i]; c {
+		case '*':
+			v = 36
+		case '@':
+			v = 37
+		case '#':
+			v = 38
+		default:
+			var ok bool
+			if v, ok = alnumValue(c); !ok {
+				return false
+			}
+		}
+		if i%2 == 1 {
+			v *= 2
+		}
+		sum += v/10 + v%10
+	}
+	return (10-sum%10)%10 == int(s[8]-'0')
+}
+
+// sedolWeights are the weights of the first six characters of a SEDOL.
+var sedolWeights = [6]int{1, 3, 1, 7, 3, 9}
+
+// ValidateSEDOL reports whether s is a well-formed SEDOL: six characters
+// (digits or consonants) followed by a weighted check digit.
+func ValidateSEDOL(s string) bool {
+	if len(s) != 7 || !isDigit(rune(s[6])) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 6; i++ {
+		if strings.IndexByte("AEIOU", s[i]) >= 0 {
+			return false
+		}
+		v, ok := alnumValue(s[i])
+		if !ok {
+			return false
+		}
+		sum += v * sedolWeights[i]
+	}
+	return (10-sum%10)%10 == int(s[6]-'0')
+}
+
+// alnumValue maps '0'-'9' to 0-9 and 'A'-'Z' to 10-35, as the securities
+// identifier check digit algorithms do.
+func alnumValue(c byte) (int, bool) {
+	switch {
+	case isDigit(rune(c)):
+		return int(c - '0'), true
+	case isUpperLetter(c):
+		return int(c-'A') + 10, true
+	}
+	return 0, false
+}
+
+func isUpperLetter(c byte) bool { return c >= 'A' && c <= 'Z' }
@@ -0,0 +1,125 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSecurityIdentifiers(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(string) bool
+		value    string
+		want     bool
+	}{
+		{"ISIN Apple", ValidateISIN, "US0378331005", true},
+		{"ISIN with letters", ValidateISIN, "AU0000XVGZA3", true},
+		{"ISIN BAE Systems", ValidateISIN, "GB0002634946", true},
+		{"ISIN bad check digit", ValidateISIN, "US0378331006", false},
+		{"ISIN lowercase", ValidateISIN, "us0378331005", false},
+		{"ISIN too short", ValidateISIN, "US03783310", false},
+		{"ISIN numeric country", ValidateISIN, "120378331005", false},
+
+		{"CUSIP Apple", ValidateCUSIP, "037833100", true},
+		{"CUSIP with letter", ValidateCUSIP, "38259P508", true},
+		{"CUSIP Microsoft", ValidateCUSIP, "594918104", true},
+		{"CUSIP bad check digit", ValidateCUSIP, "037833101", false},
+		{"CUSIP invalid character", ValidateCUSIP, "03783$100", false},
+		{"CUSIP too long", ValidateCUSIP, "0378331000", false},
+
+		{"SEDOL BAE Systems", ValidateSEDOL, "0263494", true},
+		{"SEDOL with letters", ValidateSEDOL, "B0YBKJ7", true},
+		{"SEDOL bad check digit", ValidateSEDOL, "0263495", false},
+		{"SEDOL vowel", ValidateSEDOL, "B0YBKA7", false},
+		{"SEDOL too short", ValidateSEDOL, "026349", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.validate(tt.value); got != tt.want {
+				t.Errorf("validate(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecurityIdentifiersExport(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Security struct {
+		ID    ID    `fraiseql:"id"`
+		ISIN  ISIN  `fraiseql:"isin,type=ISIN"`
+		CUSIP CUSIP `fraiseql:"cusip,type=CUSIP,nullable=true"`
+	}
+	if err := RegisterTypes(Security{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := SecurityIdentifiers("Security", "isin", "cusip"); err != nil {
+		t.Fatalf("SecurityIdentifiers: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	if !strings.Contains(string(data), `"security_identifiers":{"isin_field":"isin","cusip_field":"cusip"}`) {
+		t.Errorf("expected security_identifiers in export, got %s", data)
+	}
+}
+
+func TestSecurityIdentifiersThenReRegister(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Bond struct {
+		ISIN  ISIN  `fraiseql:"isin,type=ISIN"`
+		CUSIP CUSIP `fraiseql:"cusip,type=CUSIP"`
+	}
+	if err := RegisterTypes(Bond{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := SecurityIdentifiers("Bond", "isin", "cusip"); err != nil {
+		t.Fatalf("SecurityIdentifiers: %v", err)
+	}
+	if err := RegisterTypes(Bond{}); err != nil {
+		t.Fatalf("registering the same struct again should be a no-op, got %v", err)
+	}
+	if GetSchema().Types[0].SecurityIdentifiers == nil {
+		t.Error("re-registering should keep the security identifiers")
+	}
+}
+
+func TestSecurityIdentifiersValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		typeName string
+		isin     string
+		cusip    string
+		wantErr  string
+	}{
+		{name: "unregistered type", typeName: "Bond", isin: "isin", cusip: "cusip", wantErr: `type "Bond" is not registered`},
+		{name: "missing field", typeName: "Security", isin: "isin", cusip: "cusipCode", wantErr: `has no field "cusipCode"`},
+		{name: "wrong scalar", typeName: "Security", isin: "name", cusip: "cusip", wantErr: `field "name" must be of type ISIN`},
+		{name: "same field", typeName: "Security", isin: "isin", cusip: "isin", wantErr: "must differ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			if err := RegisterType("Security", []FieldInfo{
+				{Name: "name", Type: "String"},
+				{Name: "isin", Type: "ISIN"},
+				{Name: "cusip", Type: "CUSIP"},
+			}, ""); err != nil {
+				t.Fatalf("RegisterType: %v", err)
+			}
+			err := SecurityIdentifiers(tt.typeName, tt.isin, tt.cusip)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
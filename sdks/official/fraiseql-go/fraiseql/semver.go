@@ -0,0 +1,186 @@
+package fraiseql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SemVer is a parsed semantic version, as defined by Semantic Versioning
+// 2.0.0 (https://semver.org).
+type SemVer struct {
+	Major, Minor, Patch uint64
+	// PreRelease holds the dot-separated pre-release identifiers, e.g.
+	// ["rc", "1"] for "1.0.0-rc.1".
+	PreRelease []string
+	// Build holds the dot-separated build metadata identifiers, which do not
+	// affect precedence.
+	Build []string
+}
+
+// ParseSemanticVersion parses s as a semantic version such as "1.2.3",
+// "1.0.0-alpha.1" or "1.0.0+20130313144700". A leading "v" is not accepted.
+func ParseSemanticVersion(s string) (SemVer, error) {
+	var v SemVer
+	rest := s
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		build, err := semverIdentifiers(s, rest[i+1:], "build metadata", false)
+		if err != nil {
+			return SemVer{}, err
+		}
+		v.Build = build
+		rest = rest[:i]
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		pre, err := semverIdentifiers(s, rest[i+1:], "pre-release", true)
+		if err != nil {
+			return SemVer{}, err
+		}
+		v.PreRelease = pre
+		rest = rest[:i]
+	}
+
+	core := strings.Split(rest, ".")
+	numbers := [3]*uint64{&v.Major, &v.Minor, &v.Patch}
+	if len(core) != len(numbers) {
+		return SemVer{}, fmt.Errorf("semantic version %q must have the form MAJOR.MINOR.PATCH", s)
+	}
+	for i, part := range core {
+		if !isNumericIdentifier(part) {
+			return SemVer{}, fmt.Errorf("semantic version %q: %q is not a number without leading zeros", s, part)
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return SemVer{}, fmt.Errorf("semantic version %q: %w", s, err)
+		}
+		*numbers[i] = n
+	}
+	return v, nil
+}
+
+// semverIdentifiers splits and checks the pre-release or build identifiers
+// of version. Numeric pre-release identifiers must not have leading zeros.
+func semverIdentifiers(version, list, what string, numericNoLeadingZero bool) ([]string, error) {
+	ids := strings.Split(list, ".")
+	for _, id := range ids {
+		if id == "" {
+			return nil, fmt.Errorf("semantic version %q: empty %s identifier", version, what)
+		}
+		for _, c := range id {
+			if !isDigit(c) && !isLetter(c) && c != '-' {
+				return nil, fmt.Errorf("semantic version %q: invalid character %q in %s", version, c, what)
+			}
+		}
+		if numericNoLeadingZero && isAllDigits(id) && !isNumericIdentifier(id) {
+			return nil, fmt.Errorf("semantic version %q: numeric %s identifier %q has a leading zero", version, what, id)
+		}
+	}
+	return ids, nil
+}
+
+// String returns the version in its canonical form.
+func (v SemVer) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.PreRelease) > 0 {
+		s += "-" + strings.Join(v.PreRelease, ".")
+	}
+	if len(v.Build) > 0 {
+		s += "+" + strings.Join(v.Build, ".")
+	}
+	return s
+}
+
+// Compare returns -1, 0 or +1 as v has lower, equal or higher precedence than
+// other. A pre-release version precedes its release, pre-release identifiers
+// are compared numerically or lexically in order, and build metadata is
+// ignored.
+func (v SemVer) Compare(other SemVer) int {
+	for _, pair := range [][2]uint64{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if c := compareUint(pair[0], pair[1]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(v.PreRelease) == 0 && len(other.PreRelease) == 0:
+		return 0
+	case len(v.PreRelease) == 0:
+		return 1
+	case len(other.PreRelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.PreRelease) && i < len(other.PreRelease); i++ {
+		if c := comparePreRelease(v.PreRelease[i], other.PreRelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(v.PreRelease)), uint64(len(other.PreRelease)))
+}
+
+// comparePreRelease orders two pre-release identifiers: numeric ones
+// numerically and below alphanumeric ones, which compare in ASCII order.
+func comparePreRelease(a, b string) int {
+	aNum, bNum := isAllDigits(a), isAllDigits(b)
+	switch {
+	case aNum && bNum:
+		if c := compareUint(uint64(len(a)), uint64(len(b))); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// CompareSemVer compares the semantic versions a and b, returning -1, 0 or +1
+// as a has lower, equal or higher precedence than b. An invalid version
+// precedes every valid one, and two invalid versions compare equal.
+func CompareSemVer(a, b string) int {
+	va, errA := ParseSemanticVersion(a)
+	vb, errB := ParseSemanticVersion(b)
+	switch {
+	case errA != nil && errB != nil:
+		return 0
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+	return va.Compare(vb)
+}
+
+// IsValidSemanticVersion reports whether s is a valid semantic version.
+func IsValidSemanticVersion(s string) bool {
+	_, err := ParseSemanticVersion(s)
+	return err == nil
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func isAllDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !isDigit(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNumericIdentifier reports whether s is a number without leading zeros.
+func isNumericIdentifier(s string) bool {
+	return isAllDigits(s) && (s == "0" || s[0] != '0')
+}
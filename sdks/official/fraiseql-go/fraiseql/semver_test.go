@@ -0,0 +1,100 @@
+package fraiseql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSemanticVersion(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    SemVer
+		wantErr bool
+	}{
+		{input: "1.2.3", want: SemVer{Major: 1, Minor: 2, Patch: 3}},
+		{input: "0.0.0", want: SemVer{}},
+		{input: "1.0.0-alpha.1", want: SemVer{Major: 1, PreRelease: []string{"alpha", "1"}}},
+		{input: "1.0.0-x-y.0", want: SemVer{Major: 1, PreRelease: []string{"x-y", "0"}}},
+		{input: "1.0.0+build.007", want: SemVer{Major: 1, Build: []string{"build", "007"}}},
+		{input: "2.1.0-rc.2+sha.5114f85", want: SemVer{Major: 2, Minor: 1, PreRelease: []string{"rc", "2"}, Build: []string{"sha", "5114f85"}}},
+		{input: "", wantErr: true},
+		{input: "1.2", wantErr: true},
+		{input: "1.2.3.4", wantErr: true},
+		{input: "v1.2.3", wantErr: true},
+		{input: "01.2.3", wantErr: true},
+		{input: "1.2.x", wantErr: true},
+		{input: "1.2.3-", wantErr: true},
+		{input: "1.2.3-alpha..1", wantErr: true},
+		{input: "1.2.3-01", wantErr: true},
+		{input: "1.2.3+", wantErr: true},
+		{input: "1.2.3-beta_1", wantErr: true},
+		{input: "99999999999999999999.0.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSemanticVersion(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseSemanticVersion(%q) = %v, want an error", tt.input, got)
+				}
+				if IsValidSemanticVersion(tt.input) {
+					t.Errorf("IsValidSemanticVersion(%q) = true", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSemanticVersion(%q): %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSemanticVersion(%q) = %#v, want %#v", tt.input, got, tt.want)
+			}
+			if got.String() != tt.input {
+				t.Errorf("String() = %q, want %q", got.String(), tt.input)
+			}
+		})
+	}
+}
+
+func TestCompareSemVerPrecedence(t *testing.T) {
+	// The precedence example from the Semantic Versioning specification.
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+		"10.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			want := compareUint(uint64(i), uint64(j))
+			if got := CompareSemVer(ordered[i], ordered[j]); got != want {
+				t.Errorf("CompareSemVer(%q, %q) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+}
+
+func TestCompareSemVerBuildAndInvalid(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0+build.1", "1.0.0+build.2", 0},
+		{"1.0.0-rc.1+a", "1.0.0-rc.1", 0},
+		{"not-a-version", "0.0.1", -1},
+		{"0.0.1", "1.2", 1},
+		{"garbage", "also garbage", 0},
+	}
+	for _, tt := range tests {
+		if got := CompareSemVer(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareSemVer(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package fraiseql
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// slugFolds transliterates common accented Latin letters, so Slugify keeps
+// "Café" as "cafe" rather than dropping the letter.
+var slugFolds = func() map[rune]string {
+	folds := map[rune]string{'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'ł': "l", 'đ': "d", 'ð': "d", 'þ': "th"}
+	for base, letters := range map[string]string{
+		"a": "àáâãäåāăą",
+		"c": "çćĉċč",
+		"e": "èéêëēĕėęě",
+		"g": "ĝğġģ",
+		"i": "ìíîïĩīĭįı",
+		"n": "ñńņňŉ",
+		"o": "òóôõöōŏő",
+		"r": "ŕŗř",
+		"s": "śŝşš",
+		"t": "ţťŧ",
+		"u": "ùúûüũūŭůűų",
+		"y": "ýÿŷ",
+		"z": "źżž",
+	} {
+		for _, r := range letters {
+			folds[r] = base
+		}
+	}
+	return folds
+}()
+
+// Slugify turns s into a URL slug: lower case ASCII letters and digits in
+// runs joined by single hyphens, e.g. "Hello, World!" becomes "hello-world".
+// Accented Latin letters are transliterated ("Déjà Vu" becomes "deja-vu"),
+// apostrophes and other letters are dropped, and any other characters
+// separate words.
+func Slugify(s string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(s) {
+		var word string
+		switch {
+		case isLetter(r) || isDigit(r):
+			word = string(r)
+		case slugFolds[r] != "":
+			word = slugFolds[r]
+		case r == '\'' || r == '’' || unicode.IsLetter(r) || unicode.IsMark(r):
+			continue
+		default:
+			pendingHyphen = b.Len() > 0
+			continue
+		}
+		if pendingHyphen {
+			b.WriteByte('-')
+			pendingHyphen = false
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
+
+// IsValidSlug reports whether s is a slug as Slugify produces them: one or
+// more runs of lower case ASCII letters and digits separated by single
+// hyphens.
+func IsValidSlug(s string) bool {
+	if s == "" || s[0] == '-' || s[len(s)-1] == '-' || strings.Contains(s, "--") {
+		return false
+	}
+	for _, r := range s {
+		if r != '-' && !isDigit(r) && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+// validateSlugFields checks each slugFrom annotation: the slug field must be
+// a Slug or String and the source field must exist and be a String.
+func validateSlugFields(def TypeDefinition) error {
+	for _, f := range def.Fields {
+		if f.SlugFrom == "" {
+			continue
+		}
+		if t := namedType(f.Type); t != "Slug" && t != "String" {
+			return fmt.Errorf(
+				"type %q: field %q has slugFrom but type %s; only Slug or String fields can be derived",
+				def.Name, f.Name, f.Type,
+			)
+		}
+		if f.SlugFrom == f.Name {
+			return fmt.Errorf("type %q: field %q cannot derive its slug from itself", def.Name, f.Name)
+		}
+		var source *FieldInfo
+		for i := range def.Fields {
+			if def.Fields[i].Name == f.SlugFrom {
+				source = &def.Fields[i]
+				break
+			}
+		}
+		if source == nil {
+			return fmt.Errorf(
+				"type %q: field %q derives its slug from %q, which does not exist",
+				def.Name, f.Name, f.SlugFrom,
+			)
+		}
+		if source.Type != "String" && source.Type != "String!" {
+			return fmt.Errorf(
+				"type %q: slug source field %q of %q has type %s; it must be a String",
+				def.Name, source.Name, f.Name, source.Type,
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Hello World", "hello-world"},
+		{"Hello, World!", "hello-world"},
+		{"  multiple   spaces  ", "multiple-spaces"},
+		{"--leading and trailing--", "leading-and-trailing"},
+		{"already-a-slug", "already-a-slug"},
+		{"snake_case_title", "snake-case-title"},
+		{"Don't Stop", "dont-stop"},
+		{"Release v1.2", "release-v1-2"},
+		{"Café Déjà Vu", "cafe-deja-vu"},
+		{"Straße", "strasse"},
+		{"Ελληνικά title", "title"},
+		{"日本語", ""},
+		{"", ""},
+		{"!!!", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := Slugify(tt.input)
+			if got != tt.want {
+				t.Errorf("Slugify(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if got != "" && !IsValidSlug(got) {
+				t.Errorf("Slugify(%q) = %q, which IsValidSlug rejects", tt.input, got)
+			}
+		})
+	}
+}
+
+func TestIsValidSlug(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"hello-world", true},
+		{"a", true},
+		{"2024-roadmap", true},
+		{"", false},
+		{"Hello-World", false},
+		{"-hello", false},
+		{"hello-", false},
+		{"hello--world", false},
+		{"hello world", false},
+		{"hello_world", false},
+		{"café", false},
+	}
+	for _, tt := range tests {
+		if got := IsValidSlug(tt.input); got != tt.want {
+			t.Errorf("IsValidSlug(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSlugFromTag(t *testing.T) {
+	type Article struct {
+		Title string `fraiseql:"title"`
+		Slug  string `fraiseql:"slug,type=Slug,slugFrom=title"`
+	}
+	type MissingSource struct {
+		Slug string `fraiseql:"slug,type=Slug,slugFrom=title"`
+	}
+	type NumericSource struct {
+		Rank int    `fraiseql:"rank"`
+		Slug string `fraiseql:"slug,type=Slug,slugFrom=rank"`
+	}
+	type WrongTarget struct {
+		Title string `fraiseql:"title"`
+		Rank  int    `fraiseql:"rank,slugFrom=title"`
+	}
+	type SlugInput struct {
+		Title string `fraiseql:"title"`
+		Slug  string `fraiseql:"slug,type=Slug,slugFrom=title"`
+	}
+
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(Article{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if got := getInstance().types["Article"].Fields[1].SlugFrom; got != "title" {
+		t.Errorf("SlugFrom = %q, want title", got)
+	}
+
+	tests := []struct {
+		name     string
+		register func() error
+		wantErr  string
+	}{
+		{"missing source", func() error { return RegisterTypes(MissingSource{}) }, `"title", which does not exist`},
+		{"numeric source", func() error { return RegisterTypes(NumericSource{}) }, "it must be a String"},
+		{"wrong target", func() error { return RegisterTypes(WrongTarget{}) }, "only Slug or String fields"},
+		{"input type", func() error { return RegisterInputTypes(SlugInput{}) }, "only derived for output type fields"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package fraiseql
+
+import "fmt"
+
+// withSourceNames returns fields with each SourceName defaulted to the field
+// name, leaving computed (resolver=) and JSONB fields, which have no column of
+// their own, without one. fields is copied rather than modified.
+func withSourceNames(owner string, fields []FieldInfo) ([]FieldInfo, error) {
+	var result []FieldInfo
+	for i, f := range fields {
+		if f.SourceName != "" {
+			if f.Resolver != "" || f.JsonbColumn != "" {
+				return nil, fmt.Errorf(
+					"type %q: field %q has source %q, but computed and JSONB fields are not read from a column",
+					owner, f.Name, f.SourceName,
+				)
+			}
+			continue
+		}
+		if f.Resolver != "" || f.JsonbColumn != "" {
+			continue
+		}
+		if result == nil {
+			result = make([]FieldInfo, len(fields))
+			copy(result, fields)
+		}
+		result[i].SourceName = f.Name
+	}
+	if result == nil {
+		return fields, nil
+	}
+	return result, nil
+}
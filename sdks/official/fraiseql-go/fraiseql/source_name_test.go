@@ -0,0 +1,89 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSourceNames(t *testing.T) {
+	type Event struct {
+		ID        string `fraiseql:"id,type=ID"`
+		CreatedAt string `fraiseql:"createdAt,type=DateTime,source=created_at"`
+		Title     string `fraiseql:"title"`
+		Summary   string `fraiseql:"summary,resolver=fn_event_summary"`
+		Venue     string `fraiseql:"venue,jsonb=details"`
+	}
+
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(Event{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	want := map[string]string{
+		"id":        "id",
+		"createdAt": "created_at",
+		"title":     "title",
+		"summary":   "",
+		"venue":     "",
+	}
+	for _, f := range getInstance().types["Event"].Fields {
+		if f.SourceName != want[f.Name] {
+			t.Errorf("field %s: SourceName = %q, want %q", f.Name, f.SourceName, want[f.Name])
+		}
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	if want := `{"name":"createdAt","type":"DateTime","nullable":false,"source_name":"created_at"}`; !strings.Contains(string(data), want) {
+		t.Errorf("expected %s in export, got %s", want, data)
+	}
+}
+
+func TestSourceNamesOnRegisterTypeAndInputs(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	fields := []FieldInfo{{Name: "userId", Type: "ID", SourceName: "user_id"}, {Name: "email", Type: "Email"}}
+	if err := RegisterType("Member", fields, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if fields[1].SourceName != "" {
+		t.Errorf("RegisterType modified the caller's fields: %+v", fields[1])
+	}
+	if got := getInstance().types["Member"].Fields[1].SourceName; got != "email" {
+		t.Errorf("defaulted SourceName = %q, want email", got)
+	}
+
+	type MemberInput struct {
+		DisplayName string `fraiseql:"displayName,source=display_name"`
+	}
+	if err := RegisterInputTypes(MemberInput{}); err != nil {
+		t.Fatalf("RegisterInputTypes: %v", err)
+	}
+	if got := getInstance().inputTypes["MemberInput"].Fields[0].SourceName; got != "display_name" {
+		t.Errorf("input SourceName = %q, want display_name", got)
+	}
+}
+
+func TestSourceNameValidation(t *testing.T) {
+	type Empty struct {
+		Name string `fraiseql:"name,source="`
+	}
+	type Computed struct {
+		Name string `fraiseql:"name,source=name,resolver=fn_name"`
+	}
+	type Document struct {
+		Name string `fraiseql:"name,source=name,jsonb=data"`
+	}
+
+	for name, v := range map[string]interface{}{"empty": Empty{}, "computed": Computed{}, "jsonb": Document{}} {
+		Reset()
+		if err := RegisterTypes(v); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	Reset()
+}
@@ -0,0 +1,114 @@
+package fraiseql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// spatialFilter is a geospatial filter added by WithinRadius or WithinBoundingBox.
+type spatialFilter struct {
+	field        string
+	arg          string
+	operator     string
+	radiusMeters float64
+}
+
+// boundingBoxType is the argument type of a WithinBoundingBox filter: a
+// GeoJSON bbox of four numbers, [minLongitude, minLatitude, maxLongitude,
+// maxLatitude].
+const boundingBoxType = "[Float!]"
+
+// WithinRadius restricts the query to rows whose Coordinates field lies
+// within radiusMeters of the point passed in the named argument, e.g. stores
+// near a customer. The compiler generates a PostGIS
+// `ST_DWithin(field::geography, $arg::geography, radiusMeters)` WHERE clause,
+// so the distance is measured on the spheroid in meters.
+//
+// The argument is declared as a required Coordinates unless it was already
+// added with Arg. Register checks that field is Coordinates-typed on the
+// return type and that the radius is positive.
+//
+// Example:
+//
+//	fraiseql.NewQuery("storesNearby").
+//		ReturnType(Store{}).
+//		ReturnsArray(true).
+//		WithinRadius("location", "center", 5000).
+//		Register()
+func (qb *QueryBuilder) WithinRadius(field, centerArg string, radiusMeters float64) *QueryBuilder {
+	if qb.argIndex(centerArg) < 0 {
+		qb.addArg(centerArg, "Coordinates", nil)
+	}
+	qb.spatialFilters = append(qb.spatialFilters, spatialFilter{
+		field:        field,
+		arg:          centerArg,
+		operator:     "ST_DWithin",
+		radiusMeters: radiusMeters,
+	})
+	return qb
+}
+
+// WithinBoundingBox restricts the query to rows whose Coordinates field
+// intersects the bounding box passed in the named argument, e.g. the visible
+// area of a map. The compiler builds the box with ST_MakeEnvelope and
+// generates a `field && envelope` WHERE clause, which uses a GiST index.
+//
+// The argument is declared as a required [Float!]! GeoJSON bbox,
+// [minLongitude, minLatitude, maxLongitude, maxLatitude], unless it was
+// already added with Arg. Register checks that field is Coordinates-typed on
+// the return type. See WithinRadius.
+func (qb *QueryBuilder) WithinBoundingBox(field, bboxArg string) *QueryBuilder {
+	if qb.argIndex(bboxArg) < 0 {
+		qb.addArg(bboxArg, boundingBoxType+"!", nil)
+	}
+	qb.spatialFilters = append(qb.spatialFilters, spatialFilter{field: field, arg: bboxArg, operator: "&&"})
+	return qb
+}
+
+// validateSpatialFilters checks each spatial filter against the registered
+// return type and the query's arguments.
+func (qb *QueryBuilder) validateSpatialFilters() error {
+	for _, f := range qb.spatialFilters {
+		if err := qb.requireReturnFieldType("spatial filters", f.field, "Coordinates"); err != nil {
+			return err
+		}
+		arg := qb.arguments[qb.argIndex(f.arg)]
+		switch f.operator {
+		case "ST_DWithin":
+			if f.radiusMeters <= 0 {
+				return fmt.Errorf("query %q: WithinRadius radius must be positive, got %v meters", qb.name, f.radiusMeters)
+			}
+			if namedType(arg.Type) != "Coordinates" {
+				return fmt.Errorf("query %q: argument %q must be of type Coordinates, got %s", qb.name, f.arg, arg.Type)
+			}
+		case "&&":
+			if strings.TrimSuffix(arg.Type, "!") != boundingBoxType {
+				return fmt.Errorf(
+					"query %q: argument %q must be of type %s! (minLongitude, minLatitude, maxLongitude, maxLatitude), got %s",
+					qb.name, f.arg, boundingBoxType, arg.Type,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// spatialFilterConfig returns the "spatial_filters" config value in call order.
+func (qb *QueryBuilder) spatialFilterConfig() []map[string]interface{} {
+	filters := make([]map[string]interface{}, len(qb.spatialFilters))
+	for i, f := range qb.spatialFilters {
+		filter := map[string]interface{}{
+			"field":    f.field,
+			"operator": f.operator,
+			"arg":      f.arg,
+		}
+		if f.operator == "ST_DWithin" {
+			filter["cast"] = "geography"
+			filter["radius_meters"] = f.radiusMeters
+		} else {
+			filter["envelope"] = "ST_MakeEnvelope"
+		}
+		filters[i] = filter
+	}
+	return filters
+}
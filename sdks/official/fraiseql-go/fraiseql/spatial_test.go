@@ -0,0 +1,131 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// geoStore has a Coordinates location for spatial filters.
+type geoStore struct {
+	ID       ID          `fraiseql:"id"`
+	Name     string      `fraiseql:"name"`
+	Location Coordinates `fraiseql:"location,type=Coordinates"`
+}
+
+func TestSpatialFilterConfig(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(geoStore{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := NewQuery("storesOnMap").
+		ReturnType(geoStore{}).
+		ReturnsArray(true).
+		WithinRadius("location", "center", 2500).
+		WithinBoundingBox("location", "bbox").
+		Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	var exported struct {
+		Queries []struct {
+			Arguments []ArgumentDefinition `json:"arguments"`
+			Config    struct {
+				SpatialFilters []map[string]interface{} `json:"spatial_filters"`
+			} `json:"config"`
+		} `json:"queries"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	q := exported.Queries[0]
+
+	filters := q.Config.SpatialFilters
+	if len(filters) != 2 {
+		t.Fatalf("expected 2 spatial filters, got %v", filters)
+	}
+	radius := map[string]interface{}{
+		"field": "location", "operator": "ST_DWithin", "arg": "center", "cast": "geography", "radius_meters": 2500.0,
+	}
+	for k, v := range radius {
+		if filters[0][k] != v {
+			t.Errorf("radius filter %s: want %v, got %v", k, v, filters[0][k])
+		}
+	}
+	bbox := map[string]interface{}{"field": "location", "operator": "&&", "arg": "bbox", "envelope": "ST_MakeEnvelope"}
+	for k, v := range bbox {
+		if filters[1][k] != v {
+			t.Errorf("bounding box filter %s: want %v, got %v", k, v, filters[1][k])
+		}
+	}
+
+	if len(q.Arguments) != 2 || q.Arguments[0].Type != "Coordinates" || q.Arguments[1].Type != "[Float!]!" ||
+		q.Arguments[0].Nullable || q.Arguments[1].Nullable {
+		t.Errorf("expected required center and bbox arguments to be declared, got %+v", q.Arguments)
+	}
+}
+
+func TestSpatialFilterValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func() *QueryBuilder
+		wantErr string
+	}{
+		{
+			name: "field is not Coordinates",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(geoStore{}).WithinRadius("name", "center", 100)
+			},
+			wantErr: `field "name" on type "geoStore" is String, spatial filters require a field of type Coordinates`,
+		},
+		{
+			name: "radius not positive",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(geoStore{}).WithinRadius("location", "center", 0)
+			},
+			wantErr: "radius must be positive",
+		},
+		{
+			name: "center argument is not Coordinates",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(geoStore{}).Arg("center", "String", nil).WithinRadius("location", "center", 100)
+			},
+			wantErr: `argument "center" must be of type Coordinates`,
+		},
+		{
+			name: "bounding box argument is not a list of floats",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(geoStore{}).Arg("bbox", "Json", nil).WithinBoundingBox("location", "bbox")
+			},
+			wantErr: `argument "bbox" must be of type [Float!]!`,
+		},
+		{
+			name: "unknown field",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(geoStore{}).WithinBoundingBox("area", "bbox")
+			},
+			wantErr: `type "geoStore" has no field "area"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+			if err := RegisterTypes(geoStore{}); err != nil {
+				t.Fatalf("RegisterTypes: %v", err)
+			}
+
+			err := tt.build().Register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
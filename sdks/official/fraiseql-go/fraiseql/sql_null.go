@@ -0,0 +1,42 @@
+package fraiseql
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// sqlNullScalars maps the database/sql null wrappers to the scalar they hold.
+var sqlNullScalars = map[reflect.Type]string{
+	reflect.TypeOf(sql.NullString{}):  "String",
+	reflect.TypeOf(sql.NullInt64{}):   "Int",
+	reflect.TypeOf(sql.NullInt32{}):   "Int",
+	reflect.TypeOf(sql.NullInt16{}):   "Int",
+	reflect.TypeOf(sql.NullByte{}):    "Int",
+	reflect.TypeOf(sql.NullFloat64{}): "Float",
+	reflect.TypeOf(sql.NullBool{}):    "Boolean",
+	reflect.TypeOf(sql.NullTime{}):    "DateTime",
+}
+
+// sqlNullType maps a database/sql null wrapper — sql.NullString and friends,
+// or the generic sql.Null[T] — to the GraphQL type of the value it holds. The
+// wrappers are structs, so without this they would export under their Go
+// name. ok is false when goType is not such a wrapper.
+func sqlNullType(goType reflect.Type) (graphQLType string, ok bool, err error) {
+	if scalar, found := sqlNullScalars[goType]; found {
+		return scalar, true, nil
+	}
+	if goType.PkgPath() != "database/sql" || !strings.HasPrefix(goType.Name(), "Null[") {
+		return "", false, nil
+	}
+	v, found := goType.FieldByName("V")
+	if !found {
+		return "", false, nil
+	}
+	if v.Type == reflect.TypeOf(time.Time{}) {
+		return "DateTime", true, nil
+	}
+	graphQLType, _, err = goToGraphQLType(v.Type)
+	return graphQLType, true, err
+}
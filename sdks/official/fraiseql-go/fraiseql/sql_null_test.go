@@ -0,0 +1,54 @@
+package fraiseql
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+func TestExtractFieldsSQLNullTypes(t *testing.T) {
+	type Account struct {
+		Nickname  sql.NullString   `fraiseql:"nickname"`
+		Visits    sql.NullInt64    `fraiseql:"visits"`
+		Rank      sql.NullInt32    `fraiseql:"rank"`
+		Floor     sql.NullInt16    `fraiseql:"floor"`
+		Flags     sql.NullByte     `fraiseql:"flags"`
+		Balance   sql.NullFloat64  `fraiseql:"balance"`
+		Verified  sql.NullBool     `fraiseql:"verified"`
+		DeletedAt sql.NullTime     `fraiseql:"deletedAt"`
+		Score     sql.Null[int]    `fraiseql:"score"`
+		Label     sql.Null[string] `fraiseql:"label"`
+		Tags      []sql.NullString `fraiseql:"tags"`
+	}
+
+	fields, err := ExtractFields(reflect.TypeOf(Account{}))
+	if err != nil {
+		t.Fatalf("ExtractFields: %v", err)
+	}
+
+	tests := []struct {
+		field    string
+		typ      string
+		nullable bool
+	}{
+		{"nickname", "String", true},
+		{"visits", "Int", true},
+		{"rank", "Int", true},
+		{"floor", "Int", true},
+		{"flags", "Int", true},
+		{"balance", "Float", true},
+		{"verified", "Boolean", true},
+		{"deletedAt", "DateTime", true},
+		{"score", "Int", true},
+		{"label", "String", true},
+		{"tags", "[String]", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got := fields[tt.field]
+			if got.Type != tt.typ || got.Nullable != tt.nullable {
+				t.Errorf("%s = (%s, nullable=%v), want (%s, nullable=%v)", tt.field, got.Type, got.Nullable, tt.typ, tt.nullable)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package fraiseql
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IsValidTimezone reports whether s names a zone in the IANA time zone
+// database (e.g., "America/New_York"), as resolved by time.LoadLocation.
+// "UTC" is valid; the empty string and "Local", which time.LoadLocation
+// accepts but are not zone names, are not.
+//
+// time.LoadLocation reads the system's zoneinfo files. On systems without
+// them, such as minimal containers or Windows, every other name is invalid
+// unless the program embeds the database by importing time/tzdata:
+//
+//	import _ "time/tzdata"
+func IsValidTimezone(s string) bool {
+	if s == "" || s == "Local" || strings.ContainsAny(s, " \\") {
+		return false
+	}
+	_, err := time.LoadLocation(s)
+	return err == nil
+}
+
+// checkTimezoneField checks a field tagged validateTimezone=true: it must be
+// a Timezone, and its default, if any, must be a valid zone name.
+func checkTimezoneField(field FieldInfo) error {
+	if !field.ValidateTimezone {
+		return nil
+	}
+	if namedType(field.Type) != "Timezone" {
+		return fmt.Errorf("validateTimezone=true is only supported for Timezone fields, got %s", field.Type)
+	}
+	if zone, ok := field.Default.(string); ok && !IsValidTimezone(zone) {
+		return fmt.Errorf("default %q is not an IANA time zone name", zone)
+	}
+	return nil
+}
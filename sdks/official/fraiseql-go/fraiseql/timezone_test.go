@@ -0,0 +1,77 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+	_ "time/tzdata" // the tests must not depend on the host's zoneinfo files
+)
+
+func TestIsValidTimezone(t *testing.T) {
+	tests := []struct {
+		zone string
+		want bool
+	}{
+		{"America/New_York", true},
+		{"Europe/Paris", true},
+		{"Asia/Kolkata", true},
+		{"UTC", true},
+		{"America/New_Yrok", false},
+		{"Mars/Olympus_Mons", false},
+		{"EST5EDT ", false},
+		{"", false},
+		{"Local", false},
+		{"../etc/passwd", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.zone, func(t *testing.T) {
+			if got := IsValidTimezone(tt.zone); got != tt.want {
+				t.Errorf("IsValidTimezone(%q) = %v, want %v", tt.zone, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTimezoneTag(t *testing.T) {
+	type Office struct {
+		Zone Timezone `fraiseql:"zone,type=Timezone,validateTimezone=true"`
+	}
+	type OfficeInput struct {
+		Zone Timezone `fraiseql:"zone,type=Timezone,validateTimezone=true,default=Europe/London"`
+	}
+	type TypoInput struct {
+		Zone Timezone `fraiseql:"zone,type=Timezone,validateTimezone=true,default=America/New_Yrok"`
+	}
+	type UncheckedInput struct {
+		Zone Timezone `fraiseql:"zone,type=Timezone,default=America/New_Yrok"`
+	}
+	type WrongType struct {
+		Zone string `fraiseql:"zone,validateTimezone=true"`
+	}
+
+	Reset()
+	defer Reset()
+
+	if err := RegisterTypes(Office{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if field := getInstance().types["Office"].Fields[0]; !field.ValidateTimezone {
+		t.Errorf("Office.zone ValidateTimezone = false, want true")
+	}
+	if err := RegisterInputTypes(OfficeInput{}, UncheckedInput{}); err != nil {
+		t.Fatalf("RegisterInputTypes: %v", err)
+	}
+
+	for name, register := range map[string]func() error{
+		"typo default": func() error { return RegisterInputTypes(TypoInput{}) },
+		"wrong type":   func() error { return RegisterTypes(WrongType{}) },
+	} {
+		err := register()
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+			continue
+		}
+		if !strings.Contains(err.Error(), "zone") {
+			t.Errorf("%s: error %q does not name the field", name, err)
+		}
+	}
+}
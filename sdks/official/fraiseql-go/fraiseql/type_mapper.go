@@ -0,0 +1,64 @@
+package fraiseql
+
+import (
+	"reflect"
+	"sync"
+)
+
+// TypeMapper maps a Go type to a GraphQL type during field extraction.
+//
+// It returns handled=false to defer to the next mapper (and ultimately to the
+// built-in mapping). When handled is true, graphqlType is used as the field's
+// GraphQL type and nullable marks it nullable.
+type TypeMapper func(goType reflect.Type) (graphqlType string, nullable bool, handled bool)
+
+// typeMapperRegistry holds the mappers consulted before the built-in Go→GraphQL mapping.
+type typeMapperRegistry struct {
+	mu      sync.RWMutex
+	mappers []TypeMapper
+}
+
+// Global instance
+var typeMappers = &typeMapperRegistry{}
+
+// RegisterTypeMapper registers a custom Go→GraphQL type mapping, consulted
+// before the built-in mapping whenever a field type is inferred. This lets
+// library types map automatically without a `type=` tag on every field.
+//
+// Mappers run in registration order and the first one that reports
+// handled=true wins. Pointer types are dereferenced before mappers are
+// consulted, and a pointer field stays nullable regardless of the mapper result.
+//
+// Example:
+//
+//	fraiseql.RegisterTypeMapper(func(t reflect.Type) (string, bool, bool) {
+//	    if t == reflect.TypeOf(decimal.Decimal{}) {
+//	        return "Decimal", false, true
+//	    }
+//	    return "", false, false
+//	})
+func RegisterTypeMapper(mapper TypeMapper) {
+	typeMappers.mu.Lock()
+	defer typeMappers.mu.Unlock()
+	typeMappers.mappers = append(typeMappers.mappers, mapper)
+}
+
+// ClearTypeMappers removes all registered type mappers (useful for testing).
+func ClearTypeMappers() {
+	typeMappers.mu.Lock()
+	defer typeMappers.mu.Unlock()
+	typeMappers.mappers = nil
+}
+
+// mapWithTypeMappers runs the registered mappers against goType in order.
+func mapWithTypeMappers(goType reflect.Type) (string, bool, bool) {
+	typeMappers.mu.RLock()
+	defer typeMappers.mu.RUnlock()
+
+	for _, mapper := range typeMappers.mappers {
+		if graphQLType, nullable, handled := mapper(goType); handled {
+			return graphQLType, nullable, true
+		}
+	}
+	return "", false, false
+}
@@ -0,0 +1,92 @@
+package fraiseql
+
+import (
+	"reflect"
+	"testing"
+)
+
+// libMoney stands in for a third-party money/decimal library type.
+type libMoney struct {
+	units int64
+	nanos int32
+}
+
+func libMoneyMapper(t reflect.Type) (string, bool, bool) {
+	if t == reflect.TypeOf(libMoney{}) {
+		return "Decimal", false, true
+	}
+	return "", false, false
+}
+
+func TestRegisterTypeMapper(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	RegisterTypeMapper(libMoneyMapper)
+
+	type Invoice struct {
+		ID       ID        `fraiseql:"id"`
+		Total    libMoney  `fraiseql:"total"`
+		Discount *libMoney `fraiseql:"discount"`
+		Subtotal libMoney
+	}
+
+	fields, err := ExtractFields(reflect.TypeOf(Invoice{}))
+	if err != nil {
+		t.Fatalf("ExtractFields: %v", err)
+	}
+
+	if f := fields["total"]; f.Type != "Decimal" || f.Nullable {
+		t.Errorf("total: want non-null Decimal, got %+v", f)
+	}
+	if f := fields["discount"]; f.Type != "Decimal" || !f.Nullable {
+		t.Errorf("discount: pointer should stay nullable Decimal, got %+v", f)
+	}
+	if f := fields["Subtotal"]; f.Type != "Decimal" {
+		t.Errorf("untagged Subtotal: want Decimal, got %+v", f)
+	}
+}
+
+func TestTypeMappersRunInRegistrationOrder(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	var calls []string
+	RegisterTypeMapper(func(t reflect.Type) (string, bool, bool) {
+		calls = append(calls, "skip")
+		return "", false, false
+	})
+	RegisterTypeMapper(func(t reflect.Type) (string, bool, bool) {
+		calls = append(calls, "first")
+		return "Json", true, true
+	})
+	RegisterTypeMapper(func(t reflect.Type) (string, bool, bool) {
+		calls = append(calls, "second")
+		return "String", false, true
+	})
+
+	graphQLType, nullable, err := goToGraphQLType(reflect.TypeOf(libMoney{}))
+	if err != nil {
+		t.Fatalf("goToGraphQLType: %v", err)
+	}
+	if graphQLType != "Json" || !nullable {
+		t.Errorf("first handling mapper should win, got (%s, %v)", graphQLType, nullable)
+	}
+	if !reflect.DeepEqual(calls, []string{"skip", "first"}) {
+		t.Errorf("unexpected mapper calls: %v", calls)
+	}
+}
+
+func TestResetClearsTypeMappers(t *testing.T) {
+	Reset()
+	RegisterTypeMapper(libMoneyMapper)
+	Reset()
+
+	graphQLType, _, err := goToGraphQLType(reflect.TypeOf(libMoney{}))
+	if err != nil {
+		t.Fatalf("goToGraphQLType: %v", err)
+	}
+	if graphQLType != "libMoney" {
+		t.Errorf("expected built-in mapping after Reset, got %q", graphQLType)
+	}
+}
@@ -0,0 +1,29 @@
+package fraiseql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// View sets the canonical SQL view or table of a registered type, replacing
+// the "v_" + snake_case(name) source derived at registration. The view is
+// exported as the type's "sql_source", so tools that map types to views, and
+// the compiler when it infers a source for queries returning the type, use
+// the declared name.
+//
+// Returns an error if view is empty or the type is not registered.
+//
+// Example:
+//
+//	fraiseql.RegisterTypes(User{})
+//	fraiseql.View("User", "v_user_profile")
+func View(typeName, view string) error {
+	if strings.TrimSpace(view) == "" {
+		return fmt.Errorf("View: type %q: view name must not be empty", typeName)
+	}
+
+	return getInstance().declareType("View", typeName, func(def TypeDefinition) (TypeDefinition, error) {
+		def.SqlSource = view
+		return def, nil
+	})
+}
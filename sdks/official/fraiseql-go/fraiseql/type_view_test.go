@@ -0,0 +1,94 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTypeView(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type User struct {
+		ID   ID     `fraiseql:"id"`
+		Name string `fraiseql:"name"`
+	}
+	if err := RegisterTypes(User{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if got := GetSchema().Types[0].SqlSource; got != "v_user" {
+		t.Errorf("derived sql_source: want v_user, got %q", got)
+	}
+	if err := View("User", "v_user_profile"); err != nil {
+		t.Fatalf("View: %v", err)
+	}
+
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	var exported struct {
+		Types []struct {
+			SqlSource string `json:"sql_source"`
+		} `json:"types"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got := exported.Types[0].SqlSource; got != "v_user_profile" {
+		t.Errorf("exported sql_source: want v_user_profile, got %q", got)
+	}
+}
+
+func TestTypeViewThenReRegister(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Profile struct {
+		ID ID `fraiseql:"id"`
+	}
+	if err := RegisterTypes(Profile{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := View("Profile", "v_profile_public"); err != nil {
+		t.Fatalf("View: %v", err)
+	}
+	if err := RegisterTypes(Profile{}); err != nil {
+		t.Fatalf("registering the same struct again should be a no-op, got %v", err)
+	}
+	if got := GetSchema().Types[0].SqlSource; got != "v_profile_public" {
+		t.Errorf("re-registering should keep the declared view, got %q", got)
+	}
+}
+
+func TestTypeViewErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		typeName string
+		view     string
+		wantErr  string
+	}{
+		{name: "empty view", typeName: "Account", view: "", wantErr: "view name must not be empty"},
+		{name: "blank view", typeName: "Account", view: "  ", wantErr: "view name must not be empty"},
+		{name: "unregistered type", typeName: "Missing", view: "v_missing", wantErr: `type "Missing" is not registered`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			if err := RegisterType("Account", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+				t.Fatalf("RegisterType: %v", err)
+			}
+			err := View(tt.typeName, tt.view)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+			if got := GetSchema().Types[0].SqlSource; got != "v_account" {
+				t.Errorf("sql_source changed on error: got %q", got)
+			}
+		})
+	}
+}
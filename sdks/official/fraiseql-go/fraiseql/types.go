@@ -1,19 +1,158 @@
 package fraiseql
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 // FieldInfo represents metadata about a struct field
 type FieldInfo struct {
-	Name     string   `json:"name"`
-	Type     string   `json:"type"`
-	Nullable bool     `json:"nullable"`
-	Scope    string   `json:"scope,omitempty"`
-	Scopes   []string `json:"scopes,omitempty"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+	// SourceName is the column or JSON key the field is read from, e.g.
+	// "created_at" for "createdAt". Registration defaults it to Name.
+	SourceName string `json:"source_name,omitempty"`
+	// Description documents the field; see SetDescriptions to fill it from Go doc comments.
+	Description string `json:"description,omitempty"`
+	// Default is the value an input-type field takes when omitted. Only input
+	// type fields may have one.
+	Default   interface{} `json:"default,omitempty"`
+	Scope     string      `json:"scope,omitempty"`
+	Scopes    []string    `json:"scopes,omitempty"`
+	Normalize bool        `json:"normalize,omitempty"`
+	// Sanitize asks the runtime to strip dangerous HTML, such as scripts and
+	// event handler attributes, from a Markdown or HTML field's user content.
+	Sanitize bool `json:"sanitize,omitempty"`
+	// PrimaryKey marks the field identifying the type's rows; at most one
+	// field per type may set it. Unique marks a field whose values are distinct.
+	PrimaryKey bool `json:"primary_key,omitempty"`
+	Unique     bool `json:"unique,omitempty"`
+	// Directives lists the custom directives applied to the field; see RegisterDirective.
+	Directives []string `json:"directives,omitempty"`
+	// CurrencyField names the CurrencyCode field paired with a Decimal amount
+	// field, so the two are treated as a money value.
+	CurrencyField string `json:"currency_field,omitempty"`
+	// Constraints bounds the field's numeric values. Latitude, Longitude and
+	// Percentage fields get their scalar's range by default.
+	Constraints *FieldConstraints `json:"constraints,omitempty"`
+	// TenantKey marks the field carrying the tenant discriminator; see TenantScoped.
+	TenantKey bool `json:"tenant_key,omitempty"`
+	// JsonbColumn and JsonPath locate a field stored in a JSONB column, e.g.
+	// column "data" and path "$.profile.name", for document-style tables.
+	JsonbColumn string `json:"jsonb_column,omitempty"`
+	JsonPath    string `json:"json_path,omitempty"`
+	// RequiredIf makes an input type field required when another field of
+	// the same input has a given value; the runtime enforces the rule.
+	RequiredIf *RequiredIfRule `json:"required_if,omitempty"`
+	// Format is the wire format of a date or duration field, e.g.
+	// "epoch_millis" for a DateTime; empty means the scalar's default.
+	Format string `json:"format,omitempty"`
+	// Resolver names the SQL function that computes the field from the
+	// parent row, e.g. "fn_full_name", instead of selecting it from the
+	// type's source view.
+	Resolver string `json:"resolver,omitempty"`
+	// Computed marks a field derived at read time rather than stored, such
+	// as a view expression; it is read-only, so GenerateInputType leaves it
+	// out. Fields with a resolver are always computed.
+	Computed bool `json:"computed,omitempty"`
+	// Complexity is the field's cost for the runtime's query cost analyzer;
+	// nil leaves the analyzer's default.
+	Complexity *int `json:"complexity,omitempty"`
+	// Storage locates the object a File or Image field refers to.
+	Storage *FileStorage `json:"storage,omitempty"`
+	// SlugFrom names the String field a Slug field is derived from on write,
+	// e.g. "title".
+	SlugFrom string `json:"slug_from,omitempty"`
+	// Internal marks a field the compiler needs, e.g. a soft-delete flag used
+	// for filtering, that is hidden from the public GraphQL type, so it is
+	// left out of introspection and TypeScript output. Only output type
+	// fields may be internal.
+	Internal bool `json:"internal,omitempty"`
+	// ValidateTimezone asks the runtime to reject Timezone values that are
+	// not IANA zone names; a default value is checked at registration.
+	ValidateTimezone bool `json:"validate_timezone,omitempty"`
+	// Redact asks the runtime to mask the field's value in responses and
+	// logs unless the caller holds the field's scope (or one of its scopes);
+	// without a scope it is always masked. APIKey fields are redacted unless
+	// tagged redact=false.
+	Redact  bool   `json:"redact,omitempty"`
+	Profile string `json:"-"` // see ExportSchemaForProfile
+
+	// RawTag is the original fraiseql struct tag the field was parsed from,
+	// kept for diagnostics. It is empty for untagged fields and never exported.
+	RawTag string `json:"-"`
+}
+
+// nullablePolicy holds the default nullability set with SetDefaultNullable.
+var nullablePolicy struct {
+	mu       sync.RWMutex
+	nullable bool
+}
+
+// SetDefaultNullable sets the nullability of fields whose tag does not say
+// otherwise. By default (false) a value-type field is non-null, matching Go
+// zero-value semantics. With SetDefaultNullable(true), every field without an
+// explicit nullable= tag becomes nullable; use `nullable=false` to keep a field
+// non-null. Identity fields, named `id` or tagged `primaryKey=true`, are exempt
+// and stay non-null, since lookups and RegisterCRUD rely on them. Pointer
+// fields are nullable under either policy. Reset() restores the default.
+func SetDefaultNullable(nullable bool) {
+	nullablePolicy.mu.Lock()
+	defer nullablePolicy.mu.Unlock()
+	nullablePolicy.nullable = nullable
+}
+
+func isDefaultNullable() bool {
+	nullablePolicy.mu.RLock()
+	defer nullablePolicy.mu.RUnlock()
+	return nullablePolicy.nullable
+}
+
+// defaultNullableFor reports whether the default nullability policy makes the
+// field nullable. Identity fields are exempt.
+func defaultNullableFor(fieldName string, primaryKey bool) bool {
+	if strings.EqualFold(fieldName, "id") || primaryKey {
+		return false
+	}
+	return isDefaultNullable()
+}
+
+// vectorPolicy holds the setting made with SetFloatSlicesAsVector.
+var vectorPolicy struct {
+	mu     sync.RWMutex
+	vector bool
+}
+
+// vectorType is the Go type of the Vector scalar.
+var vectorType = reflect.TypeOf(Vector(nil))
+
+// SetFloatSlicesAsVector sets whether untagged []float64 and []float32 fields
+// map to the Vector scalar, as fraiseql.Vector fields always do. By default
+// (false) they map to [Float!]. Reset() restores the default.
+func SetFloatSlicesAsVector(vector bool) {
+	vectorPolicy.mu.Lock()
+	defer vectorPolicy.mu.Unlock()
+	vectorPolicy.vector = vector
+}
+
+// isVectorType reports whether goType maps to the Vector scalar.
+func isVectorType(goType reflect.Type) bool {
+	if goType == vectorType {
+		return true
+	}
+	vectorPolicy.mu.RLock()
+	defer vectorPolicy.mu.RUnlock()
+	if !vectorPolicy.vector || goType.Kind() != reflect.Slice {
+		return false
+	}
+	elem := goType.Elem().Kind()
+	return elem == reflect.Float64 || elem == reflect.Float32
 }
 
 // goToGraphQLType converts a Go type to GraphQL type string and nullable flag
@@ -27,6 +166,10 @@ type FieldInfo struct {
 //	*[]User -> ("[User]", true)
 //	bool -> ("Boolean", false)
 //	float64 -> ("Float", false)
+//	any -> ("Json", true)
+//	sql.NullString -> ("String", true)
+//
+// Mappers registered with RegisterTypeMapper are consulted first.
 func goToGraphQLType(goType reflect.Type) (string, bool, error) {
 	nullable := false
 
@@ -36,6 +179,15 @@ func goToGraphQLType(goType reflect.Type) (string, bool, error) {
 		goType = goType.Elem()
 	}
 
+	// Custom mappers take precedence over the built-in mapping
+	if graphQLType, mappedNullable, handled := mapWithTypeMappers(goType); handled {
+		return graphQLType, nullable || mappedNullable, nil
+	}
+
+	if isVectorType(goType) {
+		return "Vector", nullable, nil
+	}
+
 	// Handle slice/array types
 	if goType.Kind() == reflect.Slice || goType.Kind() == reflect.Array {
 		elemType := goType.Elem()
@@ -67,6 +219,10 @@ func goToGraphQLType(goType reflect.Type) (string, bool, error) {
 	case reflect.Bool:
 		return "Boolean", nullable, nil
 	case reflect.Struct:
+		// database/sql null wrappers hold a nullable scalar
+		if graphQLType, ok, err := sqlNullType(goType); ok {
+			return graphQLType, true, err
+		}
 		// Handle special struct types
 		switch goType {
 		case reflect.TypeOf(time.Time{}):
@@ -74,14 +230,32 @@ func goToGraphQLType(goType reflect.Type) (string, bool, error) {
 		case reflect.TypeOf(time.Duration(0)):
 			return "String", nullable, nil
 		default:
+			// Value types with a text encoding (e.g. date library types)
+			// serialize as strings, not as objects. Use RegisterTypeMapper
+			// to map them to a more specific scalar.
+			if implementsTextMarshaler(goType) {
+				return "String", nullable, nil
+			}
 			// Custom struct types use their name
 			return goType.Name(), nullable, nil
 		}
+	case reflect.Interface:
+		// interface{}, any and named interfaces carry arbitrary values; their
+		// zero value is nil, so they map to a nullable Json scalar.
+		return "Json", true, nil
 	default:
 		return "", false, fmt.Errorf("unsupported Go type: %v", goType.String())
 	}
 }
 
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// implementsTextMarshaler reports whether goType or a pointer to it implements
+// encoding.TextMarshaler.
+func implementsTextMarshaler(goType reflect.Type) bool {
+	return goType.Implements(textMarshalerType) || reflect.PointerTo(goType).Implements(textMarshalerType)
+}
+
 // canonicalizeIdType enforces the entity-identity convention: a field named
 // "id" is emitted as GraphQL "ID".
 //
@@ -107,6 +281,21 @@ func canonicalizeIdType(fieldName, graphqlType string) string {
 // Tag format: `fraiseql:"field_name,type=GraphQLType,nullable=true"`
 // Returns map of field name -> FieldInfo
 func ExtractFields(structType reflect.Type) (map[string]FieldInfo, error) {
+	fieldList, err := extractFieldList(structType)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]FieldInfo, len(fieldList))
+	for _, field := range fieldList {
+		fields[field.Name] = field
+	}
+	return fields, nil
+}
+
+// extractFieldList extracts field information like ExtractFields, preserving
+// the struct's field declaration order so exported schemas are deterministic.
+func extractFieldList(structType reflect.Type) ([]FieldInfo, error) {
 	if structType.Kind() == reflect.Pointer {
 		structType = structType.Elem()
 	}
@@ -115,7 +304,7 @@ func ExtractFields(structType reflect.Type) (map[string]FieldInfo, error) {
 		return nil, fmt.Errorf("expected struct type, got %v", structType.Kind())
 	}
 
-	fields := make(map[string]FieldInfo)
+	var fields []FieldInfo
 	numFields := structType.NumField()
 
 	for i := 0; i < numFields; i++ {
@@ -140,11 +329,12 @@ func ExtractFields(structType reflect.Type) (map[string]FieldInfo, error) {
 				return nil, fmt.Errorf("cannot infer type for field %s: %w", field.Name, err)
 			}
 			graphQLType = canonicalizeIdType(field.Name, graphQLType)
-			fields[field.Name] = FieldInfo{
+			fields = append(fields, FieldInfo{
 				Name:     field.Name,
 				Type:     graphQLType,
-				Nullable: nullable,
-			}
+				Nullable: nullable || defaultNullableFor(field.Name, false),
+				Redact:   redactedByDefault(graphQLType, field.Type),
+			})
 			continue
 		}
 
@@ -153,14 +343,44 @@ func ExtractFields(structType reflect.Type) (map[string]FieldInfo, error) {
 		if err != nil {
 			return nil, fmt.Errorf("invalid tag for field %s: %w", field.Name, err)
 		}
-		fields[fieldInfo.Name] = fieldInfo
+		fields = append(fields, fieldInfo)
 	}
 
 	return fields, nil
 }
 
+// parseTagDefault converts a `default=` tag value to the Go value of the
+// field's type: Int, Float and Boolean defaults are parsed, anything else
+// (strings, enum values, scalars) stays a string.
+func parseTagDefault(value, graphQLType string) (interface{}, error) {
+	switch strings.TrimSuffix(graphQLType, "!") {
+	case "Int":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("default %q is not an Int", value)
+		}
+		return n, nil
+	case "Float":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("default %q is not a Float", value)
+		}
+		return f, nil
+	case "Boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("default %q is not a Boolean", value)
+		}
+		return b, nil
+	}
+	if strings.HasPrefix(graphQLType, "[") {
+		return nil, fmt.Errorf("default= is not supported for list type %s", graphQLType)
+	}
+	return value, nil
+}
+
 // parseFieldTag parses a fraiseql struct tag
-// Format: fieldname,type=GraphQLType,nullable=true,scope=read:user.email,scopes=admin;auditor
+// Format: fieldname,type=GraphQLType,nullable=true,scope=read:user.email,scopes=admin;auditor,profile=enterprise,normalize=true
 func parseFieldTag(tag string, fieldName string, fieldType reflect.Type) (FieldInfo, error) {
 	parts := strings.Split(tag, ",")
 	if len(parts) == 0 {
@@ -168,11 +388,17 @@ func parseFieldTag(tag string, fieldName string, fieldType reflect.Type) (FieldI
 	}
 
 	fieldInfo := FieldInfo{
-		Name: fieldName, // Default to struct field name
+		Name:   fieldName, // Default to struct field name
+		RawTag: tag,
 	}
 
 	var hasSingleScope bool
 	var hasMultipleScopes bool
+	var hasNullable bool
+	var hasDefault bool
+	var hasJSONPath bool
+	var hasResolver bool
+	var hasRedact bool
 
 	// First part can be field name override or type spec
 	if parts[0] != "" && !strings.Contains(parts[0], "=") {
@@ -197,8 +423,95 @@ func parseFieldTag(tag string, fieldName string, fieldType reflect.Type) (FieldI
 		switch key {
 		case "type":
 			fieldInfo.Type = value
+		case "source":
+			if value == "" {
+				return FieldInfo{}, fmt.Errorf("empty source value for field %s", fieldName)
+			}
+			fieldInfo.SourceName = value
 		case "nullable":
 			fieldInfo.Nullable = value == "true"
+			hasNullable = true
+		case "profile":
+			if err := validateProfileName(value); err != nil {
+				return FieldInfo{}, fmt.Errorf("field %s: %w", fieldName, err)
+			}
+			fieldInfo.Profile = value
+		case "normalize":
+			fieldInfo.Normalize = value == "true"
+		case "sanitize":
+			fieldInfo.Sanitize = value == "true"
+		case "primaryKey":
+			fieldInfo.PrimaryKey = value == "true"
+		case "unique":
+			fieldInfo.Unique = value == "true"
+		case "tenantKey":
+			fieldInfo.TenantKey = value == "true"
+		case "internal":
+			fieldInfo.Internal = value == "true"
+		case "computed":
+			fieldInfo.Computed = value == "true"
+		case "validateTimezone":
+			fieldInfo.ValidateTimezone = value == "true"
+		case "redact":
+			fieldInfo.Redact = value == "true"
+			hasRedact = true
+		case "default":
+			fieldInfo.Default = value
+			hasDefault = true
+		case "currencyField":
+			fieldInfo.CurrencyField = value
+		case "resolver":
+			fieldInfo.Resolver = value
+			hasResolver = true
+		case "complexity":
+			cost, err := parseComplexity(value, fieldName)
+			if err != nil {
+				return FieldInfo{}, err
+			}
+			fieldInfo.Complexity = cost
+		case "storage", "bucket":
+			if fieldInfo.Storage == nil {
+				fieldInfo.Storage = &FileStorage{}
+			}
+			if key == "storage" {
+				fieldInfo.Storage.Backend = value
+			} else {
+				fieldInfo.Storage.Bucket = value
+			}
+		case "slugFrom":
+			fieldInfo.SlugFrom = value
+		case "format":
+			fieldInfo.Format = value
+		case "requiredIf":
+			rule, err := parseRequiredIf(value, fieldName)
+			if err != nil {
+				return FieldInfo{}, err
+			}
+			fieldInfo.RequiredIf = rule
+		case "jsonb":
+			fieldInfo.JsonbColumn = value
+		case "jsonPath":
+			fieldInfo.JsonPath = value
+			hasJSONPath = true
+		case "min", "max":
+			bound, err := parseConstraintBound(key, value, fieldName)
+			if err != nil {
+				return FieldInfo{}, err
+			}
+			if fieldInfo.Constraints == nil {
+				fieldInfo.Constraints = &FieldConstraints{}
+			}
+			if key == "min" {
+				fieldInfo.Constraints.Min = bound
+			} else {
+				fieldInfo.Constraints.Max = bound
+			}
+		case "directive":
+			directives, err := parseDirectiveList(value, fieldName)
+			if err != nil {
+				return FieldInfo{}, err
+			}
+			fieldInfo.Directives = directives
 		case "scope":
 			if value == "" {
 				return FieldInfo{}, fmt.Errorf("empty scope value for field %s", fieldName)
@@ -243,11 +556,15 @@ func parseFieldTag(tag string, fieldName string, fieldType reflect.Type) (FieldI
 		}
 		fieldInfo.Type = graphQLType
 		// Only use inferred nullable if not explicitly set
-		if !strings.Contains(tag, "nullable") {
+		if !hasNullable {
 			fieldInfo.Nullable = nullable
 		}
 	}
 
+	if !hasNullable && defaultNullableFor(fieldInfo.Name, fieldInfo.PrimaryKey) {
+		fieldInfo.Nullable = true
+	}
+
 	if fieldInfo.Type == "" {
 		return FieldInfo{}, fmt.Errorf("type not specified in tag")
 	}
@@ -257,17 +574,124 @@ func parseFieldTag(tag string, fieldName string, fieldType reflect.Type) (FieldI
 	// or set via an explicit `type=` tag override.
 	fieldInfo.Type = canonicalizeIdType(fieldInfo.Name, fieldInfo.Type)
 
+	if !hasRedact {
+		fieldInfo.Redact = redactedByDefault(fieldInfo.Type, fieldType)
+	}
+
+	if hasDefault {
+		def, err := parseTagDefault(fieldInfo.Default.(string), fieldInfo.Type)
+		if err != nil {
+			return FieldInfo{}, fmt.Errorf("field %s: %w", fieldName, err)
+		}
+		fieldInfo.Default = def
+	}
+
+	if err := applyJSONPath(&fieldInfo, hasJSONPath); err != nil {
+		return FieldInfo{}, err
+	}
+	if err := validateResolver(fieldInfo, hasResolver); err != nil {
+		return FieldInfo{}, err
+	}
+	if hasResolver {
+		fieldInfo.Computed = true
+	}
+
+	if fieldInfo.Normalize && !normalizableScalars[namedType(fieldInfo.Type)] {
+		return FieldInfo{}, fmt.Errorf(
+			"field %s: normalize=true is not supported for type %s; only scalars with a canonical form (Email, PhoneNumber, URL, ...) can be normalized",
+			fieldName, fieldInfo.Type,
+		)
+	}
+
+	fieldInfo, err := normalizePhoneDefault(fieldInfo)
+	if err != nil {
+		return FieldInfo{}, err
+	}
+
+	if fieldInfo.Sanitize && !sanitizableScalars[namedType(fieldInfo.Type)] {
+		return FieldInfo{}, fmt.Errorf(
+			"field %s: sanitize=true is not supported for type %s; only Markdown and HTML fields hold markup to sanitize",
+			fieldName, fieldInfo.Type,
+		)
+	}
+
+	if err := validateFileStorage(fieldInfo); err != nil {
+		return FieldInfo{}, err
+	}
+
+	if err := checkTimezoneField(fieldInfo); err != nil {
+		return FieldInfo{}, fmt.Errorf("field %s: %w", fieldName, err)
+	}
+
 	return fieldInfo, nil
 }
 
-// validateScope validates scope format: action:resource
-// Valid patterns:
-// - * (global wildcard)
-// - action:resource (read:user.email, write:User.salary)
-// - action:* (admin:*, read:*)
-func validateScope(scope string, fieldName string) error {
+// normalizableScalars are the scalars with a canonical form the runtime can
+// normalize to on write (normalize=true), e.g. lowercased emails, E.164 phone
+// numbers and URLs without a trailing slash.
+var normalizableScalars = map[string]bool{
+	"Email":       true,
+	"PhoneNumber": true,
+	"URL":         true,
+	"DomainName":  true,
+	"Hostname":    true,
+	"IBAN":        true,
+	"MACAddress":  true,
+}
+
+// sanitizableScalars are the scalars holding markup the runtime can sanitize
+// (sanitize=true).
+var sanitizableScalars = map[string]bool{
+	"Markdown": true,
+	"HTML":     true,
+}
+
+// scopeValidator holds the grammar check installed with SetScopeValidator.
+var scopeValidator struct {
+	mu sync.RWMutex
+	fn func(scope string) error
+}
+
+// SetScopeValidator replaces the built-in action:resource scope grammar with a
+// custom check, for teams whose scopes follow another convention (for example
+// "orders:read:own" or "urn:acme:read"). The validator receives each non-empty
+// scope= value and each scopes= entry containing a colon, and returns an error
+// to reject it. Bare role names in scopes= (entries without a colon, e.g.
+// "admin") are not scopes: they skip the validator and always follow the
+// [a-zA-Z_][a-zA-Z0-9_]* role grammar. Passing nil restores the built-in
+// grammar, as does Reset().
+func SetScopeValidator(validator func(scope string) error) {
+	scopeValidator.mu.Lock()
+	defer scopeValidator.mu.Unlock()
+	scopeValidator.fn = validator
+}
+
+// customScopeValidator returns the installed scope validator, if any.
+func customScopeValidator() func(scope string) error {
+	scopeValidator.mu.RLock()
+	defer scopeValidator.mu.RUnlock()
+	return scopeValidator.fn
+}
+
+// ValidateScope checks scope against the scope grammar that scope= tags
+// follow, for scopes built at run time, e.g. from an admin UI. The built-in
+// grammar is action:resource:
+//
+//   - * (global wildcard)
+//   - action:resource (read:user.email, write:User.salary)
+//   - action:* (admin:*, read:*)
+//
+// A validator installed with SetScopeValidator replaces these rules.
+func ValidateScope(scope string) error {
 	if scope == "" {
-		return fmt.Errorf("field %s has empty scope", fieldName)
+		return fmt.Errorf("empty scope")
+	}
+
+	if validator := customScopeValidator(); validator != nil {
+		if err := validator(scope); err != nil {
+			return fmt.Errorf("invalid scope '%s': %w", scope, err)
+		}
+		return nil
 	}
 
 	// Global wildcard is always valid
@@ -277,12 +701,12 @@ func validateScope(scope string, fieldName string) error {
 
 	// Must contain at least one colon
 	if !strings.Contains(scope, ":") {
-		return fmt.Errorf("field %s has invalid scope '%s' (missing colon)", fieldName, scope)
+		return fmt.Errorf("invalid scope '%s' (missing colon)", scope)
 	}
 
 	parts := strings.SplitN(scope, ":", 2)
 	if len(parts) != 2 {
-		return fmt.Errorf("field %s has invalid scope '%s'", fieldName, scope)
+		return fmt.Errorf("invalid scope '%s'", scope)
 	}
 
 	action := parts[0]
@@ -290,17 +714,25 @@ func validateScope(scope string, fieldName string) error {
 
 	// Validate action: [a-zA-Z_][a-zA-Z0-9_]*
 	if !isValidAction(action) {
-		return fmt.Errorf("field %s has invalid action in scope '%s' (must be alphanumeric + underscore)", fieldName, scope)
+		return fmt.Errorf("invalid action in scope '%s' (must be alphanumeric + underscore)", scope)
 	}
 
 	// Validate resource: [a-zA-Z_][a-zA-Z0-9_.]*|*
 	if !isValidResource(resource) {
-		return fmt.Errorf("field %s has invalid resource in scope '%s' (must be alphanumeric + underscore + dot, or *)", fieldName, scope)
+		return fmt.Errorf("invalid resource in scope '%s' (must be alphanumeric + underscore + dot, or *)", scope)
 	}
 
 	return nil
 }
 
+// validateScope validates the scope of the named field; see ValidateScope.
+func validateScope(scope string, fieldName string) error {
+	if err := ValidateScope(scope); err != nil {
+		return fmt.Errorf("field %s has %w", fieldName, err)
+	}
+	return nil
+}
+
 // isValidAction validates that action matches [a-zA-Z_][a-zA-Z0-9_]*
 func isValidAction(action string) bool {
 	if len(action) == 0 {
@@ -1,7 +1,10 @@
 package fraiseql
 
 import (
+	"encoding/json"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -98,6 +101,30 @@ func TestGoToGraphQLType(t *testing.T) {
 			expectedType: "[String]",
 			expectedNull: false,
 		},
+		{
+			name:         "empty interface",
+			goType:       reflect.TypeOf((*interface{})(nil)).Elem(),
+			expectedType: "Json",
+			expectedNull: true,
+		},
+		{
+			name:         "any",
+			goType:       reflect.TypeOf((*any)(nil)).Elem(),
+			expectedType: "Json",
+			expectedNull: true,
+		},
+		{
+			name:         "named interface",
+			goType:       reflect.TypeOf((*fmt.Stringer)(nil)).Elem(),
+			expectedType: "Json",
+			expectedNull: true,
+		},
+		{
+			name:         "slice of any",
+			goType:       reflect.TypeOf([]any{}),
+			expectedType: "[Json]",
+			expectedNull: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -119,6 +146,463 @@ func TestGoToGraphQLType(t *testing.T) {
 	}
 }
 
+func TestExtractFieldsInterfaceKinds(t *testing.T) {
+	type Event struct {
+		ID       ID           `fraiseql:"id"`
+		Metadata any          `fraiseql:"metadata"`
+		Payload  interface{}  `fraiseql:"payload"`
+		Label    fmt.Stringer `fraiseql:"label"`
+	}
+
+	fields, err := ExtractFields(reflect.TypeOf(Event{}))
+	if err != nil {
+		t.Fatalf("ExtractFields: %v", err)
+	}
+	for _, name := range []string{"metadata", "payload", "label"} {
+		if f := fields[name]; f.Type != "Json" || !f.Nullable {
+			t.Errorf("%s: want nullable Json, got %+v", name, f)
+		}
+	}
+}
+
+func TestInterfaceKindTypeMapperOverride(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	stringer := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	RegisterTypeMapper(func(t reflect.Type) (string, bool, bool) {
+		if t == stringer {
+			return "String", false, true
+		}
+		return "", false, false
+	})
+
+	if got, nullable, _ := goToGraphQLType(stringer); got != "String" || nullable {
+		t.Errorf("mapper should override interface fallback, got (%s, %v)", got, nullable)
+	}
+	if got, _, _ := goToGraphQLType(reflect.TypeOf((*any)(nil)).Elem()); got != "Json" {
+		t.Errorf("unmapped interface should still be Json, got %s", got)
+	}
+}
+
+// civilDate mimics a date-library type: a struct with a text encoding.
+type civilDate struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+func (d civilDate) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)), nil
+}
+
+func (d *civilDate) UnmarshalText(b []byte) error {
+	_, err := fmt.Sscanf(string(b), "%d-%d-%d", &d.Year, &d.Month, &d.Day)
+	return err
+}
+
+// pointerMarshaler implements TextMarshaler on its pointer receiver only.
+type pointerMarshaler struct{ v string }
+
+func (p *pointerMarshaler) MarshalText() ([]byte, error) { return []byte(p.v), nil }
+
+func TestTextMarshalerTypesMapToString(t *testing.T) {
+	type Booking struct {
+		ID       ID               `fraiseql:"id"`
+		Date     civilDate        `fraiseql:"date"`
+		EndsOn   *civilDate       `fraiseql:"endsOn"`
+		Ref      pointerMarshaler `fraiseql:"ref"`
+		Nights   []civilDate      `fraiseql:"nights"`
+		Attendee testUserType     `fraiseql:"attendee"`
+	}
+
+	fields, err := ExtractFields(reflect.TypeOf(Booking{}))
+	if err != nil {
+		t.Fatalf("ExtractFields: %v", err)
+	}
+
+	if f := fields["date"]; f.Type != "String" || f.Nullable {
+		t.Errorf("date: want non-null String, got %+v", f)
+	}
+	if f := fields["endsOn"]; f.Type != "String" || !f.Nullable {
+		t.Errorf("endsOn: want nullable String, got %+v", f)
+	}
+	if f := fields["ref"]; f.Type != "String" {
+		t.Errorf("ref: pointer-receiver marshaler should map to String, got %+v", f)
+	}
+	if f := fields["nights"]; f.Type != "[String!]" {
+		t.Errorf("nights: want [String!], got %+v", f)
+	}
+	if f := fields["attendee"]; f.Type != "testUserType" {
+		t.Errorf("attendee: plain structs should keep their type name, got %+v", f)
+	}
+}
+
+func TestTextMarshalerTypeMapperOverride(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	RegisterTypeMapper(func(t reflect.Type) (string, bool, bool) {
+		if t == reflect.TypeOf(civilDate{}) {
+			return "Date", false, true
+		}
+		return "", false, false
+	})
+
+	if got, _, _ := goToGraphQLType(reflect.TypeOf(civilDate{})); got != "Date" {
+		t.Errorf("mapper should override the String fallback, got %s", got)
+	}
+}
+
+type testNullablePolicyType struct {
+	ID       ID      `fraiseql:"id"`
+	Code     string  `fraiseql:"code,primaryKey=true"`
+	Name     string  `fraiseql:"name"`
+	Title    string  `fraiseql:"title,type=String"`
+	Nickname *string `fraiseql:"nickname"`
+	Count    int
+	Required string `fraiseql:"required,nullable=false"`
+}
+
+func TestDefaultNullablePolicy(t *testing.T) {
+	tests := []struct {
+		name            string
+		defaultNullable bool
+		want            map[string]bool
+	}{
+		{
+			name:            "non-null by default",
+			defaultNullable: false,
+			want: map[string]bool{
+				"id": false, "code": false, "name": false, "title": false, "nickname": true, "Count": false, "required": false,
+			},
+		},
+		{
+			name:            "nullable by default",
+			defaultNullable: true,
+			want: map[string]bool{
+				"id": false, "code": false, "name": true, "title": true, "nickname": true, "Count": true, "required": false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			SetDefaultNullable(tt.defaultNullable)
+			fields, err := ExtractFields(reflect.TypeOf(testNullablePolicyType{}))
+			if err != nil {
+				t.Fatalf("ExtractFields: %v", err)
+			}
+			for name, want := range tt.want {
+				if got := fields[name].Nullable; got != want {
+					t.Errorf("%s: nullable want %v, got %v", name, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestResetRestoresDefaultNullable(t *testing.T) {
+	SetDefaultNullable(true)
+	Reset()
+
+	fields, err := ExtractFields(reflect.TypeOf(testNullablePolicyType{}))
+	if err != nil {
+		t.Fatalf("ExtractFields: %v", err)
+	}
+	if fields["name"].Nullable {
+		t.Error("Reset should restore non-null default")
+	}
+}
+
+func TestVectorFieldRecognition(t *testing.T) {
+	type Document struct {
+		Embedding Vector      `fraiseql:"embedding"`
+		Optional  *Vector     `fraiseql:"optional"`
+		Scores    []float64   `fraiseql:"scores"`
+		Weights   []float32   `fraiseql:"weights"`
+		Matrix    [][]float64 `fraiseql:"matrix"`
+	}
+
+	tests := []struct {
+		name    string
+		vectors bool
+		want    map[string]string
+	}{
+		{
+			name: "only fraiseql.Vector by default",
+			want: map[string]string{
+				"embedding": "Vector", "optional": "Vector", "scores": "[Float!]", "weights": "[Float!]", "matrix": "[[Float!]!]",
+			},
+		},
+		{
+			name:    "float slices as vectors",
+			vectors: true,
+			want: map[string]string{
+				"embedding": "Vector", "optional": "Vector", "scores": "Vector", "weights": "Vector", "matrix": "[Vector!]",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			SetFloatSlicesAsVector(tt.vectors)
+			fields, err := ExtractFields(reflect.TypeOf(Document{}))
+			if err != nil {
+				t.Fatalf("ExtractFields: %v", err)
+			}
+			for name, want := range tt.want {
+				if got := fields[name].Type; got != want {
+					t.Errorf("%s: want %s, got %s", name, want, got)
+				}
+			}
+			if fields["embedding"].Nullable || !fields["optional"].Nullable {
+				t.Error("only the pointer Vector field should be nullable")
+			}
+		})
+	}
+}
+
+func TestNormalizeTag(t *testing.T) {
+	type Contact struct {
+		Email   string   `fraiseql:"email,type=Email,normalize=true"`
+		Phone   *string  `fraiseql:"phone,type=PhoneNumber,nullable=true,normalize=true"`
+		Website string   `fraiseql:"website,type=URL"`
+		Aliases []string `fraiseql:"aliases,type=[Email!],normalize=true"`
+	}
+
+	fields, err := ExtractFields(reflect.TypeOf(Contact{}))
+	if err != nil {
+		t.Fatalf("ExtractFields: %v", err)
+	}
+	for _, name := range []string{"email", "phone", "aliases"} {
+		if !fields[name].Normalize {
+			t.Errorf("%s: expected normalize flag", name)
+		}
+	}
+	if fields["website"].Normalize {
+		t.Error("website: normalize should default to false")
+	}
+
+	data, err := json.Marshal(fields["email"])
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"normalize":true`) {
+		t.Errorf("expected normalize to be exported, got %s", data)
+	}
+	data, _ = json.Marshal(fields["website"])
+	if strings.Contains(string(data), "normalize") {
+		t.Errorf("normalize should be omitted when false, got %s", data)
+	}
+}
+
+func TestNormalizeTagUnsupportedScalar(t *testing.T) {
+	type Product struct {
+		Price float64 `fraiseql:"price,type=Decimal,normalize=true"`
+	}
+
+	_, err := ExtractFields(reflect.TypeOf(Product{}))
+	if err == nil || !strings.Contains(err.Error(), "normalize=true is not supported for type Decimal") {
+		t.Errorf("expected unsupported-scalar error, got %v", err)
+	}
+}
+
+func TestSanitizeTag(t *testing.T) {
+	type Post struct {
+		Body    string `fraiseql:"body,type=HTML,sanitize=true"`
+		Summary string `fraiseql:"summary,type=Markdown,nullable=true,sanitize=true"`
+		Notes   string `fraiseql:"notes,type=Markdown"`
+	}
+
+	fields, err := ExtractFields(reflect.TypeOf(Post{}))
+	if err != nil {
+		t.Fatalf("ExtractFields: %v", err)
+	}
+	if !fields["body"].Sanitize || !fields["summary"].Sanitize {
+		t.Error("body and summary: expected sanitize flag")
+	}
+	if fields["notes"].Sanitize {
+		t.Error("notes: sanitize should default to false")
+	}
+
+	data, err := json.Marshal(fields["body"])
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"sanitize":true`) {
+		t.Errorf("expected sanitize to be exported, got %s", data)
+	}
+	data, _ = json.Marshal(fields["notes"])
+	if strings.Contains(string(data), "sanitize") {
+		t.Errorf("sanitize should be omitted when false, got %s", data)
+	}
+}
+
+func TestSanitizeTagUnsupportedScalar(t *testing.T) {
+	type Comment struct {
+		Text string `fraiseql:"text,sanitize=true"`
+	}
+
+	_, err := ExtractFields(reflect.TypeOf(Comment{}))
+	if err == nil || !strings.Contains(err.Error(), "sanitize=true is not supported for type String") {
+		t.Errorf("expected unsupported-scalar error, got %v", err)
+	}
+}
+
+func TestPrimaryKeyAndUniqueTags(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Account struct {
+		ID     ID     `fraiseql:"id,primaryKey=true"`
+		Email  string `fraiseql:"email,type=Email,unique=true"`
+		Handle string `fraiseql:"handle"`
+	}
+
+	fields, err := ExtractFields(reflect.TypeOf(Account{}))
+	if err != nil {
+		t.Fatalf("ExtractFields: %v", err)
+	}
+	if f := fields["id"]; !f.PrimaryKey || f.Unique {
+		t.Errorf("id: want primary key only, got %+v", f)
+	}
+	if f := fields["email"]; !f.Unique || f.PrimaryKey {
+		t.Errorf("email: want unique only, got %+v", f)
+	}
+
+	if err := RegisterTypes(Account{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	data, err := GetSchemaJSON(false)
+	if err != nil {
+		t.Fatalf("GetSchemaJSON: %v", err)
+	}
+	for _, want := range []string{`"name":"id","type":"ID","nullable":false,"source_name":"id","primary_key":true`, `"unique":true`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in exported schema, got %s", want, data)
+		}
+	}
+	if strings.Count(string(data), "primary_key") != 1 || strings.Count(string(data), `"unique"`) != 1 {
+		t.Errorf("primary_key and unique should be omitted when false, got %s", data)
+	}
+}
+
+func TestDuplicatePrimaryKey(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Membership struct {
+		UserID ID `fraiseql:"userId,primaryKey=true"`
+		TeamID ID `fraiseql:"teamId,primaryKey=true"`
+	}
+
+	err := RegisterTypes(Membership{})
+	if err == nil || !strings.Contains(err.Error(), `more than one primary key field ("userId" and "teamId")`) {
+		t.Errorf("expected duplicate primary key error, got %v", err)
+	}
+	if len(GetSchema().Types) != 0 {
+		t.Error("type with two primary keys should not be registered")
+	}
+}
+
+func TestCurrencyFieldPairing(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	type Invoice struct {
+		ID       ID           `fraiseql:"id"`
+		Total    Decimal      `fraiseql:"total,type=Decimal,currencyField=currency"`
+		Currency CurrencyCode `fraiseql:"currency,type=CurrencyCode"`
+	}
+
+	if err := RegisterTypes(Invoice{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	total := GetSchema().Types[0].Fields[1]
+	if total.CurrencyField != "currency" {
+		t.Errorf("total: want currencyField currency, got %+v", total)
+	}
+	data, _ := json.Marshal(total)
+	if !strings.Contains(string(data), `"currency_field":"currency"`) {
+		t.Errorf("expected currency_field to be exported, got %s", data)
+	}
+}
+
+func TestCurrencyFieldPairingErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []FieldInfo
+		wantErr string
+	}{
+		{
+			name:    "missing currency field",
+			fields:  []FieldInfo{{Name: "total", Type: "Decimal", CurrencyField: "currency"}},
+			wantErr: `names currency field "currency", which does not exist`,
+		},
+		{
+			name: "currency field not CurrencyCode",
+			fields: []FieldInfo{
+				{Name: "total", Type: "Decimal", CurrencyField: "currency"},
+				{Name: "currency", Type: "String"},
+			},
+			wantErr: "it must be a CurrencyCode",
+		},
+		{
+			name: "amount not Decimal",
+			fields: []FieldInfo{
+				{Name: "total", Type: "Float", CurrencyField: "currency"},
+				{Name: "currency", Type: "CurrencyCode"},
+			},
+			wantErr: "only Decimal amount fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			err := RegisterType("Invoice", tt.fields, "")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFieldInfoRawTag(t *testing.T) {
+	type Tagged struct {
+		Email   string `fraiseql:"email,type=Email,nullable=true,scope=read:user.email"`
+		Untyped int
+	}
+
+	fields, err := ExtractFields(reflect.TypeOf(Tagged{}))
+	if err != nil {
+		t.Fatalf("ExtractFields: %v", err)
+	}
+	if got := fields["email"].RawTag; got != "email,type=Email,nullable=true,scope=read:user.email" {
+		t.Errorf("RawTag: got %q", got)
+	}
+	if got := fields["Untyped"].RawTag; got != "" {
+		t.Errorf("untagged field should have empty RawTag, got %q", got)
+	}
+
+	data, err := json.Marshal(fields["email"])
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if strings.Contains(string(data), "nullable=true") {
+		t.Errorf("RawTag should not be serialized, got %s", data)
+	}
+}
+
 type testUserType struct {
 	ID        int
 	Name      string
@@ -0,0 +1,125 @@
+package fraiseql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tsScalarTypes maps scalars to TypeScript types. Scalars not listed here
+// (FraiseQL and custom scalars alike) are serialized as strings.
+var tsScalarTypes = map[string]string{
+	"ID":         "string",
+	"String":     "string",
+	"Int":        "number",
+	"Float":      "number",
+	"Boolean":    "boolean",
+	"Json":       "unknown",
+	"Vector":     "number[]",
+	"Latitude":   "number",
+	"Longitude":  "number",
+	"Percentage": "number",
+	"Port":       "number",
+}
+
+// ExportTypeScript renders the registered enums, input types and types as
+// TypeScript definitions for frontend code:
+//
+//	export type Role = "ADMIN" | "USER";
+//
+//	export interface User {
+//	  id: string;
+//	  email?: string;
+//	  tags: string[];
+//	}
+//
+// Nullable fields become optional properties, lists become arrays, and enums
+// become string union types. It is a serialization of GetSchema and returns an
+// error only if a schema transform fails.
+func ExportTypeScript() (string, error) {
+	schema, err := buildSchema()
+	if err != nil {
+		return "", err
+	}
+
+	objectNames := make(map[string]bool)
+	for _, t := range schema.Types {
+		objectNames[t.Name] = true
+	}
+	for _, in := range schema.InputTypes {
+		objectNames[in.Name] = true
+	}
+	for _, e := range schema.Enums {
+		objectNames[e.Name] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by fraiseql. DO NOT EDIT.\n")
+
+	for _, e := range schema.Enums {
+		values := make([]string, len(e.Values))
+		for i, v := range e.Values {
+			values[i] = fmt.Sprintf("%q", v.Name)
+		}
+		if len(values) == 0 {
+			values = []string{"never"}
+		}
+		fmt.Fprintf(&b, "\nexport type %s = %s;\n", e.Name, strings.Join(values, " | "))
+	}
+	for _, in := range schema.InputTypes {
+		writeTSInterface(&b, in.Name, in.Description, in.Fields, objectNames)
+	}
+	for _, t := range schema.Types {
+		writeTSInterface(&b, t.Name, t.Description, t.Fields, objectNames)
+	}
+	return b.String(), nil
+}
+
+func writeTSInterface(b *strings.Builder, name, description string, fields []FieldInfo, objectNames map[string]bool) {
+	b.WriteString("\n")
+	if description != "" {
+		fmt.Fprintf(b, "/** %s */\n", description)
+	}
+	fmt.Fprintf(b, "export interface %s {\n", name)
+	for _, f := range fields {
+		if f.Internal {
+			continue
+		}
+		optional := ""
+		if f.Nullable {
+			optional = "?"
+		}
+		fmt.Fprintf(b, "  %s%s: %s;\n", f.Name, optional, tsType(strings.TrimSuffix(f.Type, "!"), objectNames))
+	}
+	b.WriteString("}\n")
+}
+
+// tsType converts a FraiseQL type string without its outer non-null marker,
+// such as "[Post!]", to a TypeScript type. Nullable list elements become
+// `T | null` unions.
+func tsType(typeStr string, objectNames map[string]bool) string {
+	if strings.HasPrefix(typeStr, "[") && strings.HasSuffix(typeStr, "]") {
+		elem := typeStr[1 : len(typeStr)-1]
+		if strings.HasSuffix(elem, "!") {
+			return tsArray(tsType(strings.TrimSuffix(elem, "!"), objectNames))
+		}
+		return "(" + tsType(elem, objectNames) + " | null)[]"
+	}
+	if ts, ok := tsScalarTypes[typeStr]; ok {
+		return ts
+	}
+	if objectNames[typeStr] {
+		return typeStr
+	}
+	if IsScalarType(typeStr) || HasCustomScalar(typeStr) {
+		return "string"
+	}
+	return typeStr
+}
+
+// tsArray returns the array type of elem, parenthesizing unions.
+func tsArray(elem string) string {
+	if strings.Contains(elem, "|") {
+		return "(" + elem + ")[]"
+	}
+	return elem + "[]"
+}
@@ -0,0 +1,88 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportTypeScript(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	Enum("Role", map[string]string{"ADMIN": "admin", "USER": "user"})
+	if err := RegisterType("User", []FieldInfo{
+		{Name: "id", Type: "ID"},
+		{Name: "age", Type: "Int"},
+		{Name: "email", Type: "Email", Nullable: true},
+		{Name: "role", Type: "Role"},
+		{Name: "tags", Type: "[String!]"},
+		{Name: "scores", Type: "[Float]", Nullable: true},
+		{Name: "posts", Type: "[Post!]"},
+		{Name: "metadata", Type: "Json", Nullable: true},
+		{Name: "active", Type: "Boolean"},
+		{Name: "createdAt", Type: "DateTime"},
+	}, "A registered user"); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := RegisterType("Post", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+
+	ts, err := ExportTypeScript()
+	if err != nil {
+		t.Fatalf("ExportTypeScript: %v", err)
+	}
+
+	for _, want := range []string{
+		`export type Role = "ADMIN" | "USER";`,
+		"/** A registered user */\nexport interface User {\n",
+		"  id: string;\n",
+		"  age: number;\n",
+		"  email?: string;\n",
+		"  role: Role;\n",
+		"  tags: string[];\n",
+		"  scores?: (number | null)[];\n",
+		"  posts: Post[];\n",
+		"  metadata?: unknown;\n",
+		"  active: boolean;\n",
+		"  createdAt: string;\n",
+		"export interface Post {\n  id: string;\n}\n",
+	} {
+		if !strings.Contains(ts, want) {
+			t.Errorf("missing %q in:\n%s", want, ts)
+		}
+	}
+	if strings.Index(ts, "interface Post") > strings.Index(ts, "interface User") {
+		t.Error("interfaces should follow the schema's name order")
+	}
+}
+
+func TestExportTypeScriptInputTypesAndNestedLists(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterInputType(InputTypeDefinition{
+		Name: "MatrixInput",
+		Fields: []FieldInfo{
+			{Name: "rows", Type: "[[Int!]!]"},
+			{Name: "labels", Type: "[[String]]", Nullable: true},
+			{Name: "embedding", Type: "Vector"},
+		},
+	}); err != nil {
+		t.Fatalf("RegisterInputType: %v", err)
+	}
+
+	ts, err := ExportTypeScript()
+	if err != nil {
+		t.Fatalf("ExportTypeScript: %v", err)
+	}
+	for _, want := range []string{
+		"  rows: number[][];\n",
+		"  labels?: ((string | null)[] | null)[];\n",
+		"  embedding: number[];\n",
+	} {
+		if !strings.Contains(ts, want) {
+			t.Errorf("missing %q in:\n%s", want, ts)
+		}
+	}
+}
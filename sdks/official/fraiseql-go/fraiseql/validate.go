@@ -0,0 +1,195 @@
+package fraiseql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ValidationSeverity says whether a validator's findings block export.
+type ValidationSeverity int
+
+const (
+	// SeverityError findings make the schema invalid.
+	SeverityError ValidationSeverity = iota
+	// SeverityWarning findings are lints worth fixing, but do not block export.
+	SeverityWarning
+)
+
+// ValidationIssue is one finding of a validator.
+type ValidationIssue struct {
+	// Category names the validator that reported the issue, e.g. "schema",
+	// "conventions" or a plugin's category.
+	Category string `json:"category"`
+	Message  string `json:"message"`
+}
+
+// ValidationReport collects the findings of every validator, separated into
+// errors and warnings. Within each, issues are ordered by category and then
+// in the order each validator reported them.
+type ValidationReport struct {
+	Errors   []ValidationIssue `json:"errors"`
+	Warnings []ValidationIssue `json:"warnings"`
+}
+
+// HasErrors reports whether any validator found an error.
+func (r ValidationReport) HasErrors() bool {
+	return len(r.Errors) > 0
+}
+
+// ByCategory splits the report into one report per category.
+func (r ValidationReport) ByCategory() map[string]ValidationReport {
+	grouped := make(map[string]ValidationReport)
+	for _, issue := range r.Errors {
+		g := grouped[issue.Category]
+		g.Errors = append(g.Errors, issue)
+		grouped[issue.Category] = g
+	}
+	for _, issue := range r.Warnings {
+		g := grouped[issue.Category]
+		g.Warnings = append(g.Warnings, issue)
+		grouped[issue.Category] = g
+	}
+	return grouped
+}
+
+// Validator checks an assembled schema, returning one error per finding.
+type Validator func(schema Schema) []error
+
+type registeredValidator struct {
+	category  string
+	severity  ValidationSeverity
+	validator Validator
+}
+
+// validators holds the validators added with RegisterValidator.
+var validators struct {
+	mu   sync.RWMutex
+	list []registeredValidator
+}
+
+// RegisterValidator adds a validator that Validate runs after the built-in
+// ones, so plugins can contribute their own checks. Its findings are reported
+// under category with the given severity. Reset() removes all validators
+// added this way.
+//
+// Example:
+//
+//	fraiseql.RegisterValidator("naming", fraiseql.SeverityWarning, func(s fraiseql.Schema) []error {
+//		var errs []error
+//		for _, q := range s.Queries {
+//			if strings.HasPrefix(q.Name, "get") {
+//				errs = append(errs, fmt.Errorf("query %q: drop the get prefix", q.Name))
+//			}
+//		}
+//		return errs
+//	})
+func RegisterValidator(category string, severity ValidationSeverity, validator Validator) {
+	validators.mu.Lock()
+	defer validators.mu.Unlock()
+	validators.list = append(validators.list, registeredValidator{category: category, severity: severity, validator: validator})
+}
+
+// clearValidators removes all validators added with RegisterValidator.
+func clearValidators() {
+	validators.mu.Lock()
+	defer validators.mu.Unlock()
+	validators.list = nil
+}
+
+// Validate runs every validator against the registered schema and returns
+// their findings in one report:
+//   - "schema" errors: the ValidateSchema checks, which ExportSchema enforces
+//   - "conventions" warnings: the ValidateConventions checks
+//   - "orphans" warnings: the types FindOrphanTypes reports
+//   - the findings of each validator added with RegisterValidator
+//
+// See SetValidateOnExport to make ExportSchema refuse to write a schema whose
+// report has errors. A failing schema transform is reported as the only
+// "schema" error.
+func Validate() ValidationReport {
+	schema, err := buildSchema()
+	if err != nil {
+		return ValidationReport{Errors: []ValidationIssue{{Category: "schema", Message: err.Error()}}}
+	}
+	return validateReport(schema)
+}
+
+// validateReport runs the validators against an assembled schema.
+func validateReport(schema Schema) ValidationReport {
+	all := []registeredValidator{
+		{"schema", SeverityError, validateSchema},
+		{"conventions", SeverityWarning, validateConventions},
+		{"orphans", SeverityWarning, func(s Schema) []error {
+			var errs []error
+			for _, name := range findOrphanTypes(s) {
+				errs = append(errs, fmt.Errorf("%q is not reachable from any query, mutation or subscription", name))
+			}
+			return errs
+		}},
+	}
+	validators.mu.RLock()
+	all = append(all, validators.list...)
+	validators.mu.RUnlock()
+
+	var report ValidationReport
+	for _, v := range all {
+		for _, err := range v.validator(schema) {
+			issue := ValidationIssue{Category: v.category, Message: err.Error()}
+			if v.severity == SeverityWarning {
+				report.Warnings = append(report.Warnings, issue)
+			} else {
+				report.Errors = append(report.Errors, issue)
+			}
+		}
+	}
+	byCategory := func(issues []ValidationIssue) {
+		sort.SliceStable(issues, func(i, j int) bool { return issues[i].Category < issues[j].Category })
+	}
+	byCategory(report.Errors)
+	byCategory(report.Warnings)
+	return report
+}
+
+// validateOnExport holds the setting made with SetValidateOnExport.
+var validateOnExport struct {
+	mu      sync.RWMutex
+	enabled bool
+}
+
+// SetValidateOnExport makes ExportSchema run Validate, including the
+// validators added with RegisterValidator, and refuse to write a schema whose
+// report has errors. Warnings never block export. By default ExportSchema
+// runs only the ValidateSchema checks. Reset() turns it off again.
+func SetValidateOnExport(enabled bool) {
+	validateOnExport.mu.Lock()
+	defer validateOnExport.mu.Unlock()
+	validateOnExport.enabled = enabled
+}
+
+func isValidateOnExport() bool {
+	validateOnExport.mu.RLock()
+	defer validateOnExport.mu.RUnlock()
+	return validateOnExport.enabled
+}
+
+// validateReportBeforeExport returns a single error listing the report's
+// errors, if SetValidateOnExport is on and there are any.
+func validateReportBeforeExport(schema Schema) error {
+	if !isValidateOnExport() {
+		return nil
+	}
+	report := validateReport(schema)
+	if !report.HasErrors() {
+		return nil
+	}
+	msgs := make([]string, len(report.Errors))
+	for i, issue := range report.Errors {
+		msgs[i] = issue.Category + ": " + issue.Message
+	}
+	return fmt.Errorf(
+		"schema validation failed before export. Fix the following errors:\n  - %s",
+		strings.Join(msgs, "\n  - "),
+	)
+}
@@ -0,0 +1,101 @@
+package fraiseql
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateAggregatesValidators(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	// A query with an unknown return type (schema error), a type with a
+	// non-ID id (conventions warning) and an unreachable enum (orphans warning).
+	if err := NewQuery("getUsers").ReturnType("Person").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewQuery("accounts").ReturnType("Account").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := RegisterType("Account", []FieldInfo{{Name: "id", Type: "Int"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	Enum("Color", map[string]string{"RED": "red"})
+	RegisterValidator("naming", SeverityWarning, func(s Schema) []error {
+		var errs []error
+		for _, q := range s.Queries {
+			if strings.HasPrefix(q.Name, "get") {
+				errs = append(errs, fmt.Errorf("query %q: drop the get prefix", q.Name))
+			}
+		}
+		return errs
+	})
+	RegisterValidator("billing", SeverityError, func(s Schema) []error {
+		return []error{fmt.Errorf("no Invoice type")}
+	})
+
+	report := Validate()
+	wantErrors := []ValidationIssue{
+		{Category: "billing", Message: "no Invoice type"},
+		{Category: "schema", Message: `query "getUsers" has return type "Person" which is not a registered type or scalar`},
+	}
+	wantWarnings := []ValidationIssue{
+		{Category: "conventions", Message: `type "Account": field "id" has type "Int"; id fields must use ID`},
+		{Category: "naming", Message: `query "getUsers": drop the get prefix`},
+		{Category: "orphans", Message: `"Color" is not reachable from any query, mutation or subscription`},
+	}
+	if !reflect.DeepEqual(report.Errors, wantErrors) {
+		t.Errorf("Errors =\n%+v\nwant\n%+v", report.Errors, wantErrors)
+	}
+	if !reflect.DeepEqual(report.Warnings, wantWarnings) {
+		t.Errorf("Warnings =\n%+v\nwant\n%+v", report.Warnings, wantWarnings)
+	}
+	if !report.HasErrors() {
+		t.Error("HasErrors() = false")
+	}
+
+	grouped := report.ByCategory()
+	if len(grouped) != 5 {
+		t.Errorf("ByCategory() has %d categories, want 5: %+v", len(grouped), grouped)
+	}
+	if g := grouped["naming"]; len(g.Warnings) != 1 || len(g.Errors) != 0 {
+		t.Errorf(`ByCategory()["naming"] = %+v`, g)
+	}
+}
+
+func TestValidateOnExport(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	RegisterValidator("policy", SeverityError, func(s Schema) []error {
+		return []error{fmt.Errorf("type User has no access policy")}
+	})
+	RegisterValidator("style", SeverityWarning, func(s Schema) []error {
+		return []error{fmt.Errorf("just a warning")}
+	})
+	path := filepath.Join(t.TempDir(), "schema.json")
+
+	if err := ExportSchema(path); err != nil {
+		t.Fatalf("without SetValidateOnExport, plugin validators should not block export: %v", err)
+	}
+
+	SetValidateOnExport(true)
+	err := ExportSchema(path)
+	if err == nil || !strings.Contains(err.Error(), "policy: type User has no access policy") {
+		t.Errorf("error = %v, want the policy error", err)
+	}
+	if err != nil && strings.Contains(err.Error(), "just a warning") {
+		t.Errorf("warnings should not be listed as export errors: %v", err)
+	}
+
+	Reset()
+	if n := len(validators.list); n != 0 || isValidateOnExport() {
+		t.Errorf("Reset should remove validators and turn off export validation, got %d validators", n)
+	}
+}
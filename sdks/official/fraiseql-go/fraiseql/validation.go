@@ -0,0 +1,472 @@
+package fraiseql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ValidateSchema checks the registered schema for structural errors, such as
+// query or mutation return types and field types that do not refer to a
+// registered type or known scalar, names that are not legal GraphQL names or
+// start with the "__" prefix reserved for introspection, operations declaring
+// two arguments of the same name, and types that reference each other in a
+// cycle of non-null fields, which no value could satisfy. It returns every
+// problem found, or nil when the schema is valid.
+//
+// ExportSchema runs the same checks and refuses to write an invalid schema.
+// A failing schema transform is reported as the only error.
+func ValidateSchema() []error {
+	schema, err := buildSchema()
+	if err != nil {
+		return []error{err}
+	}
+	return validateSchema(schema)
+}
+
+// validateSchema collects the structural errors of an assembled schema.
+func validateSchema(schema Schema) []error {
+	registeredNames := make(map[string]struct{})
+	for _, t := range schema.Types {
+		registeredNames[t.Name] = struct{}{}
+	}
+	for _, e := range schema.Enums {
+		registeredNames[e.Name] = struct{}{}
+	}
+	for k, v := range builtinScalars {
+		registeredNames[k] = v
+	}
+	for name := range ScalarNames {
+		registeredNames[name] = struct{}{}
+	}
+	for name := range GetAllCustomScalars() {
+		registeredNames[name] = struct{}{}
+	}
+
+	var errs []error
+
+	for _, q := range schema.Queries {
+		if _, ok := registeredNames[q.ReturnType]; !ok && q.ReturnType != "" {
+			errs = append(errs, fmt.Errorf(
+				"query %q has return type %q which is not a registered type or scalar", q.Name, q.ReturnType,
+			))
+		}
+	}
+	for _, m := range schema.Mutations {
+		if _, ok := registeredNames[m.ReturnType]; !ok && m.ReturnType != "" {
+			errs = append(errs, fmt.Errorf(
+				"mutation %q has return type %q which is not a registered type or scalar", m.Name, m.ReturnType,
+			))
+		}
+	}
+
+	errs = append(errs, validateFieldTypeReferences(schema, registeredNames)...)
+	errs = append(errs, validateNames(schema)...)
+	errs = append(errs, validateEnumDefaults(schema)...)
+	errs = append(errs, validateNonNullCycles(schema)...)
+	return append(errs, validateDirectiveUsages(schema)...)
+}
+
+// validateNames checks that every type, field, enum value, operation and
+// argument name is a legal GraphQL name and not one of the "__" names
+// reserved for introspection, and that no operation repeats an argument name.
+func validateNames(schema Schema) []error {
+	var errs []error
+	check := func(element, name string) {
+		switch {
+		case !graphQLNamePattern.MatchString(name):
+			errs = append(errs, fmt.Errorf("%s has invalid name %q: names must match %s", element, name, graphQLNamePattern))
+		case strings.HasPrefix(name, "__"):
+			errs = append(errs, fmt.Errorf("%s has reserved name %q: names starting with \"__\" are reserved for introspection", element, name))
+		}
+	}
+	checkArgs := func(element string, args []ArgumentDefinition) {
+		seen := make(map[string]int, len(args))
+		for _, a := range args {
+			check(fmt.Sprintf("%s argument", element), a.Name)
+			if seen[a.Name]++; seen[a.Name] == 2 {
+				errs = append(errs, fmt.Errorf("%s has more than one argument named %q", element, a.Name))
+			}
+		}
+	}
+
+	for _, t := range schema.Types {
+		check("type", t.Name)
+		for _, f := range t.Fields {
+			check(fmt.Sprintf("type %q field", t.Name), f.Name)
+		}
+	}
+	for _, in := range schema.InputTypes {
+		check("input type", in.Name)
+		for _, f := range in.Fields {
+			check(fmt.Sprintf("input type %q field", in.Name), f.Name)
+		}
+	}
+	for _, e := range schema.Enums {
+		check("enum", e.Name)
+		for _, v := range e.Values {
+			check(fmt.Sprintf("enum %q value", e.Name), v.Name)
+			if v.Name == "true" || v.Name == "false" || v.Name == "null" {
+				errs = append(errs, fmt.Errorf("enum %q value has invalid name %q", e.Name, v.Name))
+			}
+		}
+	}
+	for _, q := range schema.Queries {
+		check("query", q.Name)
+		checkArgs(fmt.Sprintf("query %q", q.Name), q.Arguments)
+	}
+	for _, m := range schema.Mutations {
+		check("mutation", m.Name)
+		checkArgs(fmt.Sprintf("mutation %q", m.Name), m.Arguments)
+	}
+	for _, s := range schema.Subscriptions {
+		check("subscription", s.Name)
+		checkArgs(fmt.Sprintf("subscription %q", s.Name), s.Arguments)
+	}
+	return errs
+}
+
+// validateEnumDefaults checks that each enum-typed input field default is one
+// of the enum's values.
+func validateEnumDefaults(schema Schema) []error {
+	enums := make(map[string]EnumDefinition, len(schema.Enums))
+	for _, e := range schema.Enums {
+		enums[e.Name] = e
+	}
+
+	var errs []error
+	for _, in := range schema.InputTypes {
+		for _, f := range in.Fields {
+			enum, isEnum := enums[namedType(f.Type)]
+			if f.Default == nil || !isEnum {
+				continue
+			}
+			valid := false
+			for _, v := range enum.Values {
+				if f.Default == v.Name {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				errs = append(errs, fmt.Errorf(
+					"input type %q: field %q has default %v, which is not a value of enum %s",
+					in.Name, f.Name, f.Default, enum.Name,
+				))
+			}
+		}
+	}
+	return errs
+}
+
+// validateNonNullCycles reports each cycle of object types, or of input
+// types, that reference one another through non-null, non-list fields: no
+// value of such a type can be built, since each needs the next to exist first.
+// A nullable field or a list, which may be empty, breaks the cycle.
+func validateNonNullCycles(schema Schema) []error {
+	types := make(map[string][]FieldInfo, len(schema.Types))
+	for _, t := range schema.Types {
+		types[t.Name] = t.Fields
+	}
+	inputs := make(map[string][]FieldInfo, len(schema.InputTypes))
+	for _, in := range schema.InputTypes {
+		inputs[in.Name] = in.Fields
+	}
+
+	var errs []error
+	for _, group := range []struct {
+		kind  string
+		types map[string][]FieldInfo
+	}{{"type", types}, {"input type", inputs}} {
+		for _, cycle := range nonNullCycles(group.types) {
+			errs = append(errs, fmt.Errorf(
+				"%s cycle %s through non-null fields can never be satisfied; make one of these fields nullable",
+				group.kind, strings.Join(cycle, " -> "),
+			))
+		}
+	}
+	return errs
+}
+
+// nonNullCycles finds the cycles among types whose edges are non-null,
+// non-list fields, one per strongly connected group of types. Each cycle is
+// the path of "Type.field" steps from the group's alphabetically first type
+// back to it, e.g. ["A.b", "B.a", "A"].
+func nonNullCycles(types map[string][]FieldInfo) [][]string {
+	type edge struct{ field, target string }
+	names := sortedKeys(types)
+	edges := make(map[string][]edge, len(types))
+	for _, name := range names {
+		for _, f := range types[name] {
+			target := namedType(f.Type)
+			nonNull := !f.Nullable || strings.HasSuffix(f.Type, "!")
+			if _, isType := types[target]; isType && nonNull && !strings.HasPrefix(f.Type, "[") {
+				edges[name] = append(edges[name], edge{field: f.Name, target: target})
+			}
+		}
+	}
+
+	// Tarjan's algorithm groups the types into strongly connected components.
+	index := make(map[string]int, len(names))
+	lowLink := make(map[string]int, len(names))
+	onStack := make(map[string]bool, len(names))
+	var stack []string
+	var components [][]string
+	var connect func(name string)
+	connect = func(name string) {
+		index[name] = len(index)
+		lowLink[name] = index[name]
+		stack = append(stack, name)
+		onStack[name] = true
+		for _, e := range edges[name] {
+			if _, visited := index[e.target]; !visited {
+				connect(e.target)
+				lowLink[name] = min(lowLink[name], lowLink[e.target])
+			} else if onStack[e.target] {
+				lowLink[name] = min(lowLink[name], index[e.target])
+			}
+		}
+		if lowLink[name] != index[name] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == name {
+				break
+			}
+		}
+		components = append(components, component)
+	}
+	for _, name := range names {
+		if _, visited := index[name]; !visited {
+			connect(name)
+		}
+	}
+
+	var cycles [][]string
+	for _, component := range components {
+		members := make(map[string]bool, len(component))
+		for _, name := range component {
+			members[name] = true
+		}
+		sort.Strings(component)
+		start := component[0]
+
+		// A breadth-first search within the component finds the shortest
+		// way from start back to itself.
+		type step struct {
+			prev string
+			via  string
+		}
+		from := map[string]step{}
+		queue := []string{start}
+		found := false
+		for len(queue) > 0 && !found {
+			name := queue[0]
+			queue = queue[1:]
+			for _, e := range edges[name] {
+				if !members[e.target] {
+					continue
+				}
+				if e.target == start {
+					from[start] = step{prev: name, via: e.field}
+					found = true
+					break
+				}
+				if _, reached := from[e.target]; !reached {
+					from[e.target] = step{prev: name, via: e.field}
+					queue = append(queue, e.target)
+				}
+			}
+		}
+		if !found {
+			continue // a single type without a self-reference
+		}
+
+		cycle := []string{start}
+		for name := start; ; {
+			s := from[name]
+			cycle = append([]string{s.prev + "." + s.via}, cycle...)
+			if name = s.prev; name == start {
+				break
+			}
+		}
+		cycles = append(cycles, cycle)
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][len(cycles[i])-1] < cycles[j][len(cycles[j])-1] })
+	return cycles
+}
+
+// FindOrphanTypes returns the names of registered types, input types and enums
+// that no query, mutation, subscription or aggregate query argument can reach,
+// directly or through field, argument and interface references. The result is
+// sorted.
+//
+// Orphans are usually a mistake, but some types are registered on purpose for
+// federation or extension, so this is a lint rather than a validation error and
+// ExportSchema does not check it. Error types are never reported: mutations
+// surface them without a field reference.
+// Returns an error if a schema transform fails.
+func FindOrphanTypes() ([]string, error) {
+	schema, err := buildSchema()
+	if err != nil {
+		return nil, err
+	}
+	return findOrphanTypes(schema), nil
+}
+
+// findOrphanTypes lists the orphans of an assembled schema.
+func findOrphanTypes(schema Schema) []string {
+	reached := reachableTypes(schema)
+
+	var orphans []string
+	for _, t := range schema.Types {
+		if !reached[t.Name] && !t.IsError {
+			orphans = append(orphans, t.Name)
+		}
+	}
+	for _, in := range schema.InputTypes {
+		if !reached[in.Name] {
+			orphans = append(orphans, in.Name)
+		}
+	}
+	for _, e := range schema.Enums {
+		if !reached[e.Name] {
+			orphans = append(orphans, e.Name)
+		}
+	}
+	sort.Strings(orphans)
+	return orphans
+}
+
+// reachableTypes returns the names of every type, input type and enum reachable
+// from the schema's root operations and aggregate query arguments, following
+// field types, argument types and interfaces transitively. Reaching an
+// interface also reaches the types that implement it, since any of them can be
+// returned through it.
+func reachableTypes(schema Schema) map[string]bool {
+	typeByName := make(map[string]TypeDefinition, len(schema.Types))
+	implementors := make(map[string][]string)
+	for _, t := range schema.Types {
+		typeByName[t.Name] = t
+		for _, iface := range t.Implements {
+			implementors[iface] = append(implementors[iface], t.Name)
+		}
+	}
+	inputByName := make(map[string]InputTypeDefinition, len(schema.InputTypes))
+	for _, in := range schema.InputTypes {
+		inputByName[in.Name] = in
+	}
+
+	reached := make(map[string]bool)
+	var visit func(typeStr string)
+	visit = func(typeStr string) {
+		name := namedType(typeStr)
+		if name == "" || reached[name] {
+			return
+		}
+		reached[name] = true
+		if t, ok := typeByName[name]; ok {
+			for _, f := range t.Fields {
+				visit(f.Type)
+			}
+			for _, iface := range t.Implements {
+				visit(iface)
+			}
+		}
+		for _, impl := range implementors[name] {
+			visit(impl)
+		}
+		if in, ok := inputByName[name]; ok {
+			for _, f := range in.Fields {
+				visit(f.Type)
+			}
+		}
+	}
+	visitArgs := func(args []ArgumentDefinition) {
+		for _, a := range args {
+			visit(a.Type)
+		}
+	}
+
+	for _, q := range schema.Queries {
+		visit(q.ReturnType)
+		visitArgs(q.Arguments)
+	}
+	for _, m := range schema.Mutations {
+		visit(m.ReturnType)
+		visitArgs(m.Arguments)
+	}
+	for _, s := range schema.Subscriptions {
+		visit(s.EntityType)
+		visitArgs(s.Arguments)
+	}
+	for _, a := range schema.AggregateQueries {
+		visitArgs(a.Arguments)
+	}
+	return reached
+}
+
+// ValidateConventions checks the registered types against the FraiseQL
+// authoring conventions documented on the scalar types:
+//   - an `id` field is typed ID
+//   - a foreign-key field (e.g. `authorId`, `author_id`) is typed ID
+//   - a Relay type declares an `id` field
+//   - a primary-key field (`primaryKey=true`) is typed ID
+//
+// Unlike ValidateSchema, convention violations do not block ExportSchema.
+// It returns every violation found, or nil when the schema follows the conventions.
+// A failing schema transform is reported as the only error.
+func ValidateConventions() []error {
+	schema, err := buildSchema()
+	if err != nil {
+		return []error{err}
+	}
+	return validateConventions(schema)
+}
+
+// validateConventions collects the convention violations of an assembled schema.
+func validateConventions(schema Schema) []error {
+	var errs []error
+	for _, t := range schema.Types {
+		hasID := false
+		for _, f := range t.Fields {
+			switch {
+			case strings.EqualFold(f.Name, "id"):
+				hasID = true
+				if f.Type != "ID" {
+					errs = append(errs, fmt.Errorf(
+						"type %q: field %q has type %q; id fields must use ID", t.Name, f.Name, f.Type,
+					))
+				}
+			case isForeignKeyName(f.Name) && f.Type != "ID":
+				errs = append(errs, fmt.Errorf(
+					"type %q: foreign key field %q has type %q; foreign keys must use ID", t.Name, f.Name, f.Type,
+				))
+			case f.PrimaryKey && f.Type != "ID":
+				errs = append(errs, fmt.Errorf(
+					"type %q: primary key field %q has type %q; primary keys must use ID", t.Name, f.Name, f.Type,
+				))
+			}
+		}
+		if t.Relay && !hasID {
+			errs = append(errs, fmt.Errorf(
+				"type %q is a Relay type but has no id field", t.Name,
+			))
+		}
+	}
+	return errs
+}
+
+// isForeignKeyName reports whether a field name follows the foreign-key naming
+// pattern: a camelCase "Id" suffix (authorId) or a snake_case "_id" suffix (author_id).
+func isForeignKeyName(name string) bool {
+	if len(name) <= 2 {
+		return false
+	}
+	return strings.HasSuffix(name, "Id") || strings.HasSuffix(name, "_id")
+}
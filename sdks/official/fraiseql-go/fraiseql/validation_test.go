@@ -0,0 +1,305 @@
+package fraiseql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSchemaUnknownReturnType(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	if err := NewQuery("users").ReturnType("User").ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewMutation("createPost").ReturnType("Post").Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	errs := ValidateSchema()
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %d: %v", len(errs), errs)
+	}
+
+	if err := RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if err := RegisterType("Post", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+		t.Fatalf("RegisterType: %v", err)
+	}
+	if errs := ValidateSchema(); errs != nil {
+		t.Errorf("expected no errors once types are registered, got %v", errs)
+	}
+}
+
+func TestValidateConventions(t *testing.T) {
+	t.Run("conforming type", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		if err := RegisterType("Post", []FieldInfo{
+			{Name: "id", Type: "ID"},
+			{Name: "authorId", Type: "ID"},
+			{Name: "title", Type: "String"},
+		}, "", true); err != nil {
+			t.Fatalf("RegisterType: %v", err)
+		}
+		if errs := ValidateConventions(); errs != nil {
+			t.Errorf("expected no convention errors, got %v", errs)
+		}
+	})
+
+	t.Run("non-ID id and foreign key", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		if err := RegisterType("Post", []FieldInfo{
+			{Name: "id", Type: "Int"},
+			{Name: "author_id", Type: "String"},
+		}, ""); err != nil {
+			t.Fatalf("RegisterType: %v", err)
+		}
+		errs := ValidateConventions()
+		if len(errs) != 2 {
+			t.Fatalf("expected 2 convention errors, got %d: %v", len(errs), errs)
+		}
+		if !strings.Contains(errs[1].Error(), "foreign key") {
+			t.Errorf("expected foreign key error, got %v", errs[1])
+		}
+	})
+
+	t.Run("relay type without id", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		if err := RegisterType("Event", []FieldInfo{{Name: "name", Type: "String"}}, "", true); err != nil {
+			t.Fatalf("RegisterType: %v", err)
+		}
+		errs := ValidateConventions()
+		if len(errs) != 1 || !strings.Contains(errs[0].Error(), "Relay") {
+			t.Errorf("expected a Relay id error, got %v", errs)
+		}
+	})
+
+	t.Run("primary key not typed ID", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		if err := RegisterType("Country", []FieldInfo{
+			{Name: "code", Type: "String", PrimaryKey: true},
+			{Name: "name", Type: "String", Unique: true},
+		}, ""); err != nil {
+			t.Fatalf("RegisterType: %v", err)
+		}
+		errs := ValidateConventions()
+		if len(errs) != 1 || !strings.Contains(errs[0].Error(), `primary key field "code" has type "String"`) {
+			t.Errorf("expected a primary key type error, got %v", errs)
+		}
+	})
+}
+
+func TestFindOrphanTypes(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	mustRegisterType := func(name string, fields []FieldInfo) {
+		t.Helper()
+		if err := RegisterType(name, fields, ""); err != nil {
+			t.Fatalf("RegisterType(%s): %v", name, err)
+		}
+	}
+	mustRegisterType("User", []FieldInfo{
+		{Name: "id", Type: "ID"},
+		{Name: "posts", Type: "[Post!]"},
+		{Name: "role", Type: "Role"},
+	})
+	mustRegisterType("Post", []FieldInfo{{Name: "id", Type: "ID"}})
+	mustRegisterType("Node", []FieldInfo{{Name: "id", Type: "ID"}})
+	mustRegisterType("LegacyReport", []FieldInfo{{Name: "id", Type: "ID"}})
+	Enum("Role", map[string]string{"ADMIN": "admin"})
+	Enum("Unused", map[string]string{"X": "x"})
+	if err := RegisterErrorType("NotFound", []FieldInfo{{Name: "message", Type: "String"}}, ""); err != nil {
+		t.Fatalf("RegisterErrorType: %v", err)
+	}
+	if err := NewInputType("UserFilter").Field("email", "String", true).Register(); err != nil {
+		t.Fatalf("RegisterInputType: %v", err)
+	}
+	if err := NewInputType("StaleInput").Field("x", "String", true).Register(); err != nil {
+		t.Fatalf("RegisterInputType: %v", err)
+	}
+
+	// Comment is reachable only because it implements the Node interface
+	// returned by the node query.
+	reg := getInstance()
+	reg.mu.Lock()
+	reg.types["Comment"] = TypeDefinition{
+		Name:       "Comment",
+		Fields:     []FieldInfo{{Name: "id", Type: "ID"}},
+		Implements: []string{"Node"},
+	}
+	reg.mu.Unlock()
+
+	if err := NewQuery("users").ReturnType("User").ReturnsArray(true).Arg("where", "UserFilter", nil, true).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := NewQuery("node").ReturnType("Node").Nullable(true).Arg("id", "ID", nil).Register(); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	got, err := FindOrphanTypes()
+	if err != nil {
+		t.Fatalf("FindOrphanTypes: %v", err)
+	}
+	want := []string{"LegacyReport", "StaleInput", "Unused"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("FindOrphanTypes: want %v, got %v", want, got)
+	}
+}
+
+func TestValidateSchemaNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func() error
+		wantErr string
+	}{
+		{
+			name: "reserved type name",
+			setup: func() error {
+				return RegisterType("__Internal", []FieldInfo{{Name: "id", Type: "ID"}}, "")
+			},
+			wantErr: `type has reserved name "__Internal"`,
+		},
+		{
+			name: "field name with a space",
+			setup: func() error {
+				return RegisterType("User", []FieldInfo{{Name: "first name", Type: "String"}}, "")
+			},
+			wantErr: `type "User" field has invalid name "first name"`,
+		},
+		{
+			name: "reserved argument name",
+			setup: func() error {
+				if err := RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+					return err
+				}
+				return NewQuery("user").ReturnType("User").Arg("__id", "ID", nil).Register()
+			},
+			wantErr: `query "user" argument has reserved name "__id"`,
+		},
+		{
+			name: "duplicate argument name",
+			setup: func() error {
+				if err := RegisterType("User", []FieldInfo{{Name: "id", Type: "ID"}}, ""); err != nil {
+					return err
+				}
+				return RegisterQuery(QueryDefinition{
+					Name:       "user",
+					ReturnType: "User",
+					Arguments:  []ArgumentDefinition{{Name: "id", Type: "ID"}, {Name: "id", Type: "UUID"}},
+				})
+			},
+			wantErr: `query "user" has more than one argument named "id"`,
+		},
+		{
+			name: "enum value true",
+			setup: func() error {
+				Enum("Flag", map[string]string{"true": "t"})
+				return nil
+			},
+			wantErr: `enum "Flag" value has invalid name "true"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			if err := tt.setup(); err != nil {
+				t.Fatalf("setup: %v", err)
+			}
+			errs := ValidateSchema()
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Errorf("expected one error containing %q, got %v", tt.wantErr, errs)
+			}
+		})
+	}
+}
+
+func TestValidateSchemaNonNullCycles(t *testing.T) {
+	register := func(t *testing.T, name string, fields ...FieldInfo) {
+		t.Helper()
+		if err := RegisterType(name, fields, ""); err != nil {
+			t.Fatalf("RegisterType(%s): %v", name, err)
+		}
+	}
+	cycleErrors := func() []string {
+		var msgs []string
+		for _, err := range ValidateSchema() {
+			if strings.Contains(err.Error(), "through non-null fields") {
+				msgs = append(msgs, err.Error())
+			}
+		}
+		return msgs
+	}
+
+	t.Run("non-null cycle", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		register(t, "User", FieldInfo{Name: "id", Type: "ID"}, FieldInfo{Name: "profile", Type: "Profile"})
+		register(t, "Profile", FieldInfo{Name: "id", Type: "ID"}, FieldInfo{Name: "owner", Type: "User"})
+
+		msgs := cycleErrors()
+		if len(msgs) != 1 {
+			t.Fatalf("expected 1 cycle error, got %v", msgs)
+		}
+		want := `type cycle Profile.owner -> User.profile -> Profile through non-null fields can never be satisfied; make one of these fields nullable`
+		if msgs[0] != want {
+			t.Errorf("error = %q, want %q", msgs[0], want)
+		}
+	})
+
+	t.Run("self reference", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		register(t, "Category", FieldInfo{Name: "parent", Type: "Category"})
+
+		msgs := cycleErrors()
+		if len(msgs) != 1 || !strings.Contains(msgs[0], "Category.parent -> Category") {
+			t.Errorf("expected the Category self-reference, got %v", msgs)
+		}
+	})
+
+	t.Run("input type cycle", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		if err := RegisterInputType(InputTypeDefinition{Name: "AInput", Fields: []FieldInfo{{Name: "b", Type: "BInput"}}}); err != nil {
+			t.Fatalf("RegisterInputType: %v", err)
+		}
+		if err := RegisterInputType(InputTypeDefinition{Name: "BInput", Fields: []FieldInfo{{Name: "a", Type: "AInput!"}}}); err != nil {
+			t.Fatalf("RegisterInputType: %v", err)
+		}
+
+		msgs := cycleErrors()
+		if len(msgs) != 1 || !strings.HasPrefix(msgs[0], "input type cycle AInput.b -> BInput.a -> AInput") {
+			t.Errorf("expected the input type cycle, got %v", msgs)
+		}
+	})
+
+	t.Run("nullable and list edges break the cycle", func(t *testing.T) {
+		Reset()
+		defer Reset()
+
+		register(t, "Employee", FieldInfo{Name: "id", Type: "ID"}, FieldInfo{Name: "team", Type: "Team"})
+		register(t, "Team", FieldInfo{Name: "id", Type: "ID"}, FieldInfo{Name: "lead", Type: "Employee", Nullable: true},
+			FieldInfo{Name: "members", Type: "[Employee!]"})
+		register(t, "Node", FieldInfo{Name: "children", Type: "[Node!]"}, FieldInfo{Name: "parent", Type: "Node", Nullable: true})
+
+		if msgs := cycleErrors(); msgs != nil {
+			t.Errorf("expected no cycle errors, got %v", msgs)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package fraiseql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// vectorEmbeddingArg is the argument carrying the query embedding for
+// NearestNeighbors.
+const vectorEmbeddingArg = "embedding"
+
+// vectorMetricOperators maps a similarity metric to its pgvector distance operator.
+var vectorMetricOperators = map[string]string{
+	"cosine": "<=>",
+	"l2":     "<->",
+	"inner":  "<#>",
+}
+
+// vectorSearch is the similarity search configured by NearestNeighbors.
+type vectorSearch struct {
+	field  string
+	metric string
+	limit  int
+}
+
+// NearestNeighbors turns the query into a pgvector similarity search over the
+// given Vector field of the return type. The compiler orders rows by the
+// metric's distance to the "embedding" argument and limits the result:
+//
+//	ORDER BY field <=> $embedding LIMIT limit
+//
+// metric is one of "cosine" (<=>), "l2" (<->) or "inner" (<#>). The
+// "embedding" argument is declared as a required Vector unless it was already
+// added with Arg. Register checks that field is Vector-typed on the return
+// type and that the query returns a list.
+//
+// Example:
+//
+//	fraiseql.NewQuery("similarDocuments").
+//		ReturnType(Document{}).
+//		ReturnsArray(true).
+//		NearestNeighbors("embedding", "cosine", 10).
+//		Register()
+func (qb *QueryBuilder) NearestNeighbors(field, metric string, limit int) *QueryBuilder {
+	if qb.argIndex(vectorEmbeddingArg) < 0 {
+		qb.addArg(vectorEmbeddingArg, "Vector", nil)
+	}
+	qb.vectorSearch = &vectorSearch{field: field, metric: metric, limit: limit}
+	return qb
+}
+
+// validateVectorSearch checks the NearestNeighbors configuration, if any.
+func (qb *QueryBuilder) validateVectorSearch() error {
+	vs := qb.vectorSearch
+	if vs == nil {
+		return nil
+	}
+	if _, ok := vectorMetricOperators[vs.metric]; !ok {
+		metrics := make([]string, 0, len(vectorMetricOperators))
+		for m := range vectorMetricOperators {
+			metrics = append(metrics, m)
+		}
+		sort.Strings(metrics)
+		return fmt.Errorf(
+			"query %q: unknown vector metric %q; must be one of %s",
+			qb.name, vs.metric, strings.Join(metrics, ", "),
+		)
+	}
+	if vs.limit <= 0 {
+		return fmt.Errorf("query %q: NearestNeighbors limit must be positive, got %d", qb.name, vs.limit)
+	}
+	if !qb.returnsList {
+		return fmt.Errorf("query %q: NearestNeighbors requires ReturnsArray(true)", qb.name)
+	}
+	if err := qb.requireReturnFieldType("vector searches", vs.field, "Vector"); err != nil {
+		return err
+	}
+	arg := qb.arguments[qb.argIndex(vectorEmbeddingArg)]
+	if namedType(arg.Type) != "Vector" {
+		return fmt.Errorf(
+			"query %q: argument %q must be of type Vector, got %s",
+			qb.name, vectorEmbeddingArg, arg.Type,
+		)
+	}
+	return nil
+}
+
+// vectorSearchConfig returns the "vector_search" config value.
+func (qb *QueryBuilder) vectorSearchConfig() map[string]interface{} {
+	vs := qb.vectorSearch
+	return map[string]interface{}{
+		"field":    vs.field,
+		"metric":   vs.metric,
+		"operator": vectorMetricOperators[vs.metric],
+		"arg":      vectorEmbeddingArg,
+		"limit":    vs.limit,
+	}
+}
@@ -0,0 +1,140 @@
+package fraiseql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// embeddedDocument is a document with a pgvector embedding column.
+type embeddedDocument struct {
+	ID        ID     `fraiseql:"id"`
+	Title     string `fraiseql:"title"`
+	Embedding Vector `fraiseql:"embedding,type=Vector"`
+}
+
+func TestNearestNeighborsConfig(t *testing.T) {
+	tests := []struct {
+		metric       string
+		wantOperator string
+	}{
+		{"cosine", "<=>"},
+		{"l2", "<->"},
+		{"inner", "<#>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.metric, func(t *testing.T) {
+			Reset()
+			defer Reset()
+
+			if err := RegisterTypes(embeddedDocument{}); err != nil {
+				t.Fatalf("RegisterTypes: %v", err)
+			}
+			if err := NewQuery("similarDocuments").
+				ReturnType(embeddedDocument{}).
+				ReturnsArray(true).
+				NearestNeighbors("embedding", tt.metric, 10).
+				Register(); err != nil {
+				t.Fatalf("Register: %v", err)
+			}
+
+			data, err := GetSchemaJSON(false)
+			if err != nil {
+				t.Fatalf("GetSchemaJSON: %v", err)
+			}
+			var exported struct {
+				Queries []struct {
+					Arguments []ArgumentDefinition `json:"arguments"`
+					Config    struct {
+						VectorSearch struct {
+							Field    string `json:"field"`
+							Metric   string `json:"metric"`
+							Operator string `json:"operator"`
+							Arg      string `json:"arg"`
+							Limit    int    `json:"limit"`
+						} `json:"vector_search"`
+					} `json:"config"`
+				} `json:"queries"`
+			}
+			if err := json.Unmarshal(data, &exported); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			q := exported.Queries[0]
+
+			vs := q.Config.VectorSearch
+			if vs.Field != "embedding" || vs.Metric != tt.metric || vs.Operator != tt.wantOperator ||
+				vs.Arg != "embedding" || vs.Limit != 10 {
+				t.Errorf("unexpected vector_search config: %+v", vs)
+			}
+			if len(q.Arguments) != 1 || q.Arguments[0].Name != "embedding" ||
+				q.Arguments[0].Type != "Vector" || q.Arguments[0].Nullable {
+				t.Errorf("expected a required Vector embedding argument, got %+v", q.Arguments)
+			}
+		})
+	}
+}
+
+func TestNearestNeighborsValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func() *QueryBuilder
+		wantErr string
+	}{
+		{
+			name: "unknown metric",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(embeddedDocument{}).ReturnsArray(true).
+					NearestNeighbors("embedding", "hamming", 5)
+			},
+			wantErr: `unknown vector metric "hamming"; must be one of cosine, inner, l2`,
+		},
+		{
+			name: "non-positive limit",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(embeddedDocument{}).ReturnsArray(true).
+					NearestNeighbors("embedding", "cosine", 0)
+			},
+			wantErr: "limit must be positive",
+		},
+		{
+			name: "single result",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(embeddedDocument{}).NearestNeighbors("embedding", "cosine", 5)
+			},
+			wantErr: "requires ReturnsArray(true)",
+		},
+		{
+			name: "field is not Vector",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(embeddedDocument{}).ReturnsArray(true).
+					NearestNeighbors("title", "l2", 5)
+			},
+			wantErr: `field "title" on type "embeddedDocument" is String`,
+		},
+		{
+			name: "embedding argument is not Vector",
+			build: func() *QueryBuilder {
+				return NewQuery("q").ReturnType(embeddedDocument{}).ReturnsArray(true).
+					Arg("embedding", "String", nil).
+					NearestNeighbors("embedding", "l2", 5)
+			},
+			wantErr: `argument "embedding" must be of type Vector`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Reset()
+			defer Reset()
+			if err := RegisterTypes(embeddedDocument{}); err != nil {
+				t.Fatalf("RegisterTypes: %v", err)
+			}
+
+			err := tt.build().Register()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package fraiseql
+
+// SchemaVisitor is called by Walk for each element of the schema. Embed
+// BaseVisitor to implement only the methods you need.
+type SchemaVisitor interface {
+	VisitEnum(enum EnumDefinition) error
+	VisitType(typ TypeDefinition) error
+	VisitInputType(input InputTypeDefinition) error
+	// VisitField is called for each field of a type or input type, after the
+	// VisitType or VisitInputType call for its owner.
+	VisitField(owner string, field FieldInfo) error
+	VisitQuery(query QueryDefinition) error
+	VisitMutation(mutation MutationDefinition) error
+	VisitSubscription(subscription SubscriptionDefinition) error
+}
+
+// BaseVisitor implements every SchemaVisitor method as a no-op.
+type BaseVisitor struct{}
+
+func (BaseVisitor) VisitEnum(EnumDefinition) error                 { return nil }
+func (BaseVisitor) VisitType(TypeDefinition) error                 { return nil }
+func (BaseVisitor) VisitInputType(InputTypeDefinition) error       { return nil }
+func (BaseVisitor) VisitField(string, FieldInfo) error             { return nil }
+func (BaseVisitor) VisitQuery(QueryDefinition) error               { return nil }
+func (BaseVisitor) VisitMutation(MutationDefinition) error         { return nil }
+func (BaseVisitor) VisitSubscription(SubscriptionDefinition) error { return nil }
+
+// Walk traverses the assembled schema, as exported, calling visitor for each
+// element: enums, then types and input types (each followed by its fields),
+// then queries, mutations and subscriptions. Within each kind elements are
+// visited in name order and fields in declaration order.
+//
+// The first error returned by the visitor, or by a schema transform, stops
+// the walk and is returned.
+func Walk(visitor SchemaVisitor) error {
+	schema, err := buildSchema()
+	if err != nil {
+		return err
+	}
+	return walkSchema(schema, visitor)
+}
+
+func walkSchema(schema Schema, visitor SchemaVisitor) error {
+	for _, e := range schema.Enums {
+		if err := visitor.VisitEnum(e); err != nil {
+			return err
+		}
+	}
+	for _, t := range schema.Types {
+		if err := visitor.VisitType(t); err != nil {
+			return err
+		}
+		if err := walkFields(t.Name, t.Fields, visitor); err != nil {
+			return err
+		}
+	}
+	for _, in := range schema.InputTypes {
+		if err := visitor.VisitInputType(in); err != nil {
+			return err
+		}
+		if err := walkFields(in.Name, in.Fields, visitor); err != nil {
+			return err
+		}
+	}
+	for _, q := range schema.Queries {
+		if err := visitor.VisitQuery(q); err != nil {
+			return err
+		}
+	}
+	for _, m := range schema.Mutations {
+		if err := visitor.VisitMutation(m); err != nil {
+			return err
+		}
+	}
+	for _, s := range schema.Subscriptions {
+		if err := visitor.VisitSubscription(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkFields(owner string, fields []FieldInfo, visitor SchemaVisitor) error {
+	for _, f := range fields {
+		if err := visitor.VisitField(owner, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
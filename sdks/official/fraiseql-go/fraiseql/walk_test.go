@@ -0,0 +1,94 @@
+package fraiseql
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// recordingVisitor records each visited node as "kind:name".
+type recordingVisitor struct {
+	BaseVisitor
+	visited []string
+	stopAt  string
+}
+
+func (v *recordingVisitor) record(node string) error {
+	v.visited = append(v.visited, node)
+	if node == v.stopAt {
+		return errors.New("stop")
+	}
+	return nil
+}
+
+func (v *recordingVisitor) VisitEnum(e EnumDefinition) error { return v.record("enum:" + e.Name) }
+func (v *recordingVisitor) VisitType(t TypeDefinition) error { return v.record("type:" + t.Name) }
+func (v *recordingVisitor) VisitInputType(in InputTypeDefinition) error {
+	return v.record("input:" + in.Name)
+}
+func (v *recordingVisitor) VisitField(owner string, f FieldInfo) error {
+	return v.record("field:" + owner + "." + f.Name)
+}
+func (v *recordingVisitor) VisitQuery(q QueryDefinition) error { return v.record("query:" + q.Name) }
+func (v *recordingVisitor) VisitMutation(m MutationDefinition) error {
+	return v.record("mutation:" + m.Name)
+}
+
+func registerWalkFixture(t *testing.T) {
+	t.Helper()
+	type User struct {
+		ID   ID     `fraiseql:"id"`
+		Name string `fraiseql:"name"`
+	}
+	Enum("Role", map[string]string{"ADMIN": "admin"})
+	if err := RegisterTypes(User{}); err != nil {
+		t.Fatalf("RegisterTypes: %v", err)
+	}
+	if err := NewInputType("UserInput").Field("name", "String").Register(); err != nil {
+		t.Fatalf("Register input: %v", err)
+	}
+	if err := NewQuery("users").ReturnType(User{}).ReturnsArray(true).Register(); err != nil {
+		t.Fatalf("Register query: %v", err)
+	}
+	if err := NewMutation("createUser").ReturnType(User{}).Register(); err != nil {
+		t.Fatalf("Register mutation: %v", err)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	Reset()
+	defer Reset()
+	registerWalkFixture(t)
+
+	v := &recordingVisitor{}
+	if err := Walk(v); err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	want := []string{
+		"enum:Role",
+		"type:User",
+		"field:User.id",
+		"field:User.name",
+		"input:UserInput",
+		"field:UserInput.name",
+		"query:users",
+		"mutation:createUser",
+	}
+	if !reflect.DeepEqual(v.visited, want) {
+		t.Errorf("visited: want %v, got %v", want, v.visited)
+	}
+}
+
+func TestWalkStopsOnError(t *testing.T) {
+	Reset()
+	defer Reset()
+	registerWalkFixture(t)
+
+	v := &recordingVisitor{stopAt: "field:User.id"}
+	if err := Walk(v); err == nil || err.Error() != "stop" {
+		t.Fatalf("expected the visitor's error, got %v", err)
+	}
+	if len(v.visited) != 3 {
+		t.Errorf("expected the walk to stop after 3 nodes, got %v", v.visited)
+	}
+}